@@ -0,0 +1,158 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"math/rand"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/apitesting/fuzzer"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metafuzzer "k8s.io/apimachinery/pkg/apis/meta/fuzzer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/diff"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	ramendrv1beta1 "github.com/ramendr/ramen/api/v1beta1"
+)
+
+const roundTripIterations = 100
+
+type roundTripObject interface {
+	runtime.Object
+	metav1.Object
+}
+
+// testRoundTrip fuzzes the spoke (v1alpha1) and hub (v1beta1) versions of a
+// resource, and checks that converting each of them to the other version and
+// back results in the original resource
+func testRoundTrip(t *testing.T, newSpoke func() conversion.Convertible, newHub func() conversion.Hub) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ramendrv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	f := fuzzer.FuzzerFor(metafuzzer.Funcs, rand.NewSource(rand.Int63()), // nolint:gosec
+		serializer.NewCodecFactory(scheme))
+
+	for i := 0; i < roundTripIterations; i++ {
+		spoke := newSpoke()
+		f.Fuzz(spoke)
+		spoke.GetObjectKind().SetGroupVersionKind(GroupVersion.WithKind(""))
+
+		hub := newHub()
+		if err := spoke.ConvertTo(hub); err != nil {
+			t.Fatalf("failed to convert %T to %T: %v", spoke, hub, err)
+		}
+
+		spokeAfter := newSpoke()
+		if err := spokeAfter.ConvertFrom(hub); err != nil {
+			t.Fatalf("failed to convert %T to %T: %v", hub, spokeAfter, err)
+		}
+
+		spokeAfter.GetObjectKind().SetGroupVersionKind(spoke.GetObjectKind().GroupVersionKind())
+
+		if !apiequality.Semantic.DeepEqual(spoke, spokeAfter) {
+			t.Errorf("%T round trip through %T failed:\n%s", spoke, hub, diff.ObjectReflectDiff(spoke, spokeAfter))
+		}
+	}
+
+	for i := 0; i < roundTripIterations; i++ {
+		hub := newHub()
+		f.Fuzz(hub)
+		hub.GetObjectKind().SetGroupVersionKind(ramendrv1beta1.GroupVersion.WithKind(""))
+
+		spoke := newSpoke()
+		if err := spoke.ConvertFrom(hub); err != nil {
+			t.Fatalf("failed to convert %T to %T: %v", hub, spoke, err)
+		}
+
+		hubAfter := newHub()
+		if err := spoke.ConvertTo(hubAfter); err != nil {
+			t.Fatalf("failed to convert %T to %T: %v", spoke, hubAfter, err)
+		}
+
+		hubAfter.GetObjectKind().SetGroupVersionKind(hub.GetObjectKind().GroupVersionKind())
+
+		if !apiequality.Semantic.DeepEqual(hub, hubAfter) {
+			t.Errorf("%T round trip through %T failed:\n%s", hub, spoke, diff.ObjectReflectDiff(hub, hubAfter))
+		}
+	}
+}
+
+func TestVolumeReplicationGroupConversionRoundTrip(t *testing.T) {
+	testRoundTrip(t,
+		func() conversion.Convertible { return &VolumeReplicationGroup{} },
+		func() conversion.Hub { return &ramendrv1beta1.VolumeReplicationGroup{} })
+}
+
+func TestDRPolicyConversionRoundTrip(t *testing.T) {
+	testRoundTrip(t,
+		func() conversion.Convertible { return &DRPolicy{} },
+		func() conversion.Hub { return &ramendrv1beta1.DRPolicy{} })
+}
+
+func TestDRPlacementControlConversionRoundTrip(t *testing.T) {
+	testRoundTrip(t,
+		func() conversion.Convertible { return &DRPlacementControl{} },
+		func() conversion.Hub { return &ramendrv1beta1.DRPlacementControl{} })
+}
+
+func TestVolumeReplicationGroupConversionRenamesS3Profiles(t *testing.T) {
+	spoke := &VolumeReplicationGroup{Spec: VolumeReplicationGroupSpec{S3ProfileList: []string{"east", "west"}}}
+	hub := &ramendrv1beta1.VolumeReplicationGroup{}
+
+	if err := spoke.ConvertTo(hub); err != nil {
+		t.Fatal(err)
+	}
+
+	if !apiequality.Semantic.DeepEqual(hub.Spec.S3Profiles, spoke.Spec.S3ProfileList) {
+		t.Errorf("expected s3Profiles %v, got %v", spoke.Spec.S3ProfileList, hub.Spec.S3Profiles)
+	}
+}
+
+func TestDRClusterConversionRoundTrip(t *testing.T) {
+	testRoundTrip(t,
+		func() conversion.Convertible { return &DRCluster{} },
+		func() conversion.Hub { return &ramendrv1beta1.DRCluster{} })
+}
+
+func TestDRActionRequestConversionRoundTrip(t *testing.T) {
+	testRoundTrip(t,
+		func() conversion.Convertible { return &DRActionRequest{} },
+		func() conversion.Hub { return &ramendrv1beta1.DRActionRequest{} })
+}
+
+func TestDRClusterActionConversionRoundTrip(t *testing.T) {
+	testRoundTrip(t,
+		func() conversion.Convertible { return &DRClusterAction{} },
+		func() conversion.Hub { return &ramendrv1beta1.DRClusterAction{} })
+}
+
+func TestDRPlacementControlGroupConversionRoundTrip(t *testing.T) {
+	testRoundTrip(t,
+		func() conversion.Convertible { return &DRPlacementControlGroup{} },
+		func() conversion.Hub { return &ramendrv1beta1.DRPlacementControlGroup{} })
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	ramendrv1beta1 "github.com/ramendr/ramen/api/v1beta1"
+)
+
+// ConvertTo converts this DRActionRequest to the Hub version (v1beta1).
+func (src *DRActionRequest) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*ramendrv1beta1.DRActionRequest)
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec.DRPCName = src.Spec.DRPCName
+	dst.Spec.Action = ramendrv1beta1.DRAction(src.Spec.Action)
+	dst.Spec.TargetCluster = src.Spec.TargetCluster
+	dst.Spec.Reason = src.Spec.Reason
+	dst.Spec.Requester = src.Spec.Requester
+
+	dst.Status.Phase = ramendrv1beta1.DRActionRequestPhase(src.Status.Phase)
+	dst.Status.Message = src.Status.Message
+	dst.Status.StartTime = src.Status.StartTime
+	dst.Status.CompletionTime = src.Status.CompletionTime
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *DRActionRequest) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*ramendrv1beta1.DRActionRequest)
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec.DRPCName = src.Spec.DRPCName
+	dst.Spec.Action = DRAction(src.Spec.Action)
+	dst.Spec.TargetCluster = src.Spec.TargetCluster
+	dst.Spec.Reason = src.Spec.Reason
+	dst.Spec.Requester = src.Spec.Requester
+
+	dst.Status.Phase = DRActionRequestPhase(src.Status.Phase)
+	dst.Status.Message = src.Status.Message
+	dst.Status.StartTime = src.Status.StartTime
+	dst.Status.CompletionTime = src.Status.CompletionTime
+
+	return nil
+}
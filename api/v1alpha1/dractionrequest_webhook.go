@@ -0,0 +1,167 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var dractionrequestlog = logf.Log.WithName("dractionrequest-webhook")
+
+// drActionRequestMutatePath is the path of the webhook that sets the requester
+// of a DRActionRequest
+const drActionRequestMutatePath = "/mutate-ramendr-openshift-io-v1alpha1-dractionrequest"
+
+// SetupWebhookWithManager sets up the validating webhook, and the mutating
+// webhook that sets the requester, with the Manager
+func (r *DRActionRequest) SetupWebhookWithManager(mgr ctrl.Manager, s3ProfileValidator S3ProfileValidator) error {
+	setupWebhookDependencies(mgr, s3ProfileValidator)
+
+	decoder, err := admission.NewDecoder(mgr.GetScheme())
+	if err != nil {
+		return err
+	}
+
+	mgr.GetWebhookServer().Register(drActionRequestMutatePath,
+		&webhook.Admission{Handler: &drActionRequestRequesterSetter{decoder: decoder}})
+
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//nolint:lll
+//+kubebuilder:webhook:path=/mutate-ramendr-openshift-io-v1alpha1-dractionrequest,mutating=true,failurePolicy=fail,sideEffects=None,groups=ramendr.openshift.io,resources=dractionrequests,verbs=create,versions=v1alpha1,name=mdractionrequest.kb.io,admissionReviewVersions={v1,v1beta1}
+
+// drActionRequestRequesterSetter sets the requester of a DRActionRequest to
+// the user that creates it, as the user is not known to a defaulting webhook
+type drActionRequestRequesterSetter struct {
+	decoder *admission.Decoder
+}
+
+// Handle sets the requester of the DRActionRequest of the admission request
+func (s *drActionRequestRequesterSetter) Handle(ctx context.Context, req admission.Request) admission.Response {
+	request := &DRActionRequest{}
+	if err := s.decoder.Decode(req, request); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	dractionrequestlog.Info("set requester", "name", request.Name, "namespace", req.Namespace,
+		"requester", req.UserInfo.Username)
+
+	request.Spec.Requester = req.UserInfo.Username
+
+	marshaled, err := json.Marshal(request)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
+
+//nolint:lll
+//+kubebuilder:webhook:path=/validate-ramendr-openshift-io-v1alpha1-dractionrequest,mutating=false,failurePolicy=fail,sideEffects=None,groups=ramendr.openshift.io,resources=dractionrequests,verbs=create;update,versions=v1alpha1,name=vdractionrequest.kb.io,admissionReviewVersions={v1,v1beta1}
+
+var _ webhook.Validator = &DRActionRequest{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *DRActionRequest) ValidateCreate() error {
+	dractionrequestlog.Info("validate create", "name", r.Name, "namespace", r.Namespace)
+
+	return r.validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+// The spec is immutable, so updates that change it are rejected.
+func (r *DRActionRequest) ValidateUpdate(old runtime.Object) error {
+	dractionrequestlog.Info("validate update", "name", r.Name, "namespace", r.Namespace)
+
+	oldRequest, ok := old.(*DRActionRequest)
+	if !ok || equality.Semantic.DeepEqual(oldRequest.Spec, r.Spec) {
+		return nil
+	}
+
+	return invalidError("DRActionRequest", r.Name, field.ErrorList{
+		field.Forbidden(field.NewPath("spec"), "spec is immutable"),
+	})
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *DRActionRequest) ValidateDelete() error {
+	return nil
+}
+
+func (r *DRActionRequest) validate() error {
+	var allErrs field.ErrorList
+
+	specPath := field.NewPath("spec")
+	drpcNamePath := specPath.Child("drpcName")
+
+	switch r.Spec.Action {
+	case ActionFailover, ActionRelocate:
+	default:
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("action"), r.Spec.Action,
+			[]string{string(ActionFailover), string(ActionRelocate)}))
+	}
+
+	drpc := &DRPlacementControl{}
+	if err := webhookReader.Get(context.TODO(), types.NamespacedName{Name: r.Spec.DRPCName, Namespace: r.Namespace},
+		drpc); err != nil {
+		if apierrors.IsNotFound(err) {
+			allErrs = append(allErrs, field.NotFound(drpcNamePath, r.Spec.DRPCName))
+		} else {
+			allErrs = append(allErrs, field.InternalError(drpcNamePath,
+				fmt.Errorf("failed to get DRPlacementControl: %w", err)))
+		}
+
+		return invalidError("DRActionRequest", r.Name, allErrs)
+	}
+
+	if !drpc.GetDeletionTimestamp().IsZero() {
+		allErrs = append(allErrs, field.Invalid(drpcNamePath, r.Spec.DRPCName, "DRPlacementControl is being deleted"))
+
+		return invalidError("DRActionRequest", r.Name, allErrs)
+	}
+
+	drpolicy := &DRPolicy{}
+	if err := webhookReader.Get(context.TODO(), types.NamespacedName{Name: drpc.Spec.DRPolicyRef.Name},
+		drpolicy); err != nil {
+		allErrs = append(allErrs, field.InternalError(drpcNamePath, fmt.Errorf("failed to get DRPolicy: %w", err)))
+
+		return invalidError("DRActionRequest", r.Name, allErrs)
+	}
+
+	if clusterNames := drpolicy.clusterNames(); !containsString(clusterNames, r.Spec.TargetCluster) {
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("targetCluster"), r.Spec.TargetCluster,
+			clusterNames))
+	}
+
+	return invalidError("DRActionRequest", r.Name, allErrs)
+}
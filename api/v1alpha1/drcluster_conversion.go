@@ -0,0 +1,104 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	ramendrv1beta1 "github.com/ramendr/ramen/api/v1beta1"
+)
+
+// ConvertTo converts this DRCluster to the Hub version (v1beta1).
+func (src *DRCluster) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*ramendrv1beta1.DRCluster)
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec.S3ProfileName = src.Spec.S3ProfileName
+	dst.Spec.Region = src.Spec.Region
+	dst.Spec.Zone = src.Spec.Zone
+	dst.Spec.ClusterFence = ramendrv1beta1.ClusterFenceState(src.Spec.ClusterFence)
+	dst.Spec.CIDRs = src.Spec.CIDRs
+
+	dst.Status.Conditions = src.Status.Conditions
+	dst.Status.OperatorVersion = src.Status.OperatorVersion
+	dst.Status.LastHealthCheckTime = src.Status.LastHealthCheckTime
+	dst.Status.FenceState = ramendrv1beta1.ClusterFenceState(src.Status.FenceState)
+	dst.Status.FencePeerCluster = src.Status.FencePeerCluster
+
+	dst.Status.ReplicationClasses = nil
+	for _, replicationClass := range src.Status.ReplicationClasses {
+		dst.Status.ReplicationClasses = append(dst.Status.ReplicationClasses,
+			ramendrv1beta1.DRClusterReplicationClass(replicationClass))
+	}
+
+	dst.Status.StorageClasses = nil
+	for _, storageClass := range src.Status.StorageClasses {
+		dst.Status.StorageClasses = append(dst.Status.StorageClasses,
+			ramendrv1beta1.DRClusterStorageClass(storageClass))
+	}
+
+	dst.Status.CSIDrivers = src.Status.CSIDrivers
+
+	dst.Status.VolumeSnapshotClasses = nil
+	for _, snapshotClass := range src.Status.VolumeSnapshotClasses {
+		dst.Status.VolumeSnapshotClasses = append(dst.Status.VolumeSnapshotClasses,
+			ramendrv1beta1.DRClusterVolumeSnapshotClass(snapshotClass))
+	}
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *DRCluster) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*ramendrv1beta1.DRCluster)
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec.S3ProfileName = src.Spec.S3ProfileName
+	dst.Spec.Region = src.Spec.Region
+	dst.Spec.Zone = src.Spec.Zone
+	dst.Spec.ClusterFence = ClusterFenceState(src.Spec.ClusterFence)
+	dst.Spec.CIDRs = src.Spec.CIDRs
+
+	dst.Status.Conditions = src.Status.Conditions
+	dst.Status.OperatorVersion = src.Status.OperatorVersion
+	dst.Status.LastHealthCheckTime = src.Status.LastHealthCheckTime
+	dst.Status.FenceState = ClusterFenceState(src.Status.FenceState)
+	dst.Status.FencePeerCluster = src.Status.FencePeerCluster
+
+	dst.Status.ReplicationClasses = nil
+	for _, replicationClass := range src.Status.ReplicationClasses {
+		dst.Status.ReplicationClasses = append(dst.Status.ReplicationClasses,
+			DRClusterReplicationClass(replicationClass))
+	}
+
+	dst.Status.StorageClasses = nil
+	for _, storageClass := range src.Status.StorageClasses {
+		dst.Status.StorageClasses = append(dst.Status.StorageClasses, DRClusterStorageClass(storageClass))
+	}
+
+	dst.Status.CSIDrivers = src.Status.CSIDrivers
+
+	dst.Status.VolumeSnapshotClasses = nil
+	for _, snapshotClass := range src.Status.VolumeSnapshotClasses {
+		dst.Status.VolumeSnapshotClasses = append(dst.Status.VolumeSnapshotClasses,
+			DRClusterVolumeSnapshotClass(snapshotClass))
+	}
+
+	return nil
+}
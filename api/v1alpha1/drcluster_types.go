@@ -0,0 +1,173 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterFenceState is the fencing state of a cluster, in which a fenced
+// cluster is cut off from the storage it replicates
+// +kubebuilder:validation:Enum=Unfenced;Fenced
+type ClusterFenceState string
+
+const (
+	ClusterFenceStateUnfenced = ClusterFenceState("Unfenced")
+	ClusterFenceStateFenced   = ClusterFenceState("Fenced")
+)
+
+// DRClusterSpec defines the desired state of DRCluster
+type DRClusterSpec struct {
+	// S3 profile name (in Ramen config) of the cluster, which a DRPolicy that
+	// includes the cluster is expected to specify for it
+	S3ProfileName string `json:"s3ProfileName"`
+
+	// Region of the cluster, such as a cloud region or a data center
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Zone of the cluster within its region
+	// +optional
+	Zone string `json:"zone,omitempty"`
+
+	// ClusterFence is the desired fencing state of the cluster
+	// +optional
+	ClusterFence ClusterFenceState `json:"clusterFence,omitempty"`
+
+	// CIDRs of the storage clients, typically the nodes, of the cluster, which
+	// fencing cuts off from the storage.  Without CIDRs, the cluster is fenced
+	// by other means, and a fenced cluster is trusted to be so.
+	// +optional
+	CIDRs []string `json:"cidrs,omitempty"`
+}
+
+// DRClusterReplicationClass describes a VolumeReplicationClass offered by a
+// cluster
+type DRClusterReplicationClass struct {
+	Name               string            `json:"name"`
+	Provisioner        string            `json:"provisioner"`
+	SchedulingInterval string            `json:"schedulingInterval,omitempty"`
+	Labels             map[string]string `json:"labels,omitempty"`
+}
+
+// DRClusterStorageClass describes a StorageClass of a cluster
+type DRClusterStorageClass struct {
+	Name        string `json:"name"`
+	Provisioner string `json:"provisioner"`
+	Default     bool   `json:"default,omitempty"`
+}
+
+// DRClusterVolumeSnapshotClass describes a VolumeSnapshotClass of a cluster
+type DRClusterVolumeSnapshotClass struct {
+	Name   string `json:"name"`
+	Driver string `json:"driver"`
+}
+
+// DRClusterStatus defines the observed state of DRCluster
+type DRClusterStatus struct {
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// OperatorVersion is the version of the dr-cluster operator of the cluster,
+	// as reported in its inventory
+	// +optional
+	OperatorVersion string `json:"operatorVersion,omitempty"`
+
+	// ReplicationClasses are the VolumeReplicationClasses of the cluster, as
+	// reported in its inventory
+	// +optional
+	ReplicationClasses []DRClusterReplicationClass `json:"replicationClasses,omitempty"`
+
+	// StorageClasses are the StorageClasses of the cluster, as reported in its
+	// inventory
+	// +optional
+	StorageClasses []DRClusterStorageClass `json:"storageClasses,omitempty"`
+
+	// CSIDrivers are the names of the CSI drivers of the cluster, as reported
+	// in its inventory
+	// +optional
+	CSIDrivers []string `json:"csiDrivers,omitempty"`
+
+	// VolumeSnapshotClasses are the VolumeSnapshotClasses of the cluster, as
+	// reported in its inventory
+	// +optional
+	VolumeSnapshotClasses []DRClusterVolumeSnapshotClass `json:"volumeSnapshotClasses,omitempty"`
+
+	// LastHealthCheckTime is the time of the last check of the cluster that
+	// changed its status
+	// +optional
+	LastHealthCheckTime *metav1.Time `json:"lastHealthCheckTime,omitempty"`
+
+	// FenceState is the fencing state of the cluster, as confirmed by the
+	// storage of its fence peer cluster
+	// +optional
+	FenceState ClusterFenceState `json:"fenceState,omitempty"`
+
+	// FencePeerCluster is the cluster where the fence resource that fences the
+	// cluster is created, until the cluster is unfenced
+	// +optional
+	FencePeerCluster string `json:"fencePeerCluster,omitempty"`
+}
+
+const (
+	// DRClusterValidated reports whether the S3 profile of the cluster is
+	// configured in the Ramen config
+	DRClusterValidated string = `Validated`
+
+	// DRClusterAvailable reports whether the cluster is a joined and available
+	// managed cluster
+	DRClusterAvailable string = `Available`
+
+	// DRClusterInventoryReported reports whether the inventory of the cluster,
+	// published by its dr-cluster operator, is read by the hub
+	DRClusterInventoryReported string = `InventoryReported`
+
+	// DRClusterFenced reports whether the cluster is confirmed to be fenced, or
+	// the progress of its fencing or unfencing
+	DRClusterFenced string = `Fenced`
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:JSONPath=".spec.s3ProfileName",name=s3Profile,type=string
+// +kubebuilder:printcolumn:JSONPath=".spec.region",name=region,type=string
+// +kubebuilder:printcolumn:JSONPath=".spec.clusterFence",name=fence,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.fenceState",name=fenceState,type=string
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",name=Age,type=date
+
+// DRCluster is the Schema for the drclusters API.  Its name is the name of the
+// managed cluster it describes.
+type DRCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DRClusterSpec   `json:"spec,omitempty"`
+	Status DRClusterStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DRClusterList contains a list of DRCluster
+type DRClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DRCluster `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DRCluster{}, &DRClusterList{})
+}
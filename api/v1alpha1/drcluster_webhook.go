@@ -0,0 +1,77 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+var drclusterlog = logf.Log.WithName("drcluster-webhook")
+
+// SetupWebhookWithManager sets up the validating webhook with the Manager
+func (r *DRCluster) SetupWebhookWithManager(mgr ctrl.Manager, s3ProfileValidator S3ProfileValidator) error {
+	setupWebhookDependencies(mgr, s3ProfileValidator)
+
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//nolint:lll
+//+kubebuilder:webhook:path=/validate-ramendr-openshift-io-v1alpha1-drcluster,mutating=false,failurePolicy=fail,sideEffects=None,groups=ramendr.openshift.io,resources=drclusters,verbs=create;update,versions=v1alpha1,name=vdrcluster.kb.io,admissionReviewVersions={v1,v1beta1}
+
+var _ webhook.Validator = &DRCluster{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *DRCluster) ValidateCreate() error {
+	drclusterlog.Info("validate create", "name", r.Name)
+
+	return r.validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+// Updates that do not change the spec, such as status updates, are not validated.
+func (r *DRCluster) ValidateUpdate(old runtime.Object) error {
+	drclusterlog.Info("validate update", "name", r.Name)
+
+	if oldDRCluster, ok := old.(*DRCluster); ok && equality.Semantic.DeepEqual(oldDRCluster.Spec, r.Spec) {
+		return nil
+	}
+
+	return r.validate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *DRCluster) ValidateDelete() error {
+	return nil
+}
+
+func (r *DRCluster) validate() error {
+	var allErrs field.ErrorList
+
+	if err := validateS3Profile(r.Spec.S3ProfileName); err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("s3ProfileName"),
+			r.Spec.S3ProfileName, err.Error()))
+	}
+
+	return invalidError("DRCluster", r.Name, allErrs)
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	ramendrv1beta1 "github.com/ramendr/ramen/api/v1beta1"
+)
+
+// ConvertTo converts this DRClusterAction to the Hub version (v1beta1).
+func (src *DRClusterAction) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*ramendrv1beta1.DRClusterAction)
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec.ClusterName = src.Spec.ClusterName
+	dst.Spec.Action = ramendrv1beta1.DRAction(src.Spec.Action)
+	dst.Spec.DRPolicyName = src.Spec.DRPolicyName
+	dst.Spec.TargetCluster = src.Spec.TargetCluster
+	dst.Spec.Concurrency = src.Spec.Concurrency
+	dst.Spec.DryRun = src.Spec.DryRun
+
+	dst.Status.Phase = ramendrv1beta1.DRClusterActionPhase(src.Status.Phase)
+	dst.Status.Message = src.Status.Message
+	dst.Status.Progress = ramendrv1beta1.DRClusterActionProgress(src.Status.Progress)
+	dst.Status.StartTime = src.Status.StartTime
+	dst.Status.CompletionTime = src.Status.CompletionTime
+
+	dst.Status.DRPCs = nil
+	for _, drpc := range src.Status.DRPCs {
+		dst.Status.DRPCs = append(dst.Status.DRPCs, ramendrv1beta1.DRClusterActionDRPC{
+			Name:           drpc.Name,
+			Namespace:      drpc.Namespace,
+			Priority:       drpc.Priority,
+			TargetCluster:  drpc.TargetCluster,
+			State:          ramendrv1beta1.DRClusterActionDRPCState(drpc.State),
+			Message:        drpc.Message,
+			StartTime:      drpc.StartTime,
+			CompletionTime: drpc.CompletionTime,
+		})
+	}
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *DRClusterAction) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*ramendrv1beta1.DRClusterAction)
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec.ClusterName = src.Spec.ClusterName
+	dst.Spec.Action = DRAction(src.Spec.Action)
+	dst.Spec.DRPolicyName = src.Spec.DRPolicyName
+	dst.Spec.TargetCluster = src.Spec.TargetCluster
+	dst.Spec.Concurrency = src.Spec.Concurrency
+	dst.Spec.DryRun = src.Spec.DryRun
+
+	dst.Status.Phase = DRClusterActionPhase(src.Status.Phase)
+	dst.Status.Message = src.Status.Message
+	dst.Status.Progress = DRClusterActionProgress(src.Status.Progress)
+	dst.Status.StartTime = src.Status.StartTime
+	dst.Status.CompletionTime = src.Status.CompletionTime
+
+	dst.Status.DRPCs = nil
+	for _, drpc := range src.Status.DRPCs {
+		dst.Status.DRPCs = append(dst.Status.DRPCs, DRClusterActionDRPC{
+			Name:           drpc.Name,
+			Namespace:      drpc.Namespace,
+			Priority:       drpc.Priority,
+			TargetCluster:  drpc.TargetCluster,
+			State:          DRClusterActionDRPCState(drpc.State),
+			Message:        drpc.Message,
+			StartTime:      drpc.StartTime,
+			CompletionTime: drpc.CompletionTime,
+		})
+	}
+
+	return nil
+}
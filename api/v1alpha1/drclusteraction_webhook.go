@@ -0,0 +1,127 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+var drclusteractionlog = logf.Log.WithName("drclusteraction-webhook")
+
+// SetupWebhookWithManager sets up the validating webhook with the Manager
+func (r *DRClusterAction) SetupWebhookWithManager(mgr ctrl.Manager, s3ProfileValidator S3ProfileValidator) error {
+	setupWebhookDependencies(mgr, s3ProfileValidator)
+
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//nolint:lll
+//+kubebuilder:webhook:path=/validate-ramendr-openshift-io-v1alpha1-drclusteraction,mutating=false,failurePolicy=fail,sideEffects=None,groups=ramendr.openshift.io,resources=drclusteractions,verbs=create;update,versions=v1alpha1,name=vdrclusteraction.kb.io,admissionReviewVersions={v1,v1beta1}
+
+var _ webhook.Validator = &DRClusterAction{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *DRClusterAction) ValidateCreate() error {
+	drclusteractionlog.Info("validate create", "name", r.Name)
+
+	return r.validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+// The spec is immutable, so updates that change it are rejected.
+func (r *DRClusterAction) ValidateUpdate(old runtime.Object) error {
+	drclusteractionlog.Info("validate update", "name", r.Name)
+
+	oldAction, ok := old.(*DRClusterAction)
+	if !ok || equality.Semantic.DeepEqual(oldAction.Spec, r.Spec) {
+		return nil
+	}
+
+	return invalidError("DRClusterAction", r.Name, field.ErrorList{
+		field.Forbidden(field.NewPath("spec"), "spec is immutable"),
+	})
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *DRClusterAction) ValidateDelete() error {
+	return nil
+}
+
+func (r *DRClusterAction) validate() error {
+	var allErrs field.ErrorList
+
+	specPath := field.NewPath("spec")
+
+	switch r.Spec.Action {
+	case ActionFailover:
+		if r.Spec.TargetCluster != "" && r.Spec.TargetCluster == r.Spec.ClusterName {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("targetCluster"), r.Spec.TargetCluster,
+				"target cluster is the cluster to fail over from"))
+		}
+	case ActionRelocate:
+		if r.Spec.TargetCluster != "" {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("targetCluster"),
+				"a relocation relocates to the cluster of the action"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("action"), r.Spec.Action,
+			[]string{string(ActionFailover), string(ActionRelocate)}))
+	}
+
+	if r.Spec.DRPolicyName == "" {
+		return invalidError("DRClusterAction", r.Name, allErrs)
+	}
+
+	drpolicyNamePath := specPath.Child("drPolicyName")
+
+	drpolicy := &DRPolicy{}
+	if err := webhookReader.Get(context.TODO(), types.NamespacedName{Name: r.Spec.DRPolicyName},
+		drpolicy); err != nil {
+		if apierrors.IsNotFound(err) {
+			allErrs = append(allErrs, field.NotFound(drpolicyNamePath, r.Spec.DRPolicyName))
+		} else {
+			allErrs = append(allErrs, field.InternalError(drpolicyNamePath, fmt.Errorf("failed to get DRPolicy: %w", err)))
+		}
+
+		return invalidError("DRClusterAction", r.Name, allErrs)
+	}
+
+	clusterNames := drpolicy.clusterNames()
+
+	if !containsString(clusterNames, r.Spec.ClusterName) {
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("clusterName"), r.Spec.ClusterName, clusterNames))
+	}
+
+	if r.Spec.TargetCluster != "" && !containsString(clusterNames, r.Spec.TargetCluster) {
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("targetCluster"), r.Spec.TargetCluster,
+			clusterNames))
+	}
+
+	return invalidError("DRClusterAction", r.Name, allErrs)
+}
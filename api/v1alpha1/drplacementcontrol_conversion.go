@@ -0,0 +1,201 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	ramendrv1beta1 "github.com/ramendr/ramen/api/v1beta1"
+)
+
+// ConvertTo converts this DRPlacementControl to the Hub version (v1beta1).
+func (src *DRPlacementControl) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*ramendrv1beta1.DRPlacementControl)
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec.PlacementRef = src.Spec.PlacementRef
+	dst.Spec.DRPolicyRef = src.Spec.DRPolicyRef
+	dst.Spec.PreferredCluster = src.Spec.PreferredCluster
+	dst.Spec.FailoverCluster = src.Spec.FailoverCluster
+	dst.Spec.PVCSelector = src.Spec.PVCSelector
+	dst.Spec.Action = ramendrv1beta1.DRAction(src.Spec.Action)
+	dst.Spec.SplitBrainWinner = src.Spec.SplitBrainWinner
+	dst.Spec.CancelAction = src.Spec.CancelAction
+
+	dst.Status.Phase = ramendrv1beta1.DRState(src.Status.Phase)
+	dst.Status.PreferredDecision = src.Status.PreferredDecision
+	dst.Status.Conditions = src.Status.Conditions
+	dst.Status.ResourceConditions = ramendrv1beta1.VRGConditions{
+		ResourceMeta: ramendrv1beta1.VRGResourceMeta(src.Status.ResourceConditions.ResourceMeta),
+		Conditions:   src.Status.ResourceConditions.Conditions,
+	}
+	dst.Status.LastUpdateTime = src.Status.LastUpdateTime
+	dst.Status.ObservedGeneration = src.Status.ObservedGeneration
+	dst.Status.SplitBrainPrimaries = src.Status.SplitBrainPrimaries
+	dst.Status.SplitBrainResolution = (*ramendrv1beta1.SplitBrainResolution)(src.Status.SplitBrainResolution)
+
+	dst.Status.ActionOrigin = nil
+	if origin := src.Status.ActionOrigin; origin != nil {
+		dst.Status.ActionOrigin = &ramendrv1beta1.ActionOrigin{
+			Action:        ramendrv1beta1.DRAction(origin.Action),
+			TargetCluster: origin.TargetCluster,
+			SourceCluster: origin.SourceCluster,
+			SourcePhase:   ramendrv1beta1.DRState(origin.SourcePhase),
+			StartTime:     origin.StartTime,
+		}
+	}
+
+	dst.Status.ActionCancellation = nil
+	if cancellation := src.Status.ActionCancellation; cancellation != nil {
+		dst.Status.ActionCancellation = &ramendrv1beta1.ActionCancellation{
+			Action:        ramendrv1beta1.DRAction(cancellation.Action),
+			TargetCluster: cancellation.TargetCluster,
+			SourceCluster: cancellation.SourceCluster,
+			State:         ramendrv1beta1.ActionCancellationState(cancellation.State),
+			Message:       cancellation.Message,
+			Time:          cancellation.Time,
+		}
+	}
+
+	dst.Status.ActionHistory = nil
+	for _, record := range src.Status.ActionHistory {
+		dst.Status.ActionHistory = append(dst.Status.ActionHistory, ramendrv1beta1.ActionRecord{
+			Action:               ramendrv1beta1.DRAction(record.Action),
+			SourceCluster:        record.SourceCluster,
+			TargetCluster:        record.TargetCluster,
+			StartTime:            record.StartTime,
+			DataRestoredTime:     record.DataRestoredTime,
+			PlacementUpdatedTime: record.PlacementUpdatedTime,
+			CleanupCompleteTime:  record.CleanupCompleteTime,
+			EndTime:              record.EndTime,
+			Outcome:              ramendrv1beta1.ActionOutcome(record.Outcome),
+			FailureReason:        record.FailureReason,
+		})
+	}
+
+	dst.Status.SplitBrainPVCs = nil
+	for _, pvc := range src.Status.SplitBrainPVCs {
+		dst.Status.SplitBrainPVCs = append(dst.Status.SplitBrainPVCs, ramendrv1beta1.SplitBrainPVC(pvc))
+	}
+
+	dst.Status.ActionApproval = nil
+	if approval := src.Status.ActionApproval; approval != nil {
+		dst.Status.ActionApproval = &ramendrv1beta1.ActionApproval{
+			Action:             ramendrv1beta1.DRAction(approval.Action),
+			TargetCluster:      approval.TargetCluster,
+			Requester:          approval.Requester,
+			RequestTime:        approval.RequestTime,
+			ObservedGeneration: approval.ObservedGeneration,
+			State:              ramendrv1beta1.ActionApprovalState(approval.State),
+			Approver:           approval.Approver,
+			DecisionTime:       approval.DecisionTime,
+			Message:            approval.Message,
+		}
+	}
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *DRPlacementControl) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*ramendrv1beta1.DRPlacementControl)
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec.PlacementRef = src.Spec.PlacementRef
+	dst.Spec.DRPolicyRef = src.Spec.DRPolicyRef
+	dst.Spec.PreferredCluster = src.Spec.PreferredCluster
+	dst.Spec.FailoverCluster = src.Spec.FailoverCluster
+	dst.Spec.PVCSelector = src.Spec.PVCSelector
+	dst.Spec.Action = DRAction(src.Spec.Action)
+	dst.Spec.SplitBrainWinner = src.Spec.SplitBrainWinner
+	dst.Spec.CancelAction = src.Spec.CancelAction
+
+	dst.Status.Phase = DRState(src.Status.Phase)
+	dst.Status.PreferredDecision = src.Status.PreferredDecision
+	dst.Status.Conditions = src.Status.Conditions
+	dst.Status.ResourceConditions = VRGConditions{
+		ResourceMeta: VRGResourceMeta(src.Status.ResourceConditions.ResourceMeta),
+		Conditions:   src.Status.ResourceConditions.Conditions,
+	}
+	dst.Status.LastUpdateTime = src.Status.LastUpdateTime
+	dst.Status.ObservedGeneration = src.Status.ObservedGeneration
+	dst.Status.SplitBrainPrimaries = src.Status.SplitBrainPrimaries
+	dst.Status.SplitBrainResolution = (*SplitBrainResolution)(src.Status.SplitBrainResolution)
+
+	dst.Status.ActionOrigin = nil
+	if origin := src.Status.ActionOrigin; origin != nil {
+		dst.Status.ActionOrigin = &ActionOrigin{
+			Action:        DRAction(origin.Action),
+			TargetCluster: origin.TargetCluster,
+			SourceCluster: origin.SourceCluster,
+			SourcePhase:   DRState(origin.SourcePhase),
+			StartTime:     origin.StartTime,
+		}
+	}
+
+	dst.Status.ActionCancellation = nil
+	if cancellation := src.Status.ActionCancellation; cancellation != nil {
+		dst.Status.ActionCancellation = &ActionCancellation{
+			Action:        DRAction(cancellation.Action),
+			TargetCluster: cancellation.TargetCluster,
+			SourceCluster: cancellation.SourceCluster,
+			State:         ActionCancellationState(cancellation.State),
+			Message:       cancellation.Message,
+			Time:          cancellation.Time,
+		}
+	}
+
+	dst.Status.ActionHistory = nil
+	for _, record := range src.Status.ActionHistory {
+		dst.Status.ActionHistory = append(dst.Status.ActionHistory, ActionRecord{
+			Action:               DRAction(record.Action),
+			SourceCluster:        record.SourceCluster,
+			TargetCluster:        record.TargetCluster,
+			StartTime:            record.StartTime,
+			DataRestoredTime:     record.DataRestoredTime,
+			PlacementUpdatedTime: record.PlacementUpdatedTime,
+			CleanupCompleteTime:  record.CleanupCompleteTime,
+			EndTime:              record.EndTime,
+			Outcome:              ActionOutcome(record.Outcome),
+			FailureReason:        record.FailureReason,
+		})
+	}
+
+	dst.Status.SplitBrainPVCs = nil
+	for _, pvc := range src.Status.SplitBrainPVCs {
+		dst.Status.SplitBrainPVCs = append(dst.Status.SplitBrainPVCs, SplitBrainPVC(pvc))
+	}
+
+	dst.Status.ActionApproval = nil
+	if approval := src.Status.ActionApproval; approval != nil {
+		dst.Status.ActionApproval = &ActionApproval{
+			Action:             DRAction(approval.Action),
+			TargetCluster:      approval.TargetCluster,
+			Requester:          approval.Requester,
+			RequestTime:        approval.RequestTime,
+			ObservedGeneration: approval.ObservedGeneration,
+			State:              ActionApprovalState(approval.State),
+			Approver:           approval.Approver,
+			DecisionTime:       approval.DecisionTime,
+			Message:            approval.Message,
+		}
+	}
+
+	return nil
+}
@@ -36,6 +36,28 @@ const (
 	ActionRelocate = DRAction("Relocate")
 )
 
+const (
+	// DRPCActionRequestAnnotation names the DRActionRequest that last requested
+	// the action of a DRPC.  It is trusted only when set by the Ramen operator.
+	DRPCActionRequestAnnotation = "drplacementcontrols.ramendr.openshift.io/action-request"
+
+	// DRPCActionRequesterAnnotation is the user that last changed the action,
+	// or its target cluster, of a DRPC, as recorded by the admission webhook
+	DRPCActionRequesterAnnotation = "drplacementcontrols.ramendr.openshift.io/action-requester"
+
+	// DRPCActionApprovalAnnotation is the decision of an approver on the
+	// action of a DRPC that requires approval: Approved or Rejected
+	DRPCActionApprovalAnnotation = "drplacementcontrols.ramendr.openshift.io/action-approval"
+
+	// DRPCActionApproverAnnotation is the user that set the action approval
+	// annotation, as recorded by the admission webhook
+	DRPCActionApproverAnnotation = "drplacementcontrols.ramendr.openshift.io/action-approver"
+
+	// NamespaceActionApprovalRequiredAnnotation, set to "true" on a namespace,
+	// requires the failovers and relocations of its DRPCs to be approved
+	NamespaceActionApprovalRequiredAnnotation = "drplacementcontrols.ramendr.openshift.io/action-approval-required"
+)
+
 // DRPlacementControlSpec defines the desired state of DRPlacementControl
 type DRPlacementControlSpec struct {
 	// PlacementRef is the reference to the PlacementRule used by DRPC
@@ -48,7 +70,8 @@ type DRPlacementControlSpec struct {
 	PreferredCluster string `json:"preferredCluster,omitempty"`
 
 	// FailoverCluster is the cluster name that the user wants to failover the application to.
-	// If not sepcified, then the DRPC will select the surviving cluster from the DRPolicy
+	// If not sepcified, then the DRPC will select the surviving cluster from the DRPolicy,
+	// preferring one in a different region, and then zone, from the failed cluster
 	FailoverCluster string `json:"failoverCluster,omitempty"`
 
 	// Label selector to identify all the PVCs that need DR protection.
@@ -58,6 +81,23 @@ type DRPlacementControlSpec struct {
 
 	// Action is either Failover or Relocate operation
 	Action DRAction `json:"action,omitempty"`
+
+	// SplitBrainWinner is the cluster to keep as Primary, when more than one
+	// cluster reports the VRG of the DRPC as Primary.  The VRGs of the other
+	// clusters are demoted to Secondary, and their volumes resynced in Async
+	// replication mode.  It is cleared once at most one cluster reports the
+	// VRG as Primary.
+	// +optional
+	SplitBrainWinner string `json:"splitBrainWinner,omitempty"`
+
+	// CancelAction cancels the in-progress failover or relocation, and rolls
+	// the application back to the cluster the action started from.  The
+	// cancellation is refused once the VRG of the target cluster reports
+	// Primary, or the application is placed on it.  The application is held
+	// on the cluster it is rolled back to until CancelAction is cleared, after
+	// which the action, unless changed, is started again.
+	// +optional
+	CancelAction bool `json:"cancelAction,omitempty"`
 }
 
 // DRState for keeping track of the DR placement
@@ -94,6 +134,10 @@ const (
 const (
 	ConditionAvailable = "Available"
 	ConditionPeerReady = "PeerReady"
+
+	// ConditionSplitBrain reports whether more than one cluster reports the VRG
+	// of the DRPC as Primary.  It is set once a split-brain is detected.
+	ConditionSplitBrain = "SplitBrain"
 )
 
 const (
@@ -101,8 +145,236 @@ const (
 	ReasonCleaning    = "Cleaning"
 	ReasonSuccess     = "Success"
 	ReasonNotStarted  = "NotStarted"
+
+	// ReasonDRPolicyDegraded is the PeerReady condition reason while a health
+	// condition of the DRPolicy of the DRPC is false
+	ReasonDRPolicyDegraded = "DRPolicyDegraded"
+
+	// ReasonMultiplePrimaries is the SplitBrain condition reason while more than
+	// one cluster reports the VRG of the DRPC as Primary, and no winner is set
+	ReasonMultiplePrimaries = "MultiplePrimaries"
+
+	// ReasonSplitBrainResolving is the SplitBrain condition reason while the
+	// VRGs of the clusters other than the winner are demoted
+	ReasonSplitBrainResolving = "Resolving"
+
+	// ReasonSinglePrimary is the SplitBrain condition reason once at most one
+	// cluster reports the VRG of the DRPC as Primary
+	ReasonSinglePrimary = "SinglePrimary"
+)
+
+// ActionOrigin records where the current failover or relocation started from
+type ActionOrigin struct {
+	// Action started
+	Action DRAction `json:"action"`
+
+	// TargetCluster of the action
+	TargetCluster string `json:"targetCluster"`
+
+	// SourceCluster is the home cluster of the application when the action started
+	// +optional
+	SourceCluster string `json:"sourceCluster,omitempty"`
+
+	// SourcePhase is the phase of the DRPC when the action started
+	// +optional
+	SourcePhase DRState `json:"sourcePhase,omitempty"`
+
+	// StartTime of the action
+	StartTime metav1.Time `json:"startTime"`
+}
+
+// ActionOutcome is the outcome of a failover or relocation
+type ActionOutcome string
+
+const (
+	// ActionInProgress is the outcome of an action yet to complete
+	ActionInProgress = ActionOutcome("InProgress")
+
+	// ActionSucceeded is the outcome of an action completed, including the
+	// clean up of its secondaries
+	ActionSucceeded = ActionOutcome("Succeeded")
+
+	// ActionFailed is the outcome of an action superseded by another one before
+	// it completed
+	ActionFailed = ActionOutcome("Failed")
+
+	// ActionCancelled is the outcome of an action cancelled and rolled back
+	ActionCancelled = ActionOutcome("Cancelled")
+)
+
+// ActionRecord records a failover or relocation, and the time of each of its
+// phases
+type ActionRecord struct {
+	// Action performed
+	Action DRAction `json:"action"`
+
+	// SourceCluster is the home cluster of the application when the action started
+	// +optional
+	SourceCluster string `json:"sourceCluster,omitempty"`
+
+	// TargetCluster of the action
+	TargetCluster string `json:"targetCluster"`
+
+	// StartTime of the action
+	StartTime metav1.Time `json:"startTime"`
+
+	// DataRestoredTime is the time the data was found restored on the target cluster
+	// +optional
+	DataRestoredTime *metav1.Time `json:"dataRestoredTime,omitempty"`
+
+	// PlacementUpdatedTime is the time the application was placed on the target cluster
+	// +optional
+	PlacementUpdatedTime *metav1.Time `json:"placementUpdatedTime,omitempty"`
+
+	// CleanupCompleteTime is the time the secondaries were cleaned up
+	// +optional
+	CleanupCompleteTime *metav1.Time `json:"cleanupCompleteTime,omitempty"`
+
+	// EndTime of the action, once it is no longer in progress
+	// +optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+
+	// Outcome of the action
+	Outcome ActionOutcome `json:"outcome"`
+
+	// FailureReason is the last error the action encountered, if any, which is
+	// the reason of its failure if it fails
+	// +optional
+	FailureReason string `json:"failureReason,omitempty"`
+}
+
+// ActionApprovalState is the state of the approval of an action
+type ActionApprovalState string
+
+const (
+	// ActionApprovalPending is the state of an action awaiting a decision
+	ActionApprovalPending = ActionApprovalState("Pending")
+
+	// ActionApprovalApproved is the state of an approved action, which starts
+	ActionApprovalApproved = ActionApprovalState("Approved")
+
+	// ActionApprovalRejected is the state of a rejected action, which does not
+	// start unless requested again
+	ActionApprovalRejected = ActionApprovalState("Rejected")
+
+	// ActionApprovalExpired is the state of an action that awaited a decision
+	// past the expiry of the approval policy, which does not start unless
+	// requested again
+	ActionApprovalExpired = ActionApprovalState("Expired")
+)
+
+// ActionApproval records the approval of a failover or relocation that
+// requires one
+type ActionApproval struct {
+	// Action awaiting approval
+	Action DRAction `json:"action"`
+
+	// TargetCluster of the action
+	TargetCluster string `json:"targetCluster"`
+
+	// Requester is the user that requested the action, if recorded
+	// +optional
+	Requester string `json:"requester,omitempty"`
+
+	// RequestTime is the time the action was found awaiting approval
+	RequestTime metav1.Time `json:"requestTime"`
+
+	// ObservedGeneration is the generation of the DRPC the action was last
+	// requested at.  A rejected or expired action is requested again by a
+	// change to the spec of the DRPC.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// State of the approval
+	State ActionApprovalState `json:"state"`
+
+	// Approver is the user that approved or rejected the action
+	// +optional
+	Approver string `json:"approver,omitempty"`
+
+	// DecisionTime is the time the action was approved, rejected or expired
+	// +optional
+	DecisionTime *metav1.Time `json:"decisionTime,omitempty"`
+
+	// Message describing the state of the approval
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ActionCancellationState is the state of the cancellation of an action
+type ActionCancellationState string
+
+const (
+	// ActionCancellationRollingBack is the state of a cancellation while the
+	// application is rolled back to the source cluster of the action
+	ActionCancellationRollingBack = ActionCancellationState("RollingBack")
+
+	// ActionCancellationRolledBack is the state of a cancellation once the
+	// application is rolled back to the source cluster of the action
+	ActionCancellationRolledBack = ActionCancellationState("RolledBack")
+
+	// ActionCancellationRefused is the state of a cancellation requested once
+	// the action is past the point of no return, which the action ignores
+	ActionCancellationRefused = ActionCancellationState("Refused")
 )
 
+// ActionCancellation records the cancellation of a failover or relocation
+type ActionCancellation struct {
+	// Action cancelled
+	Action DRAction `json:"action"`
+
+	// TargetCluster of the action cancelled
+	TargetCluster string `json:"targetCluster"`
+
+	// SourceCluster the application is rolled back to
+	// +optional
+	SourceCluster string `json:"sourceCluster,omitempty"`
+
+	// State of the cancellation
+	State ActionCancellationState `json:"state"`
+
+	// Message describing the state of the cancellation
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Time of the last state change of the cancellation
+	Time metav1.Time `json:"time"`
+}
+
+// SplitBrainPVC is the replication state of a PVC of the VRG of a cluster that
+// reports it as Primary during a split-brain
+type SplitBrainPVC struct {
+	// ClusterName is the cluster of the VRG
+	ClusterName string `json:"clusterName"`
+
+	// Name of the PVC
+	Name string `json:"name"`
+
+	// DataReady is the status of the DataReady condition of the PVC
+	// +optional
+	DataReady metav1.ConditionStatus `json:"dataReady,omitempty"`
+
+	// DataProtected is the status of the DataProtected condition of the PVC
+	// +optional
+	DataProtected metav1.ConditionStatus `json:"dataProtected,omitempty"`
+}
+
+// SplitBrainResolution records the resolution of a split-brain
+type SplitBrainResolution struct {
+	// Winner is the cluster kept as Primary
+	Winner string `json:"winner"`
+
+	// Demoted are the clusters whose VRGs were demoted to Secondary
+	Demoted []string `json:"demoted,omitempty"`
+
+	// ResyncRequestID is the resync request of the demoted VRGs, if any
+	// +optional
+	ResyncRequestID string `json:"resyncRequestID,omitempty"`
+
+	// Time of the resolution
+	Time metav1.Time `json:"time"`
+}
+
 // VRGResourceMeta represents the VRG resource.
 type VRGResourceMeta struct {
 	// Kind is the kind of the Kubernetes resource.
@@ -138,6 +410,40 @@ type DRPlacementControlStatus struct {
 	Conditions         []metav1.Condition      `json:"conditions,omitempty"`
 	ResourceConditions VRGConditions           `json:"resourceConditions,omitempty"`
 	LastUpdateTime     metav1.Time             `json:"lastUpdateTime"`
+
+	// observedGeneration is the last generation change the operator has dealt with
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// SplitBrainPrimaries are the clusters that report the VRG of the DRPC as
+	// Primary, while more than one does
+	// +optional
+	SplitBrainPrimaries []string `json:"splitBrainPrimaries,omitempty"`
+
+	// SplitBrainPVCs are the replication states of the PVCs of the VRGs of the
+	// SplitBrainPrimaries
+	// +optional
+	SplitBrainPVCs []SplitBrainPVC `json:"splitBrainPVCs,omitempty"`
+
+	// SplitBrainResolution records the last split-brain resolution
+	// +optional
+	SplitBrainResolution *SplitBrainResolution `json:"splitBrainResolution,omitempty"`
+
+	// ActionOrigin records where the last failover or relocation started from
+	// +optional
+	ActionOrigin *ActionOrigin `json:"actionOrigin,omitempty"`
+
+	// ActionCancellation records the last cancellation of an action
+	// +optional
+	ActionCancellation *ActionCancellation `json:"actionCancellation,omitempty"`
+
+	// ActionHistory records the last failovers and relocations, oldest first
+	// +optional
+	ActionHistory []ActionRecord `json:"actionHistory,omitempty"`
+
+	// ActionApproval records the approval of the last action that required one
+	// +optional
+	ActionApproval *ActionApproval `json:"actionApproval,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,293 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var drplacementcontrollog = logf.Log.WithName("drplacementcontrol-webhook")
+
+// drplacementcontrolMutatePath is the path of the webhook that records the
+// requester and the approver of the action of a DRPlacementControl
+const drplacementcontrolMutatePath = "/mutate-ramendr-openshift-io-v1alpha1-drplacementcontrol"
+
+// SetupWebhookWithManager sets up the validating webhook, and the mutating
+// webhook that records the requester and the approver of the action, with the
+// Manager
+func (r *DRPlacementControl) SetupWebhookWithManager(mgr ctrl.Manager,
+	s3ProfileValidator S3ProfileValidator) error {
+	setupWebhookDependencies(mgr, s3ProfileValidator)
+
+	decoder, err := admission.NewDecoder(mgr.GetScheme())
+	if err != nil {
+		return err
+	}
+
+	mgr.GetWebhookServer().Register(drplacementcontrolMutatePath,
+		&webhook.Admission{Handler: &drplacementcontrolActionUserSetter{decoder: decoder}})
+
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//nolint:lll
+//+kubebuilder:webhook:path=/mutate-ramendr-openshift-io-v1alpha1-drplacementcontrol,mutating=true,failurePolicy=fail,sideEffects=None,groups=ramendr.openshift.io,resources=drplacementcontrols,verbs=create;update,versions=v1alpha1,name=mdrplacementcontrol.kb.io,admissionReviewVersions={v1,v1beta1}
+
+// drplacementcontrolActionUserSetter records the user that requests the
+// action of a DRPlacementControl, and the user that approves or rejects it, in
+// its annotations.  Users may not set these annotations themselves.
+type drplacementcontrolActionUserSetter struct {
+	decoder *admission.Decoder
+}
+
+// Handle records the requester and the approver of the action of the
+// DRPlacementControl of the admission request
+func (s *drplacementcontrolActionUserSetter) Handle(ctx context.Context, req admission.Request) admission.Response {
+	drpc := &DRPlacementControl{}
+	if err := s.decoder.Decode(req, drpc); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	oldDRPC := &DRPlacementControl{}
+	if len(req.OldObject.Raw) != 0 {
+		if err := s.decoder.DecodeRaw(req.OldObject, oldDRPC); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+	}
+
+	decision := drpc.GetAnnotations()[DRPCActionApprovalAnnotation]
+	if decision != "" && decision != string(ActionApprovalApproved) && decision != string(ActionApprovalRejected) {
+		return admission.Denied(fmt.Sprintf("annotation %s must be %s or %s", DRPCActionApprovalAnnotation,
+			ActionApprovalApproved, ActionApprovalRejected))
+	}
+
+	if !drpc.actionUsersSet(ctx, oldDRPC, req.UserInfo.Username) {
+		return admission.Allowed("")
+	}
+
+	marshaled, err := json.Marshal(drpc)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
+
+// actionUsersSet records the user as the requester of the action if the
+// update changes the action or its target cluster, and as the approver if it
+// changes the approval.  Otherwise, the requester and the approver are kept as
+// they were.  Any change to the spec clears the approval, as it requests the
+// action again.  It returns true if the annotations changed.
+func (r *DRPlacementControl) actionUsersSet(ctx context.Context, old *DRPlacementControl, user string) bool {
+	annotations := map[string]string{}
+	for key, value := range r.GetAnnotations() {
+		annotations[key] = value
+	}
+
+	oldAnnotations := old.GetAnnotations()
+	keep := func(key string) {
+		if value, ok := oldAnnotations[key]; ok {
+			annotations[key] = value
+		} else {
+			delete(annotations, key)
+		}
+	}
+
+	if r.Spec.Action != "" &&
+		(r.Spec.Action != old.Spec.Action || r.actionTargetCluster() != old.actionTargetCluster()) {
+		drplacementcontrollog.Info("set action requester", "name", r.Name, "namespace", r.Namespace, "user", user)
+
+		annotations[DRPCActionRequesterAnnotation] = r.actionRequester(ctx, oldAnnotations, user)
+	} else {
+		keep(DRPCActionRequesterAnnotation)
+	}
+
+	if !equality.Semantic.DeepEqual(r.Spec, old.Spec) {
+		delete(annotations, DRPCActionApprovalAnnotation)
+		delete(annotations, DRPCActionApproverAnnotation)
+	} else {
+		decision, decided := annotations[DRPCActionApprovalAnnotation]
+		oldDecision, oldDecided := oldAnnotations[DRPCActionApprovalAnnotation]
+
+		switch {
+		case decision == oldDecision && decided == oldDecided:
+			keep(DRPCActionApproverAnnotation)
+		case decided:
+			drplacementcontrollog.Info("set action approver", "name", r.Name, "namespace", r.Namespace,
+				"user", user, "decision", decision)
+
+			annotations[DRPCActionApproverAnnotation] = user
+		default:
+			delete(annotations, DRPCActionApproverAnnotation)
+		}
+	}
+
+	if equality.Semantic.DeepEqual(annotations, r.GetAnnotations()) ||
+		(len(annotations) == 0 && len(r.GetAnnotations()) == 0) {
+		return false
+	}
+
+	r.SetAnnotations(annotations)
+
+	return true
+}
+
+// actionRequester returns the requester of the action: the requester of the
+// DRActionRequest that sets it, if any, or the user.  The DRActionRequest is
+// trusted only if the user is the Ramen operator, which alone sets the action
+// on behalf of a request, so that no other user may assume the requester of
+// a request by annotating the DRPC with it.
+func (r *DRPlacementControl) actionRequester(ctx context.Context, oldAnnotations map[string]string,
+	user string) string {
+	name := r.GetAnnotations()[DRPCActionRequestAnnotation]
+	if name == "" || name == oldAnnotations[DRPCActionRequestAnnotation] || user != webhookOperatorUsername {
+		return user
+	}
+
+	request := &DRActionRequest{}
+	if err := webhookReader.Get(ctx, types.NamespacedName{Name: name, Namespace: r.Namespace},
+		request); err != nil {
+		return user
+	}
+
+	if request.Spec.Action != r.Spec.Action || request.Spec.TargetCluster != r.actionTargetCluster() ||
+		request.Spec.Requester == "" {
+		return user
+	}
+
+	return request.Spec.Requester
+}
+
+// actionTargetCluster returns the cluster the action moves the application to
+func (r *DRPlacementControl) actionTargetCluster() string {
+	if r.Spec.Action == ActionFailover {
+		return r.Spec.FailoverCluster
+	}
+
+	return r.Spec.PreferredCluster
+}
+
+//nolint:lll
+//+kubebuilder:webhook:path=/validate-ramendr-openshift-io-v1alpha1-drplacementcontrol,mutating=false,failurePolicy=fail,sideEffects=None,groups=ramendr.openshift.io,resources=drplacementcontrols,verbs=create;update,versions=v1alpha1,name=vdrplacementcontrol.kb.io,admissionReviewVersions={v1,v1beta1}
+
+var _ webhook.Validator = &DRPlacementControl{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *DRPlacementControl) ValidateCreate() error {
+	drplacementcontrollog.Info("validate create", "name", r.Name, "namespace", r.Namespace)
+
+	return r.validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+// Updates that do not change the spec, such as finalizer updates, and updates
+// to a resource being deleted, are not validated.
+func (r *DRPlacementControl) ValidateUpdate(old runtime.Object) error {
+	drplacementcontrollog.Info("validate update", "name", r.Name, "namespace", r.Namespace)
+
+	if oldDRPC, ok := old.(*DRPlacementControl); ok && equality.Semantic.DeepEqual(oldDRPC.Spec, r.Spec) {
+		return nil
+	}
+
+	if !r.GetDeletionTimestamp().IsZero() {
+		return nil
+	}
+
+	return r.validate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *DRPlacementControl) ValidateDelete() error {
+	return nil
+}
+
+func (r *DRPlacementControl) validate() error {
+	var allErrs field.ErrorList
+
+	specPath := field.NewPath("spec")
+	policyNamePath := specPath.Child("drPolicyRef", "name")
+
+	if r.Spec.DRPolicyRef.Name == "" {
+		allErrs = append(allErrs, field.Required(policyNamePath, "DRPolicy name is empty"))
+
+		return invalidError("DRPlacementControl", r.Name, allErrs)
+	}
+
+	drpolicy := &DRPolicy{}
+	if err := webhookReader.Get(context.TODO(), types.NamespacedName{Name: r.Spec.DRPolicyRef.Name},
+		drpolicy); err != nil {
+		if apierrors.IsNotFound(err) {
+			allErrs = append(allErrs, field.NotFound(policyNamePath, r.Spec.DRPolicyRef.Name))
+		} else {
+			allErrs = append(allErrs, field.InternalError(policyNamePath, fmt.Errorf("failed to get DRPolicy: %w", err)))
+		}
+
+		return invalidError("DRPlacementControl", r.Name, allErrs)
+	}
+
+	if !drpolicy.GetDeletionTimestamp().IsZero() {
+		allErrs = append(allErrs, field.Invalid(policyNamePath, r.Spec.DRPolicyRef.Name, "DRPolicy is being deleted"))
+
+		return invalidError("DRPlacementControl", r.Name, allErrs)
+	}
+
+	clusterNames := drpolicy.clusterNames()
+
+	for _, cluster := range []struct {
+		path *field.Path
+		name string
+	}{
+		{specPath.Child("preferredCluster"), r.Spec.PreferredCluster},
+		{specPath.Child("failoverCluster"), r.Spec.FailoverCluster},
+	} {
+		if cluster.name != "" && !containsString(clusterNames, cluster.name) {
+			allErrs = append(allErrs, field.NotSupported(cluster.path, cluster.name, clusterNames))
+		}
+	}
+
+	if r.Spec.CancelAction && r.Spec.Action == "" {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("cancelAction"), r.Spec.CancelAction,
+			"no failover or relocation to cancel"))
+	}
+
+	return invalidError("DRPlacementControl", r.Name, allErrs)
+}
+
+func containsString(values []string, s string) bool {
+	for _, value := range values {
+		if value == s {
+			return true
+		}
+	}
+
+	return false
+}
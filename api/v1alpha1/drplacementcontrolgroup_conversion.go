@@ -0,0 +1,101 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	ramendrv1beta1 "github.com/ramendr/ramen/api/v1beta1"
+)
+
+// ConvertTo converts this DRPlacementControlGroup to the Hub version (v1beta1).
+func (src *DRPlacementControlGroup) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*ramendrv1beta1.DRPlacementControlGroup)
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec.Members = nil
+	for _, member := range src.Spec.Members {
+		dst.Spec.Members = append(dst.Spec.Members, ramendrv1beta1.DRPlacementControlGroupMember(member))
+	}
+
+	dst.Spec.Action = ramendrv1beta1.DRAction(src.Spec.Action)
+	dst.Spec.TargetCluster = src.Spec.TargetCluster
+	dst.Spec.FailurePolicy = ramendrv1beta1.DRPlacementControlGroupFailurePolicy(src.Spec.FailurePolicy)
+
+	dst.Status.Action = ramendrv1beta1.DRAction(src.Status.Action)
+	dst.Status.TargetCluster = src.Status.TargetCluster
+	dst.Status.Phase = ramendrv1beta1.DRPlacementControlGroupPhase(src.Status.Phase)
+	dst.Status.Message = src.Status.Message
+	dst.Status.CurrentWave = src.Status.CurrentWave
+	dst.Status.StartTime = src.Status.StartTime
+	dst.Status.CompletionTime = src.Status.CompletionTime
+
+	dst.Status.Members = nil
+	for _, member := range src.Status.Members {
+		dst.Status.Members = append(dst.Status.Members, ramendrv1beta1.DRPlacementControlGroupMemberStatus{
+			Name:           member.Name,
+			Namespace:      member.Namespace,
+			Wave:           member.Wave,
+			State:          ramendrv1beta1.DRPlacementControlGroupMemberState(member.State),
+			Message:        member.Message,
+			StartTime:      member.StartTime,
+			CompletionTime: member.CompletionTime,
+		})
+	}
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *DRPlacementControlGroup) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*ramendrv1beta1.DRPlacementControlGroup)
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec.Members = nil
+	for _, member := range src.Spec.Members {
+		dst.Spec.Members = append(dst.Spec.Members, DRPlacementControlGroupMember(member))
+	}
+
+	dst.Spec.Action = DRAction(src.Spec.Action)
+	dst.Spec.TargetCluster = src.Spec.TargetCluster
+	dst.Spec.FailurePolicy = DRPlacementControlGroupFailurePolicy(src.Spec.FailurePolicy)
+
+	dst.Status.Action = DRAction(src.Status.Action)
+	dst.Status.TargetCluster = src.Status.TargetCluster
+	dst.Status.Phase = DRPlacementControlGroupPhase(src.Status.Phase)
+	dst.Status.Message = src.Status.Message
+	dst.Status.CurrentWave = src.Status.CurrentWave
+	dst.Status.StartTime = src.Status.StartTime
+	dst.Status.CompletionTime = src.Status.CompletionTime
+
+	dst.Status.Members = nil
+	for _, member := range src.Status.Members {
+		dst.Status.Members = append(dst.Status.Members, DRPlacementControlGroupMemberStatus{
+			Name:           member.Name,
+			Namespace:      member.Namespace,
+			Wave:           member.Wave,
+			State:          DRPlacementControlGroupMemberState(member.State),
+			Message:        member.Message,
+			StartTime:      member.StartTime,
+			CompletionTime: member.CompletionTime,
+		})
+	}
+
+	return nil
+}
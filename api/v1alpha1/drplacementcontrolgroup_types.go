@@ -0,0 +1,216 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DRPlacementControlGroupMember is a DRPlacementControl of a group
+type DRPlacementControlGroupMember struct {
+	// Name of the DRPlacementControl
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace of the DRPlacementControl
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+
+	// Wave of the DRPlacementControl.  The action of the group is performed on
+	// the members of a wave once it has completed for all the members of the
+	// preceding waves.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Wave int `json:"wave,omitempty"`
+}
+
+// DRPlacementControlGroupFailurePolicy is what the action of a group does once
+// it fails for a member
+// +kubebuilder:validation:Enum=Halt;Continue
+type DRPlacementControlGroupFailurePolicy string
+
+const (
+	// DRPlacementControlGroupHalt halts the action of the group at the wave of
+	// the member it failed for
+	DRPlacementControlGroupHalt = DRPlacementControlGroupFailurePolicy("Halt")
+
+	// DRPlacementControlGroupContinue continues the action of the group with
+	// the following waves
+	DRPlacementControlGroupContinue = DRPlacementControlGroupFailurePolicy("Continue")
+)
+
+// DRPlacementControlGroupSpec defines the desired state of DRPlacementControlGroup
+type DRPlacementControlGroupSpec struct {
+	// Members of the group
+	// +kubebuilder:validation:MinItems=1
+	Members []DRPlacementControlGroupMember `json:"members"`
+
+	// Action to perform on the members of the group, wave by wave
+	// +optional
+	Action DRAction `json:"action,omitempty"`
+
+	// TargetCluster is the cluster to fail over or relocate the members to
+	// +optional
+	TargetCluster string `json:"targetCluster,omitempty"`
+
+	// FailurePolicy is what the action does once it fails for a member
+	// +kubebuilder:default=Halt
+	// +optional
+	FailurePolicy DRPlacementControlGroupFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// DRPlacementControlGroupPhase is the phase of the action of a group
+type DRPlacementControlGroupPhase string
+
+const (
+	// DRPlacementControlGroupRunning is the phase of an action in progress
+	DRPlacementControlGroupRunning = DRPlacementControlGroupPhase("Running")
+
+	// DRPlacementControlGroupSucceeded is the phase of an action completed for
+	// all the members
+	DRPlacementControlGroupSucceeded = DRPlacementControlGroupPhase("Succeeded")
+
+	// DRPlacementControlGroupFailed is the phase of an action completed that
+	// failed for any member, or halted
+	DRPlacementControlGroupFailed = DRPlacementControlGroupPhase("Failed")
+)
+
+// DRPlacementControlGroupMemberState is the state of the action of a group on
+// a member
+type DRPlacementControlGroupMemberState string
+
+const (
+	// DRPlacementControlGroupMemberPending is the state of a member whose wave
+	// is yet to start
+	DRPlacementControlGroupMemberPending = DRPlacementControlGroupMemberState("Pending")
+
+	// DRPlacementControlGroupMemberInProgress is the state of a member whose
+	// action is requested, until it completes and the member is available
+	DRPlacementControlGroupMemberInProgress = DRPlacementControlGroupMemberState("InProgress")
+
+	// DRPlacementControlGroupMemberSucceeded is the state of a member whose
+	// action completed, and that is available
+	DRPlacementControlGroupMemberSucceeded = DRPlacementControlGroupMemberState("Succeeded")
+
+	// DRPlacementControlGroupMemberFailed is the state of a member whose action
+	// could not be started, or did not complete
+	DRPlacementControlGroupMemberFailed = DRPlacementControlGroupMemberState("Failed")
+
+	// DRPlacementControlGroupMemberSkipped is the state of a member whose wave
+	// did not start, as the action halted
+	DRPlacementControlGroupMemberSkipped = DRPlacementControlGroupMemberState("Skipped")
+)
+
+// DRPlacementControlGroupMemberStatus is the outcome of the action of a group
+// on a member
+type DRPlacementControlGroupMemberStatus struct {
+	// Name of the DRPlacementControl
+	Name string `json:"name"`
+
+	// Namespace of the DRPlacementControl
+	Namespace string `json:"namespace"`
+
+	// Wave of the DRPlacementControl
+	// +optional
+	Wave int `json:"wave,omitempty"`
+
+	// State of the action on the DRPlacementControl
+	State DRPlacementControlGroupMemberState `json:"state"`
+
+	// Message describing the state of the action on the DRPlacementControl
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// StartTime is the time the action was requested from the DRPlacementControl
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time the action on the DRPlacementControl succeeded
+	// or failed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// DRPlacementControlGroupStatus defines the observed state of DRPlacementControlGroup
+type DRPlacementControlGroupStatus struct {
+	// Action of the group last started
+	// +optional
+	Action DRAction `json:"action,omitempty"`
+
+	// TargetCluster of the action of the group last started
+	// +optional
+	TargetCluster string `json:"targetCluster,omitempty"`
+
+	// Phase of the action
+	// +optional
+	Phase DRPlacementControlGroupPhase `json:"phase,omitempty"`
+
+	// Message describing the phase of the action
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// CurrentWave is the wave of the members whose action is in progress
+	// +optional
+	CurrentWave int `json:"currentWave,omitempty"`
+
+	// Members are the outcome of the action on each member, in the order it is
+	// performed
+	// +optional
+	Members []DRPlacementControlGroupMemberStatus `json:"members,omitempty"`
+
+	// StartTime is the time the action started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time the action completed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=drpcg
+// +kubebuilder:printcolumn:JSONPath=".spec.action",name=action,type=string
+// +kubebuilder:printcolumn:JSONPath=".spec.targetCluster",name=target,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.phase",name=phase,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.currentWave",name=wave,type=integer
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",name=Age,type=date
+
+// DRPlacementControlGroup is the Schema for the drplacementcontrolgroups API.
+// It fails over or relocates its member DRPlacementControls wave by wave,
+// starting a wave once the members of the preceding waves are available on
+// the target cluster.
+type DRPlacementControlGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DRPlacementControlGroupSpec   `json:"spec,omitempty"`
+	Status DRPlacementControlGroupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DRPlacementControlGroupList contains a list of DRPlacementControlGroup
+type DRPlacementControlGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DRPlacementControlGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DRPlacementControlGroup{}, &DRPlacementControlGroupList{})
+}
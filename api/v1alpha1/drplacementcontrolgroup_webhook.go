@@ -0,0 +1,138 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+var drplacementcontrolgrouplog = logf.Log.WithName("drplacementcontrolgroup-webhook")
+
+// SetupWebhookWithManager sets up the validating webhook with the Manager
+func (r *DRPlacementControlGroup) SetupWebhookWithManager(mgr ctrl.Manager, s3ProfileValidator S3ProfileValidator,
+) error {
+	setupWebhookDependencies(mgr, s3ProfileValidator)
+
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//nolint:lll
+//+kubebuilder:webhook:path=/validate-ramendr-openshift-io-v1alpha1-drplacementcontrolgroup,mutating=false,failurePolicy=fail,sideEffects=None,groups=ramendr.openshift.io,resources=drplacementcontrolgroups,verbs=create;update,versions=v1alpha1,name=vdrplacementcontrolgroup.kb.io,admissionReviewVersions={v1,v1beta1}
+
+var _ webhook.Validator = &DRPlacementControlGroup{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *DRPlacementControlGroup) ValidateCreate() error {
+	drplacementcontrolgrouplog.Info("validate create", "name", r.Name)
+
+	return r.validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *DRPlacementControlGroup) ValidateUpdate(old runtime.Object) error {
+	drplacementcontrolgrouplog.Info("validate update", "name", r.Name)
+
+	return r.validate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *DRPlacementControlGroup) ValidateDelete() error {
+	return nil
+}
+
+func (r *DRPlacementControlGroup) validate() error {
+	var allErrs field.ErrorList
+
+	specPath := field.NewPath("spec")
+	membersPath := specPath.Child("members")
+
+	members := map[types.NamespacedName]bool{}
+
+	for idx, member := range r.Spec.Members {
+		name := types.NamespacedName{Name: member.Name, Namespace: member.Namespace}
+		if members[name] {
+			allErrs = append(allErrs, field.Duplicate(membersPath.Index(idx), name.String()))
+		}
+
+		members[name] = true
+	}
+
+	switch r.Spec.Action {
+	case "":
+		return invalidError("DRPlacementControlGroup", r.Name, allErrs)
+	case ActionFailover, ActionRelocate:
+	default:
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("action"), r.Spec.Action,
+			[]string{string(ActionFailover), string(ActionRelocate)}))
+
+		return invalidError("DRPlacementControlGroup", r.Name, allErrs)
+	}
+
+	if r.Spec.TargetCluster == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("targetCluster"),
+			"target cluster is required by the action"))
+
+		return invalidError("DRPlacementControlGroup", r.Name, allErrs)
+	}
+
+	for idx, member := range r.Spec.Members {
+		allErrs = append(allErrs, r.validateMember(membersPath.Index(idx), member)...)
+	}
+
+	return invalidError("DRPlacementControlGroup", r.Name, allErrs)
+}
+
+// validateMember checks that the member exists, and that the target cluster
+// of the action is one of the clusters of its DRPolicy
+func (r *DRPlacementControlGroup) validateMember(memberPath *field.Path, member DRPlacementControlGroupMember,
+) field.ErrorList {
+	name := types.NamespacedName{Name: member.Name, Namespace: member.Namespace}
+
+	drpc := &DRPlacementControl{}
+	if err := webhookReader.Get(context.TODO(), name, drpc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return field.ErrorList{field.NotFound(memberPath, name.String())}
+		}
+
+		return field.ErrorList{field.InternalError(memberPath, fmt.Errorf("failed to get DRPlacementControl: %w", err))}
+	}
+
+	drpolicy := &DRPolicy{}
+	if err := webhookReader.Get(context.TODO(), types.NamespacedName{Name: drpc.Spec.DRPolicyRef.Name},
+		drpolicy); err != nil {
+		return field.ErrorList{field.InternalError(memberPath, fmt.Errorf("failed to get DRPolicy: %w", err))}
+	}
+
+	if clusterNames := drpolicy.clusterNames(); !containsString(clusterNames, r.Spec.TargetCluster) {
+		return field.ErrorList{field.Invalid(memberPath, name.String(),
+			fmt.Sprintf("target cluster %s is not one of the clusters %v of DRPolicy %s", r.Spec.TargetCluster,
+				clusterNames, drpolicy.Name))}
+	}
+
+	return nil
+}
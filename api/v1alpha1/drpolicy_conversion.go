@@ -0,0 +1,75 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	ramendrv1beta1 "github.com/ramendr/ramen/api/v1beta1"
+)
+
+// ConvertTo converts this DRPolicy to the Hub version (v1beta1).
+func (src *DRPolicy) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*ramendrv1beta1.DRPolicy)
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec.SchedulingInterval = src.Spec.SchedulingInterval
+	dst.Spec.ReplicationClassSelector = src.Spec.ReplicationClassSelector
+	dst.Spec.ReplicationMode = ramendrv1beta1.ReplicationMode(src.Spec.ReplicationMode)
+	dst.Spec.TopologyRule = ramendrv1beta1.ClusterTopologyRule(src.Spec.TopologyRule)
+	dst.Spec.AutoFailover = (*ramendrv1beta1.AutoFailover)(src.Spec.AutoFailover)
+	dst.Spec.ActionApproval = (*ramendrv1beta1.ActionApprovalPolicy)(src.Spec.ActionApproval)
+
+	dst.Spec.DRClusterSet = nil
+	for _, cluster := range src.Spec.DRClusterSet {
+		dst.Spec.DRClusterSet = append(dst.Spec.DRClusterSet, ramendrv1beta1.ManagedCluster(cluster))
+	}
+
+	dst.Status.Conditions = src.Status.Conditions
+	dst.Status.ClusterSet = src.Status.ClusterSet
+	dst.Status.AutoFailoverTimes = src.Status.AutoFailoverTimes
+	dst.Status.LastHealthCheckTime = src.Status.LastHealthCheckTime
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *DRPolicy) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*ramendrv1beta1.DRPolicy)
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec.SchedulingInterval = src.Spec.SchedulingInterval
+	dst.Spec.ReplicationClassSelector = src.Spec.ReplicationClassSelector
+	dst.Spec.ReplicationMode = ReplicationMode(src.Spec.ReplicationMode)
+	dst.Spec.TopologyRule = ClusterTopologyRule(src.Spec.TopologyRule)
+	dst.Spec.AutoFailover = (*AutoFailover)(src.Spec.AutoFailover)
+	dst.Spec.ActionApproval = (*ActionApprovalPolicy)(src.Spec.ActionApproval)
+
+	dst.Spec.DRClusterSet = nil
+	for _, cluster := range src.Spec.DRClusterSet {
+		dst.Spec.DRClusterSet = append(dst.Spec.DRClusterSet, ManagedCluster(cluster))
+	}
+
+	dst.Status.Conditions = src.Status.Conditions
+	dst.Status.ClusterSet = src.Status.ClusterSet
+	dst.Status.AutoFailoverTimes = src.Status.AutoFailoverTimes
+	dst.Status.LastHealthCheckTime = src.Status.LastHealthCheckTime
+
+	return nil
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+var drpolicylog = logf.Log.WithName("drpolicy-webhook")
+
+// SetupWebhookWithManager sets up the validating webhook with the Manager
+func (r *DRPolicy) SetupWebhookWithManager(mgr ctrl.Manager, s3ProfileValidator S3ProfileValidator) error {
+	setupWebhookDependencies(mgr, s3ProfileValidator)
+
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//nolint:lll
+//+kubebuilder:webhook:path=/validate-ramendr-openshift-io-v1alpha1-drpolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=ramendr.openshift.io,resources=drpolicies,verbs=create;update,versions=v1alpha1,name=vdrpolicy.kb.io,admissionReviewVersions={v1,v1beta1}
+
+var _ webhook.Validator = &DRPolicy{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *DRPolicy) ValidateCreate() error {
+	drpolicylog.Info("validate create", "name", r.Name)
+
+	return r.validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+// Updates that do not change the spec, such as finalizer updates, and updates
+// to a resource being deleted, are not validated.
+func (r *DRPolicy) ValidateUpdate(old runtime.Object) error {
+	drpolicylog.Info("validate update", "name", r.Name)
+
+	oldDRPolicy, ok := old.(*DRPolicy)
+	if ok && equality.Semantic.DeepEqual(oldDRPolicy.Spec, r.Spec) {
+		return nil
+	}
+
+	if !r.GetDeletionTimestamp().IsZero() {
+		return nil
+	}
+
+	if err := r.validate(); err != nil {
+		return err
+	}
+
+	if !ok {
+		return nil
+	}
+
+	if oldDRPolicy.Spec.ReplicationMode != r.Spec.ReplicationMode {
+		return invalidError("DRPolicy", r.Name, field.ErrorList{
+			field.Forbidden(field.NewPath("spec").Child("replicationMode"), "field is immutable"),
+		})
+	}
+
+	return r.validateClusterRemoval(oldDRPolicy)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *DRPolicy) ValidateDelete() error {
+	return nil
+}
+
+func (r *DRPolicy) validate() error {
+	var allErrs field.ErrorList
+
+	specPath := field.NewPath("spec")
+
+	if err := validateReplicationSchedule(r.Spec.ReplicationMode, r.Spec.SchedulingInterval); err != nil {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("schedulingInterval"),
+			r.Spec.SchedulingInterval, err.Error()))
+	}
+
+	clusterNames := map[string]bool{}
+
+	for index, cluster := range r.Spec.DRClusterSet {
+		clusterPath := specPath.Child("drClusterSet").Index(index)
+
+		switch {
+		case cluster.Name == "":
+			allErrs = append(allErrs, field.Required(clusterPath.Child("name"), "cluster name is empty"))
+		case clusterNames[cluster.Name]:
+			allErrs = append(allErrs, field.Duplicate(clusterPath.Child("name"), cluster.Name))
+		default:
+			clusterNames[cluster.Name] = true
+		}
+
+		if err := validateS3Profile(cluster.S3ProfileName); err != nil {
+			allErrs = append(allErrs, field.Invalid(clusterPath.Child("s3ProfileName"),
+				cluster.S3ProfileName, err.Error()))
+		}
+	}
+
+	allErrs = append(allErrs, r.validateTopology(specPath)...)
+
+	return invalidError("DRPolicy", r.Name, allErrs)
+}
+
+// validateTopology rejects clusters that share a failure domain under the
+// topology rule of the policy.  Clusters whose region is not in the spec are
+// left to the DRPolicy controller, which also considers their DRCluster.
+func (r *DRPolicy) validateTopology(specPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if r.Spec.TopologyRule == "" {
+		return allErrs
+	}
+
+	domains := map[string]string{}
+
+	for index, cluster := range r.Spec.DRClusterSet {
+		domain := r.Spec.TopologyRule.FailureDomain(cluster.Region, cluster.Zone)
+		if domain == "" {
+			continue
+		}
+
+		if peer, found := domains[domain]; found {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("drClusterSet").Index(index).Child("region"),
+				cluster.Region, fmt.Sprintf("cluster %s shares failure domain %s with cluster %s, violating %s",
+					cluster.Name, domain, peer, r.Spec.TopologyRule)))
+
+			continue
+		}
+
+		domains[domain] = cluster.Name
+	}
+
+	return allErrs
+}
+
+// FailureDomain returns the failure domain of a cluster in the given region and
+// zone under the topology rule, or an empty string if it is not known
+func (rule ClusterTopologyRule) FailureDomain(region, zone string) string {
+	switch {
+	case region == "":
+		return ""
+	case rule != ClusterTopologyDistinctZones:
+		return region
+	case zone == "":
+		return ""
+	default:
+		return region + "/" + zone
+	}
+}
+
+// validateClusterRemoval refuses the removal of a cluster that hosts the
+// primary of a DRPlacementControl referring to the policy, as it could then no
+// longer be relocated or failed over from the cluster
+func (r *DRPolicy) validateClusterRemoval(oldDRPolicy *DRPolicy) error {
+	clusterNames := r.clusterNames()
+	removed := []string{}
+
+	for _, clusterName := range oldDRPolicy.clusterNames() {
+		if !containsString(clusterNames, clusterName) {
+			removed = append(removed, clusterName)
+		}
+	}
+
+	if len(removed) == 0 || webhookReader == nil {
+		return nil
+	}
+
+	clusterSetPath := field.NewPath("spec").Child("drClusterSet")
+
+	drpcs := &DRPlacementControlList{}
+	if err := webhookReader.List(context.TODO(), drpcs); err != nil {
+		return invalidError("DRPolicy", r.Name, field.ErrorList{
+			field.InternalError(clusterSetPath, fmt.Errorf("failed to list DRPlacementControls: %w", err)),
+		})
+	}
+
+	var allErrs field.ErrorList
+
+	for idx := range drpcs.Items {
+		drpc := &drpcs.Items[idx]
+		if drpc.Spec.DRPolicyRef.Name != r.Name ||
+			!containsString(removed, drpc.Status.PreferredDecision.ClusterName) {
+			continue
+		}
+
+		allErrs = append(allErrs, field.Forbidden(clusterSetPath, fmt.Sprintf(
+			"cluster %s hosts the primary of DRPlacementControl %s/%s, relocate or fail it over first",
+			drpc.Status.PreferredDecision.ClusterName, drpc.Namespace, drpc.Name)))
+	}
+
+	return invalidError("DRPolicy", r.Name, allErrs)
+}
+
+// clusterNames returns the names of the clusters in the DRClusterSet of the policy
+func (r *DRPolicy) clusterNames() []string {
+	names := make([]string, 0, len(r.Spec.DRClusterSet))
+
+	for _, cluster := range r.Spec.DRClusterSet {
+		names = append(names, cluster.Name)
+	}
+
+	return names
+}
@@ -30,11 +30,11 @@ import (
 type ControllerType string
 
 const (
-	// DRCluster operates as the DR cluster controller on a peer cluster
-	DRCluster ControllerType = "dr-cluster"
+	// DRClusterType operates as the DR cluster controller on a peer cluster
+	DRClusterType ControllerType = "dr-cluster"
 
-	// DRHub operates as the DR hub controller on a cluster managing DR across peer clusters
-	DRHub ControllerType = "dr-hub"
+	// DRHubType operates as the DR hub controller on a cluster managing DR across peer clusters
+	DRHubType ControllerType = "dr-hub"
 )
 
 // Profile of a S3 compatible store to replicate the relevant Kubernetes cluster
@@ -89,7 +89,11 @@ type RamenConfig struct {
 
 	// MaxConcurrentReconciles is the maximum number of concurrent Reconciles which can be run.
 	// Defaults to 1.
-	MaxConcurrentReconciles int
+	MaxConcurrentReconciles int `json:"maxConcurrentReconciles,omitempty"`
+
+	// AutoFailoverDisabled, when true, stops the automatic failover of the
+	// DRPlacementControls of all DRPolicies that opt in to it
+	AutoFailoverDisabled bool `json:"autoFailoverDisabled,omitempty"`
 }
 
 func init() {
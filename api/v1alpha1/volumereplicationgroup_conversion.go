@@ -0,0 +1,135 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	ramendrv1beta1 "github.com/ramendr/ramen/api/v1beta1"
+)
+
+// ConvertTo converts this VolumeReplicationGroup to the Hub version (v1beta1).
+func (src *VolumeReplicationGroup) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*ramendrv1beta1.VolumeReplicationGroup)
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec.PVCSelector = src.Spec.PVCSelector
+	dst.Spec.ReplicationClassSelector = src.Spec.ReplicationClassSelector
+	dst.Spec.SchedulingInterval = src.Spec.SchedulingInterval
+	dst.Spec.ReplicationMode = ramendrv1beta1.ReplicationMode(src.Spec.ReplicationMode)
+	dst.Spec.ReplicationState = ramendrv1beta1.ReplicationState(src.Spec.ReplicationState)
+	dst.Spec.S3Profiles = src.Spec.S3ProfileList
+	dst.Spec.Preview = src.Spec.Preview
+
+	dst.Spec.ConsistencyGroups = nil
+	for _, group := range src.Spec.ConsistencyGroups {
+		dst.Spec.ConsistencyGroups = append(dst.Spec.ConsistencyGroups, ramendrv1beta1.ConsistencyGroup(group))
+	}
+
+	dst.Spec.Resync = nil
+	if src.Spec.Resync != nil {
+		resync := ramendrv1beta1.ResyncRequest(*src.Spec.Resync)
+		dst.Spec.Resync = &resync
+	}
+
+	dst.Status.State = ramendrv1beta1.State(src.Status.State)
+	dst.Status.Conditions = src.Status.Conditions
+	dst.Status.ObservedGeneration = src.Status.ObservedGeneration
+	dst.Status.LastUpdateTime = src.Status.LastUpdateTime
+
+	dst.Status.ProtectedPVCs = nil
+	for _, protectedPVC := range src.Status.ProtectedPVCs {
+		dst.Status.ProtectedPVCs = append(dst.Status.ProtectedPVCs, ramendrv1beta1.ProtectedPVC(protectedPVC))
+	}
+
+	dst.Status.ProtectionPreview = nil
+	if src.Status.ProtectionPreview != nil {
+		dst.Status.ProtectionPreview = &ramendrv1beta1.ProtectionPreview{
+			ObservedGeneration: src.Status.ProtectionPreview.ObservedGeneration,
+		}
+
+		for _, pvc := range src.Status.ProtectionPreview.PVCs {
+			dst.Status.ProtectionPreview.PVCs = append(dst.Status.ProtectionPreview.PVCs,
+				ramendrv1beta1.PVCPreview{
+					Name:                   pvc.Name,
+					Result:                 ramendrv1beta1.PVCPreviewResult(pvc.Result),
+					VolumeReplicationClass: pvc.VolumeReplicationClass,
+					ConsistencyGroup:       pvc.ConsistencyGroup,
+					Message:                pvc.Message,
+				})
+		}
+	}
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *VolumeReplicationGroup) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*ramendrv1beta1.VolumeReplicationGroup)
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec.PVCSelector = src.Spec.PVCSelector
+	dst.Spec.ReplicationClassSelector = src.Spec.ReplicationClassSelector
+	dst.Spec.SchedulingInterval = src.Spec.SchedulingInterval
+	dst.Spec.ReplicationMode = ReplicationMode(src.Spec.ReplicationMode)
+	dst.Spec.ReplicationState = ReplicationState(src.Spec.ReplicationState)
+	dst.Spec.S3ProfileList = src.Spec.S3Profiles
+	dst.Spec.Preview = src.Spec.Preview
+
+	dst.Spec.ConsistencyGroups = nil
+	for _, group := range src.Spec.ConsistencyGroups {
+		dst.Spec.ConsistencyGroups = append(dst.Spec.ConsistencyGroups, ConsistencyGroup(group))
+	}
+
+	dst.Spec.Resync = nil
+	if src.Spec.Resync != nil {
+		resync := ResyncRequest(*src.Spec.Resync)
+		dst.Spec.Resync = &resync
+	}
+
+	dst.Status.State = State(src.Status.State)
+	dst.Status.Conditions = src.Status.Conditions
+	dst.Status.ObservedGeneration = src.Status.ObservedGeneration
+	dst.Status.LastUpdateTime = src.Status.LastUpdateTime
+
+	dst.Status.ProtectedPVCs = nil
+	for _, protectedPVC := range src.Status.ProtectedPVCs {
+		dst.Status.ProtectedPVCs = append(dst.Status.ProtectedPVCs, ProtectedPVC(protectedPVC))
+	}
+
+	dst.Status.ProtectionPreview = nil
+	if src.Status.ProtectionPreview != nil {
+		dst.Status.ProtectionPreview = &ProtectionPreview{
+			ObservedGeneration: src.Status.ProtectionPreview.ObservedGeneration,
+		}
+
+		for _, pvc := range src.Status.ProtectionPreview.PVCs {
+			dst.Status.ProtectionPreview.PVCs = append(dst.Status.ProtectionPreview.PVCs,
+				PVCPreview{
+					Name:                   pvc.Name,
+					Result:                 PVCPreviewResult(pvc.Result),
+					VolumeReplicationClass: pvc.VolumeReplicationClass,
+					ConsistencyGroup:       pvc.ConsistencyGroup,
+					Message:                pvc.Message,
+				})
+		}
+	}
+
+	return nil
+}
@@ -76,9 +76,17 @@ type VolumeReplicationGroupSpec struct {
 	// data to a peer cluster. Interval is typically in the
 	// form <num><m,h,d>. Here <num> is a number, 'm' means
 	// minutes, 'h' means hours and 'd' stands for days.
-	// +kubebuilder:validation:Required
+	// Required in Async replication mode, and not supported
+	// in Sync replication mode.
+	// +optional
 	// +kubebuilder:validation:Pattern=`^\d+[mhd]$`
-	SchedulingInterval string `json:"schedulingInterval"`
+	SchedulingInterval string `json:"schedulingInterval,omitempty"`
+
+	// Mode of replication of the volumes, as in the DRPolicy.  In Sync mode,
+	// the VolumeReplicationClass of a volume is one without a scheduling
+	// interval, and volumes are neither demoted nor resynced as secondary.
+	// +optional
+	ReplicationMode ReplicationMode `json:"replicationMode,omitempty"`
 
 	// Desired state of all volumes [primary or secondary] in this replication group;
 	// this value is propagated to children VolumeReplication CRs
@@ -87,12 +95,116 @@ type VolumeReplicationGroupSpec struct {
 	// List of unique S3 profiles in RamenConfig that should be used to store
 	// and forward PV related cluster state to peer DR clusters.
 	S3ProfileList []string `json:"s3ProfileName,omitempty"`
+
+	// List of consistency groups, each identifying a subset of the PVCs
+	// selected by PVCSelector whose volumes are replicated together, as a
+	// single unit, to a crash-consistent point. PVCs that are not part of any
+	// consistency group are replicated individually.
+	//+optional
+	ConsistencyGroups []ConsistencyGroup `json:"consistencyGroups,omitempty"`
+
+	// Request to resync the volumes of PVCs, as secondary, with their current
+	// primary.  Typically required post a failover, when the volumes of the
+	// old primary have diverged from the new primary (split-brain).  Ignored
+	// when ReplicationState is primary.
+	//+optional
+	Resync *ResyncRequest `json:"resync,omitempty"`
+
+	// Preview the protection of PVCs, without protecting any of them.  The
+	// protection plan is reported in the status, and no VolumeReplication
+	// resources, finalizers or S3 uploads are created.  Ignored once the
+	// VolumeReplicationGroup has started protecting PVCs.
+	//+optional
+	Preview bool `json:"preview,omitempty"`
+}
+
+// PVCPreviewResult is the outcome of protecting a pvc, as previewed
+type PVCPreviewResult string
+
+const (
+	// PVCProtectable indicates that the pvc would be protected using the
+	// previewed VolumeReplicationClass
+	PVCProtectable PVCPreviewResult = "Protectable"
+
+	// PVCSkipped indicates that the pvc would be skipped, until its state
+	// changes (for example, once bound)
+	PVCSkipped PVCPreviewResult = "Skipped"
+
+	// PVCNoReplicationClass indicates that no VolumeReplicationClass matches
+	// the pvc storage provisioner and the VRG schedule
+	PVCNoReplicationClass PVCPreviewResult = "NoReplicationClass"
+)
+
+// PVCPreview is the previewed protection of a pvc selected by PVCSelector
+type PVCPreview struct {
+	// Name of the pvc
+	Name string `json:"name"`
+
+	// Result of protecting the pvc
+	Result PVCPreviewResult `json:"result"`
+
+	// Name of the VolumeReplicationClass that would be used to replicate the pvc
+	//+optional
+	VolumeReplicationClass string `json:"volumeReplicationClass,omitempty"`
+
+	// Name of the consistency group the pvc would be replicated with, if any
+	//+optional
+	ConsistencyGroup string `json:"consistencyGroup,omitempty"`
+
+	// Reason the pvc would be skipped, or has no VolumeReplicationClass
+	//+optional
+	Message string `json:"message,omitempty"`
+}
+
+// ProtectionPreview is the plan to protect PVCs, computed when Preview is set
+type ProtectionPreview struct {
+	// Generation of the VolumeReplicationGroup the preview was computed for
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Previewed protection of each pvc selected by PVCSelector
+	PVCs []PVCPreview `json:"pvcs,omitempty"`
+}
+
+// ResyncRequest identifies PVCs whose volumes need to be resynced.  A resync
+// is performed once per RequestID for each PVC, and its completion is recorded
+// in the status of the protected PVC.
+type ResyncRequest struct {
+	// Unique identifier of the request.  Change it to request a new resync.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	RequestID string `json:"requestID"`
+
+	// Names of the PVCs to resync.  All PVCs of the VolumeReplicationGroup
+	// are resynced when empty.  PVCs of a consistency group are resynced as a
+	// group, when any of its PVCs is listed.
+	//+optional
+	PVCNames []string `json:"pvcNames,omitempty"`
+}
+
+// ConsistencyGroup identifies a set of PVCs that are replicated using a single
+// group level replication resource (VolumeGroupReplication), instead of a
+// VolumeReplication resource per PVC.
+type ConsistencyGroup struct {
+	// Name of the consistency group, unique within the VolumeReplicationGroup
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Label selector to identify the PVCs, among those selected by the
+	// VolumeReplicationGroup PVCSelector, that belong to this group
+	PVCSelector metav1.LabelSelector `json:"pvcSelector"`
 }
 
 type ProtectedPVC struct {
 	// Name of the VolRep resource
 	Name string `json:"name,omitempty"`
 
+	// Name of the consistency group the pvc is replicated with, if any
+	ConsistencyGroup string `json:"consistencyGroup,omitempty"`
+
+	// RequestID of the last resync request completed for the pvc
+	ResyncRequestID string `json:"resyncRequestID,omitempty"`
+
 	// Conditions for each protected pvc
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
@@ -108,6 +220,11 @@ type VolumeReplicationGroupStatus struct {
 	// Conditions are the list of VRG's summary conditions and their status.
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
+	// Protection plan of the PVCs, reported while Preview is set in the spec
+	// and the VolumeReplicationGroup has not started protecting PVCs
+	//+optional
+	ProtectionPreview *ProtectionPreview `json:"protectionPreview,omitempty"`
+
 	// observedGeneration is the last generation change the operator has dealt with
 	// +optional
 	ObservedGeneration int64       `json:"observedGeneration,omitempty"`
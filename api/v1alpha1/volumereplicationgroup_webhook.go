@@ -0,0 +1,104 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+var volumereplicationgrouplog = logf.Log.WithName("volumereplicationgroup-webhook")
+
+// SetupWebhookWithManager sets up the validating webhook with the Manager
+func (r *VolumeReplicationGroup) SetupWebhookWithManager(mgr ctrl.Manager,
+	s3ProfileValidator S3ProfileValidator) error {
+	setupWebhookDependencies(mgr, s3ProfileValidator)
+
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//nolint:lll
+//+kubebuilder:webhook:path=/validate-ramendr-openshift-io-v1alpha1-volumereplicationgroup,mutating=false,failurePolicy=fail,sideEffects=None,groups=ramendr.openshift.io,resources=volumereplicationgroups,verbs=create;update,versions=v1alpha1,name=vvolumereplicationgroup.kb.io,admissionReviewVersions={v1,v1beta1}
+
+var _ webhook.Validator = &VolumeReplicationGroup{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *VolumeReplicationGroup) ValidateCreate() error {
+	volumereplicationgrouplog.Info("validate create", "name", r.Name, "namespace", r.Namespace)
+
+	return r.validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+// Updates that do not change the spec, such as finalizer updates, and updates
+// to a resource being deleted, are not validated.
+func (r *VolumeReplicationGroup) ValidateUpdate(old runtime.Object) error {
+	volumereplicationgrouplog.Info("validate update", "name", r.Name, "namespace", r.Namespace)
+
+	if oldVRG, ok := old.(*VolumeReplicationGroup); ok && equality.Semantic.DeepEqual(oldVRG.Spec, r.Spec) {
+		return nil
+	}
+
+	if !r.GetDeletionTimestamp().IsZero() {
+		return nil
+	}
+
+	return r.validate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *VolumeReplicationGroup) ValidateDelete() error {
+	return nil
+}
+
+func (r *VolumeReplicationGroup) validate() error {
+	var allErrs field.ErrorList
+
+	specPath := field.NewPath("spec")
+
+	if r.Spec.ReplicationState != Primary && r.Spec.ReplicationState != Secondary {
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("replicationState"),
+			r.Spec.ReplicationState, []string{string(Primary), string(Secondary)}))
+	}
+
+	if err := validateReplicationSchedule(r.Spec.ReplicationMode, r.Spec.SchedulingInterval); err != nil {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("schedulingInterval"),
+			r.Spec.SchedulingInterval, err.Error()))
+	}
+
+	if r.Spec.ReplicationMode == ReplicationModeSync && r.Spec.Resync != nil {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("resync"),
+			fmt.Sprintf("not supported in %s replication mode", r.Spec.ReplicationMode)))
+	}
+
+	for index, profileName := range r.Spec.S3ProfileList {
+		if err := validateS3Profile(profileName); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("s3ProfileName").Index(index),
+				profileName, err.Error()))
+		}
+	}
+
+	return invalidError("VolumeReplicationGroup", r.Name, allErrs)
+}
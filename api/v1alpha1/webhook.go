@@ -0,0 +1,138 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// S3ProfileValidator returns an error if the named S3 profile is not
+// configured, or is misconfigured, in the Ramen config
+// +kubebuilder:object:generate=false
+type S3ProfileValidator func(profileName string) error
+
+// The namespace and the service account of the Ramen operator, unless set in
+// the environment of its pod
+const (
+	defaultOperatorNamespace      = "ramen-system"
+	defaultOperatorServiceAccount = "ramen-hub-operator"
+)
+
+// Dependencies of the validating webhooks, initialized when any of the
+// webhooks is set up with the manager
+var (
+	webhookReader             client.Reader
+	webhookS3ProfileValidator S3ProfileValidator
+	webhookOperatorUsername   string
+)
+
+func setupWebhookDependencies(mgr ctrl.Manager, s3ProfileValidator S3ProfileValidator) {
+	webhookReader = mgr.GetAPIReader()
+	webhookS3ProfileValidator = s3ProfileValidator
+	webhookOperatorUsername = operatorUsername()
+}
+
+// operatorUsername returns the user name the Ramen operator authenticates as,
+// which is that of the service account of its pod
+func operatorUsername() string {
+	namespace := os.Getenv("POD_NAMESPACE")
+	if namespace == "" {
+		namespace = defaultOperatorNamespace
+	}
+
+	serviceAccount := os.Getenv("POD_SERVICE_ACCOUNT")
+	if serviceAccount == "" {
+		serviceAccount = defaultOperatorServiceAccount
+	}
+
+	return "system:serviceaccount:" + namespace + ":" + serviceAccount
+}
+
+// validateReplicationSchedule returns an error if the scheduling interval is
+// not valid for the replication mode: required in Async mode, and not
+// supported in Sync mode
+func validateReplicationSchedule(mode ReplicationMode, interval string) error {
+	if mode != ReplicationModeSync {
+		return validateSchedulingInterval(interval)
+	}
+
+	if interval != "" {
+		return fmt.Errorf("not supported in %s replication mode", mode)
+	}
+
+	return nil
+}
+
+// validateSchedulingInterval returns an error if the interval is not of the
+// form <num><m,h,d>, with num greater than zero
+func validateSchedulingInterval(interval string) error {
+	const minLength = 2
+
+	if len(interval) < minLength {
+		return fmt.Errorf("expected <num><m,h,d>")
+	}
+
+	switch interval[len(interval)-1] {
+	case 'm', 'h', 'd':
+	default:
+		return fmt.Errorf("expected <num><m,h,d>, unit is not one of m, h or d")
+	}
+
+	num, err := strconv.Atoi(interval[:len(interval)-1])
+	if err != nil {
+		return fmt.Errorf("expected <num><m,h,d>, %w", err)
+	}
+
+	if num <= 0 {
+		return fmt.Errorf("expected <num><m,h,d>, num is not greater than zero")
+	}
+
+	return nil
+}
+
+func validateS3Profile(profileName string) error {
+	if profileName == "" {
+		return fmt.Errorf("s3 profile name is empty")
+	}
+
+	if webhookS3ProfileValidator == nil {
+		return nil
+	}
+
+	if err := webhookS3ProfileValidator(profileName); err != nil {
+		return fmt.Errorf("unknown s3 profile: %w", err)
+	}
+
+	return nil
+}
+
+// invalidError returns an error for the resource if there are any field errors
+func invalidError(kind, name string, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(schema.GroupKind{Group: GroupVersion.Group, Kind: kind}, name, allErrs)
+}
@@ -0,0 +1,431 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func expectInvalid(err error, field string) {
+	Expect(err).To(HaveOccurred())
+	Expect(apierrors.IsInvalid(err)).To(BeTrue(), "unexpected error %v", err)
+	Expect(err.Error()).To(ContainSubstring(field))
+}
+
+var _ = Describe("VolumeReplicationGroup webhook", func() {
+	newVRG := func(name string) *VolumeReplicationGroup {
+		return &VolumeReplicationGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: VolumeReplicationGroupSpec{
+				PVCSelector:        metav1.LabelSelector{MatchLabels: map[string]string{"app": "busybox"}},
+				SchedulingInterval: "1h",
+				ReplicationState:   Primary,
+				S3ProfileList:      []string{knownS3Profiles[0]},
+			},
+		}
+	}
+
+	It("admits a valid VolumeReplicationGroup", func() {
+		Expect(k8sClient.Create(ctx, newVRG("vrg-valid"))).To(Succeed())
+	})
+	It("rejects an unsupported replication state", func() {
+		vrg := newVRG("vrg-state")
+		vrg.Spec.ReplicationState = "tertiary"
+		expectInvalid(k8sClient.Create(ctx, vrg), "spec.replicationState")
+	})
+	It("rejects a scheduling interval of zero", func() {
+		vrg := newVRG("vrg-interval")
+		vrg.Spec.SchedulingInterval = "0m"
+		expectInvalid(k8sClient.Create(ctx, vrg), "spec.schedulingInterval")
+	})
+	It("admits a Sync VolumeReplicationGroup without a scheduling interval", func() {
+		vrg := newVRG("vrg-sync")
+		vrg.Spec.ReplicationMode = ReplicationModeSync
+		vrg.Spec.SchedulingInterval = ""
+		Expect(k8sClient.Create(ctx, vrg)).To(Succeed())
+	})
+	It("rejects a Sync VolumeReplicationGroup with a scheduling interval", func() {
+		vrg := newVRG("vrg-sync-interval")
+		vrg.Spec.ReplicationMode = ReplicationModeSync
+		expectInvalid(k8sClient.Create(ctx, vrg), "spec.schedulingInterval")
+	})
+	It("rejects an Async VolumeReplicationGroup without a scheduling interval", func() {
+		vrg := newVRG("vrg-async-interval")
+		vrg.Spec.SchedulingInterval = ""
+		expectInvalid(k8sClient.Create(ctx, vrg), "spec.schedulingInterval")
+	})
+	It("rejects an unknown s3 profile", func() {
+		vrg := newVRG("vrg-s3profile")
+		vrg.Spec.S3ProfileList = []string{"s3profile-unknown"}
+		expectInvalid(k8sClient.Create(ctx, vrg), "spec.s3ProfileName[0]")
+	})
+	It("rejects an update to an invalid replication state", func() {
+		vrg := &VolumeReplicationGroup{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: "vrg-valid", Namespace: "default"}, vrg)).To(Succeed())
+		vrg.Spec.ReplicationState = "tertiary"
+		expectInvalid(k8sClient.Update(ctx, vrg), "spec.replicationState")
+	})
+})
+
+var _ = Describe("DRPolicy and DRPlacementControl webhooks", func() {
+	newDRPolicy := func(name string, clusters ...string) *DRPolicy {
+		drpolicy := &DRPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       DRPolicySpec{SchedulingInterval: "1h"},
+		}
+
+		for index, cluster := range clusters {
+			drpolicy.Spec.DRClusterSet = append(drpolicy.Spec.DRClusterSet,
+				ManagedCluster{Name: cluster, S3ProfileName: knownS3Profiles[index%len(knownS3Profiles)]})
+		}
+
+		return drpolicy
+	}
+	newDRPC := func(name, drpolicyName string) *DRPlacementControl {
+		return &DRPlacementControl{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: DRPlacementControlSpec{
+				PlacementRef: corev1.ObjectReference{Name: "placement", Kind: "PlacementRule"},
+				DRPolicyRef:  corev1.ObjectReference{Name: drpolicyName},
+				PVCSelector:  metav1.LabelSelector{MatchLabels: map[string]string{"app": "busybox"}},
+			},
+		}
+	}
+
+	It("admits a valid DRPolicy", func() {
+		Expect(k8sClient.Create(ctx, newDRPolicy("drpolicy-valid", "east", "west"))).To(Succeed())
+	})
+	It("rejects a DRPolicy with an unknown s3 profile", func() {
+		drpolicy := newDRPolicy("drpolicy-s3profile", "east", "west")
+		drpolicy.Spec.DRClusterSet[1].S3ProfileName = "s3profile-unknown"
+		expectInvalid(k8sClient.Create(ctx, drpolicy), "spec.drClusterSet[1].s3ProfileName")
+	})
+	It("admits a Sync DRPolicy without a scheduling interval", func() {
+		drpolicy := newDRPolicy("drpolicy-sync", "east", "west")
+		drpolicy.Spec.ReplicationMode = ReplicationModeSync
+		drpolicy.Spec.SchedulingInterval = ""
+		Expect(k8sClient.Create(ctx, drpolicy)).To(Succeed())
+	})
+	It("rejects a Sync DRPolicy with a scheduling interval", func() {
+		drpolicy := newDRPolicy("drpolicy-sync-interval", "east", "west")
+		drpolicy.Spec.ReplicationMode = ReplicationModeSync
+		expectInvalid(k8sClient.Create(ctx, drpolicy), "spec.schedulingInterval")
+	})
+	It("rejects an update to the replication mode of a DRPolicy", func() {
+		drpolicy := &DRPolicy{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: "drpolicy-sync"}, drpolicy)).To(Succeed())
+		drpolicy.Spec.ReplicationMode = ReplicationModeAsync
+		drpolicy.Spec.SchedulingInterval = "1h"
+		expectInvalid(k8sClient.Update(ctx, drpolicy), "spec.replicationMode")
+	})
+	It("rejects a DRPolicy with a duplicate cluster", func() {
+		expectInvalid(k8sClient.Create(ctx, newDRPolicy("drpolicy-duplicate", "east", "east")),
+			"spec.drClusterSet[1].name")
+	})
+	It("rejects a DRPolicy pairing clusters in the same region under a distinct regions rule", func() {
+		drpolicy := newDRPolicy("drpolicy-same-region", "east", "west")
+		drpolicy.Spec.TopologyRule = ClusterTopologyDistinctRegions
+		drpolicy.Spec.DRClusterSet[0].Region = "us"
+		drpolicy.Spec.DRClusterSet[1].Region = "us"
+		expectInvalid(k8sClient.Create(ctx, drpolicy), "spec.drClusterSet[1].region")
+	})
+	It("admits a DRPolicy with clusters in distinct zones of a region under a distinct zones rule", func() {
+		drpolicy := newDRPolicy("drpolicy-distinct-zones", "east", "west")
+		drpolicy.Spec.TopologyRule = ClusterTopologyDistinctZones
+		drpolicy.Spec.DRClusterSet[0].Region = "us"
+		drpolicy.Spec.DRClusterSet[0].Zone = "a"
+		drpolicy.Spec.DRClusterSet[1].Region = "us"
+		drpolicy.Spec.DRClusterSet[1].Zone = "b"
+		Expect(k8sClient.Create(ctx, drpolicy)).To(Succeed())
+	})
+	It("admits a DRPlacementControl with clusters in its DRPolicy", func() {
+		drpc := newDRPC("drpc-valid", "drpolicy-valid")
+		drpc.Spec.PreferredCluster = "east"
+		drpc.Spec.FailoverCluster = "west"
+		Expect(k8sClient.Create(ctx, drpc)).To(Succeed())
+	})
+	It("rejects a DRPlacementControl with an unknown DRPolicy", func() {
+		expectInvalid(k8sClient.Create(ctx, newDRPC("drpc-drpolicy", "drpolicy-unknown")),
+			"spec.drPolicyRef.name")
+	})
+	It("rejects a DRPlacementControl with a preferred cluster outside its DRPolicy", func() {
+		drpc := newDRPC("drpc-preferred", "drpolicy-valid")
+		drpc.Spec.PreferredCluster = "north"
+		expectInvalid(k8sClient.Create(ctx, drpc), "spec.preferredCluster")
+	})
+	It("rejects a DRPlacementControl with a failover cluster outside its DRPolicy", func() {
+		drpc := newDRPC("drpc-failover", "drpolicy-valid")
+		drpc.Spec.FailoverCluster = "north"
+		expectInvalid(k8sClient.Create(ctx, drpc), "spec.failoverCluster")
+	})
+	It("rejects a DRPlacementControl cancelling no action", func() {
+		drpc := newDRPC("drpc-cancel", "drpolicy-valid")
+		drpc.Spec.CancelAction = true
+		expectInvalid(k8sClient.Create(ctx, drpc), "spec.cancelAction")
+	})
+	It("records the requester of the action of a DRPlacementControl, and the approver of its approval", func() {
+		drpc := newDRPC("drpc-approval", "drpolicy-valid")
+		drpc.Spec.Action = ActionFailover
+		drpc.Spec.FailoverCluster = "west"
+		drpc.SetAnnotations(map[string]string{DRPCActionRequesterAnnotation: "someone-else"})
+		Expect(k8sClient.Create(ctx, drpc)).To(Succeed())
+		requester := drpc.GetAnnotations()[DRPCActionRequesterAnnotation]
+		Expect(requester).NotTo(BeEmpty())
+		Expect(requester).NotTo(Equal("someone-else"))
+		drpc.SetAnnotations(map[string]string{
+			DRPCActionRequesterAnnotation: requester,
+			DRPCActionApprovalAnnotation:  string(ActionApprovalApproved),
+			DRPCActionApproverAnnotation:  "someone-else",
+		})
+		Expect(k8sClient.Update(ctx, drpc)).To(Succeed())
+		Expect(drpc.GetAnnotations()[DRPCActionApproverAnnotation]).To(Equal(requester))
+		drpc.Spec.FailoverCluster = "east"
+		Expect(k8sClient.Update(ctx, drpc)).To(Succeed())
+		Expect(drpc.GetAnnotations()).NotTo(HaveKey(DRPCActionApprovalAnnotation))
+		Expect(drpc.GetAnnotations()).NotTo(HaveKey(DRPCActionApproverAnnotation))
+	})
+	It("rejects an unknown approval decision on a DRPlacementControl", func() {
+		drpc := &DRPlacementControl{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: "drpc-approval", Namespace: "default"}, drpc)).To(Succeed())
+		drpc.SetAnnotations(map[string]string{DRPCActionApprovalAnnotation: "Maybe"})
+		Expect(k8sClient.Update(ctx, drpc)).NotTo(Succeed())
+	})
+	It("rejects the removal of a cluster hosting the primary of a DRPlacementControl from its DRPolicy", func() {
+		drpc := &DRPlacementControl{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: "drpc-valid", Namespace: "default"}, drpc)).To(Succeed())
+		drpc.Status.PreferredDecision.ClusterName = "east"
+		drpc.Status.LastUpdateTime = metav1.Now()
+		Expect(k8sClient.Status().Update(ctx, drpc)).To(Succeed())
+		drpolicy := &DRPolicy{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: "drpolicy-valid"}, drpolicy)).To(Succeed())
+		drpolicy.Spec.DRClusterSet = drpolicy.Spec.DRClusterSet[1:]
+		expectInvalid(k8sClient.Update(ctx, drpolicy), "spec.drClusterSet")
+	})
+	It("rejects a DRPlacementControl with a DRPolicy being deleted", func() {
+		drpolicy := newDRPolicy("drpolicy-deleting", "east", "west")
+		drpolicy.Finalizers = []string{"drpolicies.ramendr.openshift.io/test"}
+		Expect(k8sClient.Create(ctx, drpolicy)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, drpolicy)).To(Succeed())
+		expectInvalid(k8sClient.Create(ctx, newDRPC("drpc-deleting", "drpolicy-deleting")),
+			"spec.drPolicyRef.name")
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: drpolicy.Name}, drpolicy)).To(Succeed())
+		drpolicy.Finalizers = nil
+		Expect(k8sClient.Update(ctx, drpolicy)).To(Succeed())
+	})
+})
+
+var _ = Describe("DRCluster webhook", func() {
+	newDRCluster := func(name, s3ProfileName string) *DRCluster {
+		return &DRCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       DRClusterSpec{S3ProfileName: s3ProfileName, Region: "east"},
+		}
+	}
+
+	It("admits a valid DRCluster", func() {
+		Expect(k8sClient.Create(ctx, newDRCluster("drcluster-valid", knownS3Profiles[0]))).To(Succeed())
+	})
+	It("rejects a DRCluster with an unknown s3 profile", func() {
+		expectInvalid(k8sClient.Create(ctx, newDRCluster("drcluster-s3profile", "s3profile-unknown")),
+			"spec.s3ProfileName")
+	})
+	It("rejects an update to an unknown s3 profile", func() {
+		drcluster := &DRCluster{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: "drcluster-valid"}, drcluster)).To(Succeed())
+		drcluster.Spec.S3ProfileName = "s3profile-unknown"
+		expectInvalid(k8sClient.Update(ctx, drcluster), "spec.s3ProfileName")
+	})
+})
+
+var _ = Describe("DRActionRequest webhook", func() {
+	newDRActionRequest := func(name, drpcName string, action DRAction, targetCluster string) *DRActionRequest {
+		return &DRActionRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: DRActionRequestSpec{
+				DRPCName:      drpcName,
+				Action:        action,
+				TargetCluster: targetCluster,
+			},
+		}
+	}
+
+	It("admits a valid DRActionRequest, recording its requester", func() {
+		Expect(k8sClient.Create(ctx, &DRPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "drpolicy-dractionrequest"},
+			Spec: DRPolicySpec{SchedulingInterval: "1h", DRClusterSet: []ManagedCluster{
+				{Name: "east", S3ProfileName: knownS3Profiles[0]},
+				{Name: "west", S3ProfileName: knownS3Profiles[len(knownS3Profiles)-1]},
+			}},
+		})).To(Succeed())
+		Expect(k8sClient.Create(ctx, &DRPlacementControl{
+			ObjectMeta: metav1.ObjectMeta{Name: "drpc-dractionrequest", Namespace: "default"},
+			Spec: DRPlacementControlSpec{
+				PlacementRef: corev1.ObjectReference{Name: "placement", Kind: "PlacementRule"},
+				DRPolicyRef:  corev1.ObjectReference{Name: "drpolicy-dractionrequest"},
+				PVCSelector:  metav1.LabelSelector{MatchLabels: map[string]string{"app": "busybox"}},
+			},
+		})).To(Succeed())
+		request := newDRActionRequest("dractionrequest-valid", "drpc-dractionrequest", ActionFailover, "west")
+		request.Spec.Requester = "someone-else"
+		Expect(k8sClient.Create(ctx, request)).To(Succeed())
+		Expect(request.Spec.Requester).NotTo(Equal("someone-else"))
+	})
+	It("records the requester of a DRActionRequest as the requester of the action only for the operator", func() {
+		request := &DRActionRequest{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: "dractionrequest-valid", Namespace: "default"},
+			request)).To(Succeed())
+		old := &DRPlacementControl{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: "drpc-dractionrequest", Namespace: "default"},
+			old)).To(Succeed())
+		drpc := old.DeepCopy()
+		drpc.Spec.Action = ActionFailover
+		drpc.Spec.FailoverCluster = "west"
+		drpc.SetAnnotations(map[string]string{DRPCActionRequestAnnotation: request.Name})
+		userDRPC := drpc.DeepCopy()
+		Expect(userDRPC.actionUsersSet(ctx, old, "someone-else")).To(BeTrue())
+		Expect(userDRPC.GetAnnotations()[DRPCActionRequesterAnnotation]).To(Equal("someone-else"))
+		Expect(drpc.actionUsersSet(ctx, old, webhookOperatorUsername)).To(BeTrue())
+		Expect(drpc.GetAnnotations()[DRPCActionRequesterAnnotation]).To(Equal(request.Spec.Requester))
+	})
+	It("rejects a DRActionRequest for an unknown DRPlacementControl", func() {
+		expectInvalid(k8sClient.Create(ctx,
+			newDRActionRequest("dractionrequest-drpc", "drpc-unknown", ActionFailover, "west")),
+			"spec.drpcName")
+	})
+	It("rejects a DRActionRequest with a target cluster outside the DRPolicy", func() {
+		expectInvalid(k8sClient.Create(ctx,
+			newDRActionRequest("dractionrequest-target", "drpc-dractionrequest", ActionRelocate, "north")),
+			"spec.targetCluster")
+	})
+	It("rejects an update to the spec of a DRActionRequest", func() {
+		request := &DRActionRequest{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: "dractionrequest-valid", Namespace: "default"},
+			request)).To(Succeed())
+		request.Spec.TargetCluster = "east"
+		expectInvalid(k8sClient.Update(ctx, request), "spec")
+	})
+})
+
+var _ = Describe("DRClusterAction webhook", func() {
+	newDRClusterAction := func(name, clusterName string, action DRAction, targetCluster string) *DRClusterAction {
+		return &DRClusterAction{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: DRClusterActionSpec{
+				ClusterName:   clusterName,
+				Action:        action,
+				TargetCluster: targetCluster,
+			},
+		}
+	}
+
+	It("admits a valid DRClusterAction", func() {
+		Expect(k8sClient.Create(ctx, &DRPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "drpolicy-drclusteraction"},
+			Spec: DRPolicySpec{SchedulingInterval: "1h", DRClusterSet: []ManagedCluster{
+				{Name: "east", S3ProfileName: knownS3Profiles[0]},
+				{Name: "west", S3ProfileName: knownS3Profiles[len(knownS3Profiles)-1]},
+			}},
+		})).To(Succeed())
+		clusterAction := newDRClusterAction("drclusteraction-valid", "east", ActionFailover, "west")
+		clusterAction.Spec.DRPolicyName = "drpolicy-drclusteraction"
+		Expect(k8sClient.Create(ctx, clusterAction)).To(Succeed())
+	})
+	It("admits a DRClusterAction that relocates back to a recovered cluster", func() {
+		Expect(k8sClient.Create(ctx,
+			newDRClusterAction("drclusteraction-relocate", "east", ActionRelocate, ""))).To(Succeed())
+	})
+	It("rejects a relocation with a target cluster", func() {
+		expectInvalid(k8sClient.Create(ctx,
+			newDRClusterAction("drclusteraction-relocate-target", "east", ActionRelocate, "west")),
+			"spec.targetCluster")
+	})
+	It("rejects a DRClusterAction that fails over to the failed cluster", func() {
+		expectInvalid(k8sClient.Create(ctx,
+			newDRClusterAction("drclusteraction-target", "east", ActionFailover, "east")),
+			"spec.targetCluster")
+	})
+	It("rejects a DRClusterAction of an unknown DRPolicy", func() {
+		clusterAction := newDRClusterAction("drclusteraction-drpolicy", "east", ActionFailover, "")
+		clusterAction.Spec.DRPolicyName = "drpolicy-unknown"
+		expectInvalid(k8sClient.Create(ctx, clusterAction), "spec.drPolicyName")
+	})
+	It("rejects an update to the spec of a DRClusterAction", func() {
+		clusterAction := &DRClusterAction{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: "drclusteraction-valid"}, clusterAction)).To(Succeed())
+		clusterAction.Spec.Concurrency = 1
+		expectInvalid(k8sClient.Update(ctx, clusterAction), "spec")
+	})
+})
+
+var _ = Describe("DRPlacementControlGroup webhook", func() {
+	newDRPlacementControlGroup := func(name string, action DRAction, targetCluster string,
+		members ...DRPlacementControlGroupMember,
+	) *DRPlacementControlGroup {
+		return &DRPlacementControlGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: DRPlacementControlGroupSpec{
+				Members:       members,
+				Action:        action,
+				TargetCluster: targetCluster,
+			},
+		}
+	}
+
+	It("admits a valid DRPlacementControlGroup", func() {
+		Expect(k8sClient.Create(ctx, &DRPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "drpolicy-drpcgroup"},
+			Spec: DRPolicySpec{SchedulingInterval: "1h", DRClusterSet: []ManagedCluster{
+				{Name: "east", S3ProfileName: knownS3Profiles[0]},
+				{Name: "west", S3ProfileName: knownS3Profiles[len(knownS3Profiles)-1]},
+			}},
+		})).To(Succeed())
+		Expect(k8sClient.Create(ctx, &DRPlacementControl{
+			ObjectMeta: metav1.ObjectMeta{Name: "drpc-drpcgroup", Namespace: "default"},
+			Spec: DRPlacementControlSpec{
+				PlacementRef: corev1.ObjectReference{Name: "placement", Kind: "PlacementRule"},
+				DRPolicyRef:  corev1.ObjectReference{Name: "drpolicy-drpcgroup"},
+				PVCSelector:  metav1.LabelSelector{MatchLabels: map[string]string{"app": "busybox"}},
+			},
+		})).To(Succeed())
+		Expect(k8sClient.Create(ctx, newDRPlacementControlGroup("drpcgroup-valid", ActionFailover, "west",
+			DRPlacementControlGroupMember{Name: "drpc-drpcgroup", Namespace: "default"}))).To(Succeed())
+	})
+	It("rejects a DRPlacementControlGroup with a duplicate member", func() {
+		member := DRPlacementControlGroupMember{Name: "drpc-drpcgroup", Namespace: "default"}
+		expectInvalid(k8sClient.Create(ctx, newDRPlacementControlGroup("drpcgroup-duplicate", "", "",
+			member, member)), "spec.members[1]")
+	})
+	It("rejects a DRPlacementControlGroup action without a target cluster", func() {
+		expectInvalid(k8sClient.Create(ctx, newDRPlacementControlGroup("drpcgroup-notarget", ActionRelocate, "",
+			DRPlacementControlGroupMember{Name: "drpc-drpcgroup", Namespace: "default"})), "spec.targetCluster")
+	})
+	It("rejects a DRPlacementControlGroup action on an unknown member", func() {
+		expectInvalid(k8sClient.Create(ctx, newDRPlacementControlGroup("drpcgroup-unknown", ActionFailover, "west",
+			DRPlacementControlGroupMember{Name: "drpc-unknown", Namespace: "default"})), "spec.members[0]")
+	})
+	It("rejects a DRPlacementControlGroup action to a cluster outside the DRPolicy of a member", func() {
+		group := &DRPlacementControlGroup{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: "drpcgroup-valid"}, group)).To(Succeed())
+		group.Spec.TargetCluster = "north"
+		expectInvalid(k8sClient.Update(ctx, group), "spec.members[0]")
+	})
+})
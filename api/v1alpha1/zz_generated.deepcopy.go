@@ -25,6 +25,562 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionApproval) DeepCopyInto(out *ActionApproval) {
+	*out = *in
+	in.RequestTime.DeepCopyInto(&out.RequestTime)
+	if in.DecisionTime != nil {
+		in, out := &in.DecisionTime, &out.DecisionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionApproval.
+func (in *ActionApproval) DeepCopy() *ActionApproval {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionApproval)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionApprovalPolicy) DeepCopyInto(out *ActionApprovalPolicy) {
+	*out = *in
+	if in.Approvers != nil {
+		in, out := &in.Approvers, &out.Approvers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Expiry = in.Expiry
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionApprovalPolicy.
+func (in *ActionApprovalPolicy) DeepCopy() *ActionApprovalPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionApprovalPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionCancellation) DeepCopyInto(out *ActionCancellation) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionCancellation.
+func (in *ActionCancellation) DeepCopy() *ActionCancellation {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionCancellation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionOrigin) DeepCopyInto(out *ActionOrigin) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionOrigin.
+func (in *ActionOrigin) DeepCopy() *ActionOrigin {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionOrigin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionRecord) DeepCopyInto(out *ActionRecord) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.DataRestoredTime != nil {
+		in, out := &in.DataRestoredTime, &out.DataRestoredTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PlacementUpdatedTime != nil {
+		in, out := &in.PlacementUpdatedTime, &out.PlacementUpdatedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CleanupCompleteTime != nil {
+		in, out := &in.CleanupCompleteTime, &out.CleanupCompleteTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionRecord.
+func (in *ActionRecord) DeepCopy() *ActionRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoFailover) DeepCopyInto(out *AutoFailover) {
+	*out = *in
+	out.UnavailableGracePeriod = in.UnavailableGracePeriod
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoFailover.
+func (in *AutoFailover) DeepCopy() *AutoFailover {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoFailover)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsistencyGroup) DeepCopyInto(out *ConsistencyGroup) {
+	*out = *in
+	in.PVCSelector.DeepCopyInto(&out.PVCSelector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsistencyGroup.
+func (in *ConsistencyGroup) DeepCopy() *ConsistencyGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsistencyGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRActionRequest) DeepCopyInto(out *DRActionRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRActionRequest.
+func (in *DRActionRequest) DeepCopy() *DRActionRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(DRActionRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DRActionRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRActionRequestList) DeepCopyInto(out *DRActionRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DRActionRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRActionRequestList.
+func (in *DRActionRequestList) DeepCopy() *DRActionRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(DRActionRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DRActionRequestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRActionRequestSpec) DeepCopyInto(out *DRActionRequestSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRActionRequestSpec.
+func (in *DRActionRequestSpec) DeepCopy() *DRActionRequestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DRActionRequestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRActionRequestStatus) DeepCopyInto(out *DRActionRequestStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRActionRequestStatus.
+func (in *DRActionRequestStatus) DeepCopy() *DRActionRequestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DRActionRequestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRCluster) DeepCopyInto(out *DRCluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRCluster.
+func (in *DRCluster) DeepCopy() *DRCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(DRCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DRCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRClusterAction) DeepCopyInto(out *DRClusterAction) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRClusterAction.
+func (in *DRClusterAction) DeepCopy() *DRClusterAction {
+	if in == nil {
+		return nil
+	}
+	out := new(DRClusterAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DRClusterAction) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRClusterActionDRPC) DeepCopyInto(out *DRClusterActionDRPC) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRClusterActionDRPC.
+func (in *DRClusterActionDRPC) DeepCopy() *DRClusterActionDRPC {
+	if in == nil {
+		return nil
+	}
+	out := new(DRClusterActionDRPC)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRClusterActionList) DeepCopyInto(out *DRClusterActionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DRClusterAction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRClusterActionList.
+func (in *DRClusterActionList) DeepCopy() *DRClusterActionList {
+	if in == nil {
+		return nil
+	}
+	out := new(DRClusterActionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DRClusterActionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRClusterActionProgress) DeepCopyInto(out *DRClusterActionProgress) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRClusterActionProgress.
+func (in *DRClusterActionProgress) DeepCopy() *DRClusterActionProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(DRClusterActionProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRClusterActionSpec) DeepCopyInto(out *DRClusterActionSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRClusterActionSpec.
+func (in *DRClusterActionSpec) DeepCopy() *DRClusterActionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DRClusterActionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRClusterActionStatus) DeepCopyInto(out *DRClusterActionStatus) {
+	*out = *in
+	out.Progress = in.Progress
+	if in.DRPCs != nil {
+		in, out := &in.DRPCs, &out.DRPCs
+		*out = make([]DRClusterActionDRPC, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRClusterActionStatus.
+func (in *DRClusterActionStatus) DeepCopy() *DRClusterActionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DRClusterActionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRClusterList) DeepCopyInto(out *DRClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DRCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRClusterList.
+func (in *DRClusterList) DeepCopy() *DRClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(DRClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DRClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRClusterReplicationClass) DeepCopyInto(out *DRClusterReplicationClass) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRClusterReplicationClass.
+func (in *DRClusterReplicationClass) DeepCopy() *DRClusterReplicationClass {
+	if in == nil {
+		return nil
+	}
+	out := new(DRClusterReplicationClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRClusterSpec) DeepCopyInto(out *DRClusterSpec) {
+	*out = *in
+	if in.CIDRs != nil {
+		in, out := &in.CIDRs, &out.CIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRClusterSpec.
+func (in *DRClusterSpec) DeepCopy() *DRClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DRClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRClusterStatus) DeepCopyInto(out *DRClusterStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ReplicationClasses != nil {
+		in, out := &in.ReplicationClasses, &out.ReplicationClasses
+		*out = make([]DRClusterReplicationClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StorageClasses != nil {
+		in, out := &in.StorageClasses, &out.StorageClasses
+		*out = make([]DRClusterStorageClass, len(*in))
+		copy(*out, *in)
+	}
+	if in.CSIDrivers != nil {
+		in, out := &in.CSIDrivers, &out.CSIDrivers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VolumeSnapshotClasses != nil {
+		in, out := &in.VolumeSnapshotClasses, &out.VolumeSnapshotClasses
+		*out = make([]DRClusterVolumeSnapshotClass, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastHealthCheckTime != nil {
+		in, out := &in.LastHealthCheckTime, &out.LastHealthCheckTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRClusterStatus.
+func (in *DRClusterStatus) DeepCopy() *DRClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DRClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRClusterStorageClass) DeepCopyInto(out *DRClusterStorageClass) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRClusterStorageClass.
+func (in *DRClusterStorageClass) DeepCopy() *DRClusterStorageClass {
+	if in == nil {
+		return nil
+	}
+	out := new(DRClusterStorageClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRClusterVolumeSnapshotClass) DeepCopyInto(out *DRClusterVolumeSnapshotClass) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRClusterVolumeSnapshotClass.
+func (in *DRClusterVolumeSnapshotClass) DeepCopy() *DRClusterVolumeSnapshotClass {
+	if in == nil {
+		return nil
+	}
+	out := new(DRClusterVolumeSnapshotClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DRPlacementControl) DeepCopyInto(out *DRPlacementControl) {
 	*out = *in
@@ -52,6 +608,153 @@ func (in *DRPlacementControl) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRPlacementControlGroup) DeepCopyInto(out *DRPlacementControlGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPlacementControlGroup.
+func (in *DRPlacementControlGroup) DeepCopy() *DRPlacementControlGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(DRPlacementControlGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DRPlacementControlGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRPlacementControlGroupList) DeepCopyInto(out *DRPlacementControlGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DRPlacementControlGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPlacementControlGroupList.
+func (in *DRPlacementControlGroupList) DeepCopy() *DRPlacementControlGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(DRPlacementControlGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DRPlacementControlGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRPlacementControlGroupMember) DeepCopyInto(out *DRPlacementControlGroupMember) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPlacementControlGroupMember.
+func (in *DRPlacementControlGroupMember) DeepCopy() *DRPlacementControlGroupMember {
+	if in == nil {
+		return nil
+	}
+	out := new(DRPlacementControlGroupMember)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRPlacementControlGroupMemberStatus) DeepCopyInto(out *DRPlacementControlGroupMemberStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPlacementControlGroupMemberStatus.
+func (in *DRPlacementControlGroupMemberStatus) DeepCopy() *DRPlacementControlGroupMemberStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DRPlacementControlGroupMemberStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRPlacementControlGroupSpec) DeepCopyInto(out *DRPlacementControlGroupSpec) {
+	*out = *in
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]DRPlacementControlGroupMember, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPlacementControlGroupSpec.
+func (in *DRPlacementControlGroupSpec) DeepCopy() *DRPlacementControlGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DRPlacementControlGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRPlacementControlGroupStatus) DeepCopyInto(out *DRPlacementControlGroupStatus) {
+	*out = *in
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]DRPlacementControlGroupMemberStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPlacementControlGroupStatus.
+func (in *DRPlacementControlGroupStatus) DeepCopy() *DRPlacementControlGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DRPlacementControlGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DRPlacementControlList) DeepCopyInto(out *DRPlacementControlList) {
 	*out = *in
@@ -115,6 +818,43 @@ func (in *DRPlacementControlStatus) DeepCopyInto(out *DRPlacementControlStatus)
 	}
 	in.ResourceConditions.DeepCopyInto(&out.ResourceConditions)
 	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	if in.SplitBrainPrimaries != nil {
+		in, out := &in.SplitBrainPrimaries, &out.SplitBrainPrimaries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SplitBrainPVCs != nil {
+		in, out := &in.SplitBrainPVCs, &out.SplitBrainPVCs
+		*out = make([]SplitBrainPVC, len(*in))
+		copy(*out, *in)
+	}
+	if in.SplitBrainResolution != nil {
+		in, out := &in.SplitBrainResolution, &out.SplitBrainResolution
+		*out = new(SplitBrainResolution)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ActionOrigin != nil {
+		in, out := &in.ActionOrigin, &out.ActionOrigin
+		*out = new(ActionOrigin)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ActionCancellation != nil {
+		in, out := &in.ActionCancellation, &out.ActionCancellation
+		*out = new(ActionCancellation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ActionHistory != nil {
+		in, out := &in.ActionHistory, &out.ActionHistory
+		*out = make([]ActionRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ActionApproval != nil {
+		in, out := &in.ActionApproval, &out.ActionApproval
+		*out = new(ActionApproval)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPlacementControlStatus.
@@ -195,6 +935,16 @@ func (in *DRPolicySpec) DeepCopyInto(out *DRPolicySpec) {
 		*out = make([]ManagedCluster, len(*in))
 		copy(*out, *in)
 	}
+	if in.AutoFailover != nil {
+		in, out := &in.AutoFailover, &out.AutoFailover
+		*out = new(AutoFailover)
+		**out = **in
+	}
+	if in.ActionApproval != nil {
+		in, out := &in.ActionApproval, &out.ActionApproval
+		*out = new(ActionApprovalPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPolicySpec.
@@ -217,6 +967,22 @@ func (in *DRPolicyStatus) DeepCopyInto(out *DRPolicyStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ClusterSet != nil {
+		in, out := &in.ClusterSet, &out.ClusterSet
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AutoFailoverTimes != nil {
+		in, out := &in.AutoFailoverTimes, &out.AutoFailoverTimes
+		*out = make([]v1.Time, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastHealthCheckTime != nil {
+		in, out := &in.LastHealthCheckTime, &out.LastHealthCheckTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPolicyStatus.
@@ -244,6 +1010,21 @@ func (in *ManagedCluster) DeepCopy() *ManagedCluster {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PVCPreview) DeepCopyInto(out *PVCPreview) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PVCPreview.
+func (in *PVCPreview) DeepCopy() *PVCPreview {
+	if in == nil {
+		return nil
+	}
+	out := new(PVCPreview)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProtectedPVC) DeepCopyInto(out *ProtectedPVC) {
 	*out = *in
@@ -266,6 +1047,26 @@ func (in *ProtectedPVC) DeepCopy() *ProtectedPVC {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProtectionPreview) DeepCopyInto(out *ProtectionPreview) {
+	*out = *in
+	if in.PVCs != nil {
+		in, out := &in.PVCs, &out.PVCs
+		*out = make([]PVCPreview, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProtectionPreview.
+func (in *ProtectionPreview) DeepCopy() *ProtectionPreview {
+	if in == nil {
+		return nil
+	}
+	out := new(ProtectionPreview)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RamenConfig) DeepCopyInto(out *RamenConfig) {
 	*out = *in
@@ -296,6 +1097,26 @@ func (in *RamenConfig) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResyncRequest) DeepCopyInto(out *ResyncRequest) {
+	*out = *in
+	if in.PVCNames != nil {
+		in, out := &in.PVCNames, &out.PVCNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResyncRequest.
+func (in *ResyncRequest) DeepCopy() *ResyncRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(ResyncRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *S3StoreProfile) DeepCopyInto(out *S3StoreProfile) {
 	*out = *in
@@ -312,6 +1133,42 @@ func (in *S3StoreProfile) DeepCopy() *S3StoreProfile {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SplitBrainPVC) DeepCopyInto(out *SplitBrainPVC) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SplitBrainPVC.
+func (in *SplitBrainPVC) DeepCopy() *SplitBrainPVC {
+	if in == nil {
+		return nil
+	}
+	out := new(SplitBrainPVC)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SplitBrainResolution) DeepCopyInto(out *SplitBrainResolution) {
+	*out = *in
+	if in.Demoted != nil {
+		in, out := &in.Demoted, &out.Demoted
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SplitBrainResolution.
+func (in *SplitBrainResolution) DeepCopy() *SplitBrainResolution {
+	if in == nil {
+		return nil
+	}
+	out := new(SplitBrainResolution)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VRGConditions) DeepCopyInto(out *VRGConditions) {
 	*out = *in
@@ -419,6 +1276,18 @@ func (in *VolumeReplicationGroupSpec) DeepCopyInto(out *VolumeReplicationGroupSp
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ConsistencyGroups != nil {
+		in, out := &in.ConsistencyGroups, &out.ConsistencyGroups
+		*out = make([]ConsistencyGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resync != nil {
+		in, out := &in.Resync, &out.Resync
+		*out = new(ResyncRequest)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeReplicationGroupSpec.
@@ -448,6 +1317,11 @@ func (in *VolumeReplicationGroupStatus) DeepCopyInto(out *VolumeReplicationGroup
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ProtectionPreview != nil {
+		in, out := &in.ProtectionPreview, &out.ProtectionPreview
+		*out = new(ProtectionPreview)
+		(*in).DeepCopyInto(*out)
+	}
 	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
 }
 
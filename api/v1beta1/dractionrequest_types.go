@@ -0,0 +1,119 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DRActionRequestSpec defines the desired state of DRActionRequest
+type DRActionRequestSpec struct {
+	// DRPCName is the name of the DRPlacementControl, in the namespace of the
+	// request, to perform the action on
+	// +kubebuilder:validation:MinLength=1
+	DRPCName string `json:"drpcName"`
+
+	// Action to perform
+	// +kubebuilder:validation:Required
+	Action DRAction `json:"action"`
+
+	// TargetCluster is the cluster to fail over or relocate the application to
+	// +kubebuilder:validation:MinLength=1
+	TargetCluster string `json:"targetCluster"`
+
+	// Reason for the action
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Requester is the user that created the request.  It is set on creation,
+	// overriding any value given.
+	// +optional
+	Requester string `json:"requester,omitempty"`
+}
+
+// DRActionRequestPhase is the phase of a DRActionRequest
+type DRActionRequestPhase string
+
+const (
+	// DRActionRequestPending is the phase of a request waiting to be started
+	DRActionRequestPending = DRActionRequestPhase("Pending")
+
+	// DRActionRequestRunning is the phase of a request whose action is
+	// requested from its DRPlacementControl, until the action completes
+	DRActionRequestRunning = DRActionRequestPhase("Running")
+
+	// DRActionRequestSucceeded is the phase of a request whose action completed
+	DRActionRequestSucceeded = DRActionRequestPhase("Succeeded")
+
+	// DRActionRequestFailed is the phase of a request whose action could not
+	// be started, or did not complete
+	DRActionRequestFailed = DRActionRequestPhase("Failed")
+)
+
+// DRActionRequestStatus defines the observed state of DRActionRequest
+type DRActionRequestStatus struct {
+	// Phase of the request
+	// +optional
+	Phase DRActionRequestPhase `json:"phase,omitempty"`
+
+	// Message describing the phase of the request
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// StartTime is the time the action was requested from the DRPlacementControl
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time the request succeeded or failed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=drar
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:JSONPath=".spec.drpcName",name=drpc,type=string
+// +kubebuilder:printcolumn:JSONPath=".spec.action",name=action,type=string
+// +kubebuilder:printcolumn:JSONPath=".spec.targetCluster",name=target,type=string
+// +kubebuilder:printcolumn:JSONPath=".spec.requester",name=requester,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.phase",name=phase,type=string
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",name=Age,type=date
+
+// DRActionRequest is the Schema for the dractionrequests API.  It requests a
+// one-shot failover or relocation of a DRPlacementControl, and records who
+// requested it, why, and its outcome.  Its spec is immutable.
+type DRActionRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DRActionRequestSpec   `json:"spec,omitempty"`
+	Status DRActionRequestStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DRActionRequestList contains a list of DRActionRequest
+type DRActionRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DRActionRequest `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DRActionRequest{}, &DRActionRequestList{})
+}
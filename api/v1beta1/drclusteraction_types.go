@@ -0,0 +1,218 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DRPCPriorityAnnotation is the priority of a DRPC in the actions of a
+// DRClusterAction, an integer, higher first.  DRPCs without it have priority 0.
+const DRPCPriorityAnnotation = "drplacementcontrols.ramendr.openshift.io/priority"
+
+// DRClusterActionSpec defines the desired state of DRClusterAction
+type DRClusterActionSpec struct {
+	// ClusterName is the failed cluster whose DRPlacementControls are failed
+	// over, or the recovered cluster to which the DRPlacementControls failed
+	// over from it are relocated back
+	// +kubebuilder:validation:MinLength=1
+	ClusterName string `json:"clusterName"`
+
+	// Action to perform on the DRPlacementControls of the cluster
+	// +kubebuilder:validation:Required
+	Action DRAction `json:"action"`
+
+	// DRPolicyName, if set, limits the action to the DRPlacementControls of
+	// the DRPolicy
+	// +optional
+	DRPolicyName string `json:"drPolicyName,omitempty"`
+
+	// TargetCluster, if set, is the cluster to fail over to.  Otherwise, each
+	// DRPlacementControl fails over to the most preferred available peer
+	// cluster of its DRPolicy.  It is not set for a relocation.
+	// +optional
+	TargetCluster string `json:"targetCluster,omitempty"`
+
+	// Concurrency is the maximum number of DRPlacementControls whose action is
+	// in progress at once
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=10
+	// +optional
+	Concurrency int `json:"concurrency,omitempty"`
+
+	// DryRun, if true, only reports the DRPlacementControls the action would
+	// be performed on, and their target clusters
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// DRClusterActionPhase is the phase of a DRClusterAction
+type DRClusterActionPhase string
+
+const (
+	// DRClusterActionRunning is the phase of an action in progress
+	DRClusterActionRunning = DRClusterActionPhase("Running")
+
+	// DRClusterActionSucceeded is the phase of an action completed for all its
+	// DRPlacementControls, or of a completed dry run
+	DRClusterActionSucceeded = DRClusterActionPhase("Succeeded")
+
+	// DRClusterActionFailed is the phase of an action completed that failed for
+	// any of its DRPlacementControls
+	DRClusterActionFailed = DRClusterActionPhase("Failed")
+)
+
+// DRClusterActionDRPCState is the state of the action on a DRPlacementControl
+type DRClusterActionDRPCState string
+
+const (
+	// DRClusterActionDRPCPlanned is the state of a DRPlacementControl in a dry
+	// run
+	DRClusterActionDRPCPlanned = DRClusterActionDRPCState("Planned")
+
+	// DRClusterActionDRPCPending is the state of a DRPlacementControl whose
+	// action is yet to start
+	DRClusterActionDRPCPending = DRClusterActionDRPCState("Pending")
+
+	// DRClusterActionDRPCResyncing is the state of a DRPlacementControl to be
+	// relocated, while the data of its application resyncs to the recovered
+	// cluster
+	DRClusterActionDRPCResyncing = DRClusterActionDRPCState("Resyncing")
+
+	// DRClusterActionDRPCInProgress is the state of a DRPlacementControl whose
+	// action is requested, until it completes
+	DRClusterActionDRPCInProgress = DRClusterActionDRPCState("InProgress")
+
+	// DRClusterActionDRPCSucceeded is the state of a DRPlacementControl whose
+	// action completed
+	DRClusterActionDRPCSucceeded = DRClusterActionDRPCState("Succeeded")
+
+	// DRClusterActionDRPCFailed is the state of a DRPlacementControl whose
+	// action could not be started, or did not complete
+	DRClusterActionDRPCFailed = DRClusterActionDRPCState("Failed")
+)
+
+// DRClusterActionDRPC is the outcome of the action on a DRPlacementControl
+type DRClusterActionDRPC struct {
+	// Name of the DRPlacementControl
+	Name string `json:"name"`
+
+	// Namespace of the DRPlacementControl
+	Namespace string `json:"namespace"`
+
+	// Priority of the DRPlacementControl
+	// +optional
+	Priority int `json:"priority,omitempty"`
+
+	// TargetCluster of the action on the DRPlacementControl
+	// +optional
+	TargetCluster string `json:"targetCluster,omitempty"`
+
+	// State of the action on the DRPlacementControl
+	State DRClusterActionDRPCState `json:"state"`
+
+	// Message describing the state of the action on the DRPlacementControl
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// StartTime is the time the action was requested from the DRPlacementControl
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time the action on the DRPlacementControl succeeded
+	// or failed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// DRClusterActionProgress counts the DRPlacementControls of a DRClusterAction
+// by the state of their action
+type DRClusterActionProgress struct {
+	Total      int `json:"total"`
+	Pending    int `json:"pending"`
+	Resyncing  int `json:"resyncing"`
+	InProgress int `json:"inProgress"`
+	Succeeded  int `json:"succeeded"`
+	Failed     int `json:"failed"`
+}
+
+// DRClusterActionStatus defines the observed state of DRClusterAction
+type DRClusterActionStatus struct {
+	// Phase of the action
+	// +optional
+	Phase DRClusterActionPhase `json:"phase,omitempty"`
+
+	// Message describing the phase of the action
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Progress of the action
+	// +optional
+	Progress DRClusterActionProgress `json:"progress,omitempty"`
+
+	// DRPCs are the DRPlacementControls the action is performed on, in the
+	// order it is performed
+	// +optional
+	DRPCs []DRClusterActionDRPC `json:"drpcs,omitempty"`
+
+	// StartTime is the time the action started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time the action completed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=drca
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:JSONPath=".spec.clusterName",name=cluster,type=string
+// +kubebuilder:printcolumn:JSONPath=".spec.action",name=action,type=string
+// +kubebuilder:printcolumn:JSONPath=".spec.dryRun",name=dryrun,type=boolean
+// +kubebuilder:printcolumn:JSONPath=".status.phase",name=phase,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.progress.total",name=total,type=integer
+// +kubebuilder:printcolumn:JSONPath=".status.progress.succeeded",name=succeeded,type=integer
+// +kubebuilder:printcolumn:JSONPath=".status.progress.failed",name=failed,type=integer
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",name=Age,type=date
+
+// DRClusterAction is the Schema for the drclusteractions API.  It fails over
+// all the DRPlacementControls placed on a failed cluster, or relocates back
+// all the DRPlacementControls failed over from a recovered cluster, in
+// priority order and with bounded concurrency, and records the outcome for
+// each.  Its spec is immutable.
+type DRClusterAction struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DRClusterActionSpec   `json:"spec,omitempty"`
+	Status DRClusterActionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DRClusterActionList contains a list of DRClusterAction
+type DRClusterActionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DRClusterAction `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DRClusterAction{}, &DRClusterActionList{})
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Managed cluster information
+type ManagedCluster struct {
+	// Name of this managed cluster as configured in OCM/ACM
+	Name string `json:"name"`
+
+	// S3 profile name (in Ramen config) to use as a source to restore PV
+	// related cluster state during recovery or relocate actions of applications
+	// to this managed cluster;  hence, this S3 profile should be available to
+	// successfully move the workload to this managed cluster.  For applications
+	// that are active on this managed cluster, their PV related cluster state
+	// is stored to S3 profiles of all other managed clusters in the same
+	// DRPolicy to enable recovery or relocate actions on those managed clusters.
+	S3ProfileName string `json:"s3ProfileName"`
+
+	// Region of this managed cluster, such as a cloud region or a data center,
+	// that is, the failure domain shared by clusters that fail together.  If
+	// empty, the region of the DRCluster of the same name, if any, is used.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Zone of this managed cluster within its region.  If empty, the zone of
+	// the DRCluster of the same name, if any, is used.
+	// +optional
+	Zone string `json:"zone,omitempty"`
+}
+
+// ReplicationMode is the mode of replication of the volumes of the clusters of
+// a DRPolicy
+// +kubebuilder:validation:Enum=Async;Sync
+type ReplicationMode string
+
+const (
+	// ReplicationModeAsync replicates volumes asynchronously, on the schedule
+	// of a scheduling interval
+	ReplicationModeAsync = ReplicationMode("Async")
+
+	// ReplicationModeSync replicates volumes synchronously, through storage
+	// stretched across the clusters
+	ReplicationModeSync = ReplicationMode("Sync")
+)
+
+// ClusterTopologyRule is a placement rule on the failure domains of the
+// clusters of a DRPolicy
+// +kubebuilder:validation:Enum=DistinctRegions;DistinctZones
+type ClusterTopologyRule string
+
+const (
+	// ClusterTopologyDistinctRegions requires each cluster of the policy to be
+	// in a region of its own
+	ClusterTopologyDistinctRegions = ClusterTopologyRule("DistinctRegions")
+
+	// ClusterTopologyDistinctZones requires each cluster of the policy to be in
+	// a zone of its own, possibly within the same region
+	ClusterTopologyDistinctZones = ClusterTopologyRule("DistinctZones")
+)
+
+// AutoFailover configures the automatic failover of the DRPlacementControls of
+// a DRPolicy from a cluster that becomes unavailable
+type AutoFailover struct {
+	// UnavailableGracePeriod is how long a cluster is to be unavailable before
+	// the DRPlacementControls it hosts are failed over
+	// +kubebuilder:default="10m"
+	// +optional
+	UnavailableGracePeriod metav1.Duration `json:"unavailableGracePeriod,omitempty"`
+
+	// MaxFailoversPerHour bounds the number of DRPlacementControls of the
+	// policy that are failed over automatically in any hour
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=10
+	// +optional
+	MaxFailoversPerHour int `json:"maxFailoversPerHour,omitempty"`
+}
+
+// ActionApprovalPolicy requires the failovers and relocations of the
+// DRPlacementControls of a DRPolicy to be approved before they start
+type ActionApprovalPolicy struct {
+	// Approvers, if set, are the only users that may approve or reject an
+	// action.  Otherwise, any user other than the requester may.
+	// +optional
+	Approvers []string `json:"approvers,omitempty"`
+
+	// Expiry is how long an action awaits approval before it expires
+	// +kubebuilder:default="24h"
+	// +optional
+	Expiry metav1.Duration `json:"expiry,omitempty"`
+}
+
+// DRPolicySpec defines the desired state of DRPolicy
+type DRPolicySpec struct {
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// scheduling Interval for replicating Persistent Volume
+	// data to a peer cluster. Interval is typically in the
+	// form <num><m,h,d>. Here <num> is a number, 'm' means
+	// minutes, 'h' means hours and 'd' stands for days.
+	// Required in Async replication mode, and not supported
+	// in Sync replication mode.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^\d+[mhd]$`
+	SchedulingInterval string `json:"schedulingInterval,omitempty"`
+
+	// Mode of replication of volumes between the clusters.  Async, the
+	// default, replicates on the schedule of SchedulingInterval.  Sync relies
+	// on storage stretched across the clusters, with no schedule; failover
+	// requires the failed cluster to be fenced, and volumes are neither
+	// demoted nor resynced.  Immutable.
+	// +optional
+	ReplicationMode ReplicationMode `json:"replicationMode,omitempty"`
+
+	// Label selector to identify all the VolumeReplicationClasses.
+	// This selector is assumed to be the same for all subscriptions that
+	// need DR protection. It will be passed in to the VRG when it is created
+	//+optional
+	ReplicationClassSelector metav1.LabelSelector `json:"replicationClassSelector,omitempty"`
+
+	// The set of managed clusters governed by this policy, which have
+	// replication relationship enabled between them.
+	DRClusterSet []ManagedCluster `json:"drClusterSet"`
+
+	// TopologyRule, if set, requires the clusters of the policy to be in
+	// distinct failure domains, so that peers do not fail together.  Failover
+	// prefers a target cluster in a different failure domain regardless.
+	// +optional
+	TopologyRule ClusterTopologyRule `json:"topologyRule,omitempty"`
+
+	// AutoFailover, if set, opts the DRPlacementControls of the policy in to
+	// automatic failover from a cluster that stays unavailable for a grace
+	// period, once the cluster is fenced
+	// +optional
+	AutoFailover *AutoFailover `json:"autoFailover,omitempty"`
+
+	// ActionApproval, if set, requires the failovers and relocations of the
+	// DRPlacementControls of the policy to be approved by a user other than
+	// the requester
+	// +optional
+	ActionApproval *ActionApprovalPolicy `json:"actionApproval,omitempty"`
+}
+
+// DRPolicyStatus defines the observed state of DRPolicy
+// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+// Important: Run "make" to regenerate code after modifying this file
+type DRPolicyStatus struct {
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ClusterSet lists the names of the clusters the policy is applied to.  It
+	// includes clusters removed from the spec until their removal completes.
+	// +optional
+	ClusterSet []string `json:"clusterSet,omitempty"`
+
+	// AutoFailoverTimes are the times of the automatic failovers of the
+	// DRPlacementControls of the policy within the last hour
+	// +optional
+	AutoFailoverTimes []metav1.Time `json:"autoFailoverTimes,omitempty"`
+
+	// LastHealthCheckTime is the time the s3 profiles and replication classes
+	// of the policy were last checked for its health conditions
+	// +optional
+	LastHealthCheckTime *metav1.Time `json:"lastHealthCheckTime,omitempty"`
+}
+
+const (
+	DRPolicyValidated string = `Validated`
+
+	// DRPolicyClustersAvailable reports whether the clusters of the policy are
+	// joined and available managed clusters
+	DRPolicyClustersAvailable string = `ClustersAvailable`
+
+	// DRPolicyS3Reachable reports whether objects can be stored in, and
+	// retrieved from, the s3 profiles of the policy
+	DRPolicyS3Reachable string = `S3Reachable`
+
+	// DRPolicyReplicationClassesPresent reports whether the clusters of the
+	// policy offer a VolumeReplicationClass that matches the policy
+	DRPolicyReplicationClassesPresent string = `ReplicationClassesPresent`
+
+	// DRPolicyDeletionBlocked reports whether the deletion of the policy is
+	// held, as DRPlacementControls still refer to it
+	DRPolicyDeletionBlocked string = `DeletionBlocked`
+
+	// DRPolicyClusterSetUpdated reports whether changes to the clusters of the
+	// policy are applied, or why the removal of a cluster is pending
+	DRPolicyClusterSetUpdated string = `ClusterSetUpdated`
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:storageversion
+
+// DRPolicy is the Schema for the drpolicies API
+type DRPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DRPolicySpec   `json:"spec,omitempty"`
+	Status DRPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DRPolicyList contains a list of DRPolicy
+type DRPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DRPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DRPolicy{}, &DRPolicyList{})
+}
@@ -0,0 +1,261 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Important: Run "make" to regenerate code after modifying this file
+
+// ReplicationState represents the replication operations to be performed on the volume
+// +kubebuilder:validation:Enum=primary;secondary
+type ReplicationState string
+
+const (
+	// Promote the protected PVCs to primary
+	Primary ReplicationState = "primary"
+
+	// Demote the proteced PVCs to secondary
+	Secondary ReplicationState = "secondary"
+)
+
+// State captures the latest state of the replication operation
+type State string
+
+const (
+	// PrimaryState represents the Primary replication state
+	PrimaryState State = "Primary"
+
+	// SecondaryState represents the Secondary replication state
+	SecondaryState State = "Secondary"
+
+	// UnknownState represents the Unknown replication state
+	UnknownState State = "Unknown"
+)
+
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// VolumeReplicationGroup (VRG) spec declares the desired schedule for data
+// replication and replication state of all PVCs identified via the given
+// PVC label selector. For each such PVC, the VRG will do the following:
+// 	- Create a VolumeReplication (VR) CR to enable storage level replication
+// 	  of volume data and set the desired replication state (primary, secondary,
+//    etc).
+//  - Take the corresponding PV cluster data in Kubernetes etcd and deposit it in
+//    the S3 store.  The url, access key and access id required to access the
+//    S3 store is specified via environment variables of the VRG operator POD,
+//    which is obtained from a secret resource.
+//  - Manage the lifecycle of VR CR and S3 data according to CUD operations on
+//    the PVC and the VRG CR.
+type VolumeReplicationGroupSpec struct {
+	// Label selector to identify all the PVCs that are in this group
+	// that needs to be replicated to the peer cluster.
+	PVCSelector metav1.LabelSelector `json:"pvcSelector"`
+
+	// Label selector to identify the VolumeReplicationClass resources
+	// that are scanned to select an appropriate VolumeReplicationClass
+	// for the VolumeReplication resource.
+	//+optional
+	ReplicationClassSelector metav1.LabelSelector `json:"replicationClassSelector,omitempty"`
+
+	// scheduling Interval for replicating Persistent Volume
+	// data to a peer cluster. Interval is typically in the
+	// form <num><m,h,d>. Here <num> is a number, 'm' means
+	// minutes, 'h' means hours and 'd' stands for days.
+	// Required in Async replication mode, and not supported
+	// in Sync replication mode.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^\d+[mhd]$`
+	SchedulingInterval string `json:"schedulingInterval,omitempty"`
+
+	// Mode of replication of the volumes, as in the DRPolicy.  In Sync mode,
+	// the VolumeReplicationClass of a volume is one without a scheduling
+	// interval, and volumes are neither demoted nor resynced as secondary.
+	// +optional
+	ReplicationMode ReplicationMode `json:"replicationMode,omitempty"`
+
+	// Desired state of all volumes [primary or secondary] in this replication group;
+	// this value is propagated to children VolumeReplication CRs
+	ReplicationState ReplicationState `json:"replicationState"`
+
+	// List of unique S3 profiles in RamenConfig that should be used to store
+	// and forward PV related cluster state to peer DR clusters.
+	//+optional
+	S3Profiles []string `json:"s3Profiles,omitempty"`
+
+	// List of consistency groups, each identifying a subset of the PVCs
+	// selected by PVCSelector whose volumes are replicated together, as a
+	// single unit, to a crash-consistent point. PVCs that are not part of any
+	// consistency group are replicated individually.
+	//+optional
+	ConsistencyGroups []ConsistencyGroup `json:"consistencyGroups,omitempty"`
+
+	// Request to resync the volumes of PVCs, as secondary, with their current
+	// primary.  Typically required post a failover, when the volumes of the
+	// old primary have diverged from the new primary (split-brain).  Ignored
+	// when ReplicationState is primary.
+	//+optional
+	Resync *ResyncRequest `json:"resync,omitempty"`
+
+	// Preview the protection of PVCs, without protecting any of them.  The
+	// protection plan is reported in the status, and no VolumeReplication
+	// resources, finalizers or S3 uploads are created.  Ignored once the
+	// VolumeReplicationGroup has started protecting PVCs.
+	//+optional
+	Preview bool `json:"preview,omitempty"`
+}
+
+// PVCPreviewResult is the outcome of protecting a pvc, as previewed
+type PVCPreviewResult string
+
+const (
+	// PVCProtectable indicates that the pvc would be protected using the
+	// previewed VolumeReplicationClass
+	PVCProtectable PVCPreviewResult = "Protectable"
+
+	// PVCSkipped indicates that the pvc would be skipped, until its state
+	// changes (for example, once bound)
+	PVCSkipped PVCPreviewResult = "Skipped"
+
+	// PVCNoReplicationClass indicates that no VolumeReplicationClass matches
+	// the pvc storage provisioner and the VRG schedule
+	PVCNoReplicationClass PVCPreviewResult = "NoReplicationClass"
+)
+
+// PVCPreview is the previewed protection of a pvc selected by PVCSelector
+type PVCPreview struct {
+	// Name of the pvc
+	Name string `json:"name"`
+
+	// Result of protecting the pvc
+	Result PVCPreviewResult `json:"result"`
+
+	// Name of the VolumeReplicationClass that would be used to replicate the pvc
+	//+optional
+	VolumeReplicationClass string `json:"volumeReplicationClass,omitempty"`
+
+	// Name of the consistency group the pvc would be replicated with, if any
+	//+optional
+	ConsistencyGroup string `json:"consistencyGroup,omitempty"`
+
+	// Reason the pvc would be skipped, or has no VolumeReplicationClass
+	//+optional
+	Message string `json:"message,omitempty"`
+}
+
+// ProtectionPreview is the plan to protect PVCs, computed when Preview is set
+type ProtectionPreview struct {
+	// Generation of the VolumeReplicationGroup the preview was computed for
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Previewed protection of each pvc selected by PVCSelector
+	PVCs []PVCPreview `json:"pvcs,omitempty"`
+}
+
+// ResyncRequest identifies PVCs whose volumes need to be resynced.  A resync
+// is performed once per RequestID for each PVC, and its completion is recorded
+// in the status of the protected PVC.
+type ResyncRequest struct {
+	// Unique identifier of the request.  Change it to request a new resync.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	RequestID string `json:"requestID"`
+
+	// Names of the PVCs to resync.  All PVCs of the VolumeReplicationGroup
+	// are resynced when empty.  PVCs of a consistency group are resynced as a
+	// group, when any of its PVCs is listed.
+	//+optional
+	PVCNames []string `json:"pvcNames,omitempty"`
+}
+
+// ConsistencyGroup identifies a set of PVCs that are replicated using a single
+// group level replication resource (VolumeGroupReplication), instead of a
+// VolumeReplication resource per PVC.
+type ConsistencyGroup struct {
+	// Name of the consistency group, unique within the VolumeReplicationGroup
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Label selector to identify the PVCs, among those selected by the
+	// VolumeReplicationGroup PVCSelector, that belong to this group
+	PVCSelector metav1.LabelSelector `json:"pvcSelector"`
+}
+
+type ProtectedPVC struct {
+	// Name of the pvc
+	Name string `json:"name,omitempty"`
+
+	// Name of the consistency group the pvc is replicated with, if any
+	ConsistencyGroup string `json:"consistencyGroup,omitempty"`
+
+	// RequestID of the last resync request completed for the pvc
+	ResyncRequestID string `json:"resyncRequestID,omitempty"`
+
+	// Conditions for each protected pvc
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// VolumeReplicationGroupStatus defines the observed state of VolumeReplicationGroup
+// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+type VolumeReplicationGroupStatus struct {
+	State State `json:"state,omitempty"`
+
+	// All the protected pvcs
+	ProtectedPVCs []ProtectedPVC `json:"protectedPVCs,omitempty"`
+
+	// Conditions are the list of VRG's summary conditions and their status.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Protection plan of the PVCs, reported while Preview is set in the spec
+	// and the VolumeReplicationGroup has not started protecting PVCs
+	//+optional
+	ProtectionPreview *ProtectionPreview `json:"protectionPreview,omitempty"`
+
+	// observedGeneration is the last generation change the operator has dealt with
+	// +optional
+	ObservedGeneration int64       `json:"observedGeneration,omitempty"`
+	LastUpdateTime     metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=vrg
+// +kubebuilder:storageversion
+
+// VolumeReplicationGroup is the Schema for the volumereplicationgroups API
+type VolumeReplicationGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VolumeReplicationGroupSpec   `json:"spec,omitempty"`
+	Status VolumeReplicationGroupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VolumeReplicationGroupList contains a list of VolumeReplicationGroup
+type VolumeReplicationGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VolumeReplicationGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VolumeReplicationGroup{}, &VolumeReplicationGroupList{})
+}
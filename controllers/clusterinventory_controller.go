@@ -0,0 +1,359 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	volrep "github.com/csi-addons/volume-replication-operator/api/v1alpha1"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// ClusterInventoryName is the name of the ConfigMap, in the namespace of the
+	// dr-cluster operator, that holds the DR capability inventory of the cluster
+	ClusterInventoryName = "ramen-dr-cluster-inventory"
+
+	// ClusterInventoryKey is the ConfigMap data key holding the inventory
+	ClusterInventoryKey = "inventory"
+
+	defaultOperatorNamespace = "ramen-system"
+)
+
+// OperatorVersion is the version of the operator, set at build time
+var OperatorVersion = "devel"
+
+// ClusterInventory is the DR capability inventory published by the dr-cluster
+// operator of a managed cluster, for the hub to read it through a
+// ManagedClusterView, as a ManagedClusterView cannot list resources
+type ClusterInventory struct {
+	OperatorVersion          string                       `json:"operatorVersion,omitempty"`
+	VolumeReplicationClasses []VolumeReplicationClassInfo `json:"volumeReplicationClasses,omitempty"`
+	StorageClasses           []StorageClassInfo           `json:"storageClasses,omitempty"`
+	CSIDrivers               []string                     `json:"csiDrivers,omitempty"`
+	VolumeSnapshotClasses    []VolumeSnapshotClassInfo    `json:"volumeSnapshotClasses,omitempty"`
+}
+
+// VolumeReplicationClassInfo describes a VolumeReplicationClass of a cluster
+type VolumeReplicationClassInfo struct {
+	Name               string            `json:"name"`
+	Provisioner        string            `json:"provisioner"`
+	SchedulingInterval string            `json:"schedulingInterval,omitempty"`
+	Labels             map[string]string `json:"labels,omitempty"`
+}
+
+// StorageClassInfo describes a StorageClass of a cluster
+type StorageClassInfo struct {
+	Name        string `json:"name"`
+	Provisioner string `json:"provisioner"`
+	Default     bool   `json:"default,omitempty"`
+}
+
+// VolumeSnapshotClassInfo describes a VolumeSnapshotClass of a cluster
+type VolumeSnapshotClassInfo struct {
+	Name   string `json:"name"`
+	Driver string `json:"driver"`
+}
+
+// HasReplicationClass returns true if the inventory contains a
+// VolumeReplicationClass with the given labels and scheduling interval, as
+// selected by a VRG
+func (inventory *ClusterInventory) HasReplicationClass(matchLabels map[string]string,
+	schedulingInterval string) bool {
+	return len(inventory.ReplicationProvisioners(matchLabels, schedulingInterval)) > 0
+}
+
+// ReplicationProvisioners returns the provisioners of the
+// VolumeReplicationClasses with the given labels and scheduling interval, an
+// empty interval selecting the classes without a schedule, as in Sync mode
+func (inventory *ClusterInventory) ReplicationProvisioners(matchLabels map[string]string,
+	schedulingInterval string) []string {
+	selector := labels.SelectorFromSet(matchLabels)
+	provisioners := []string{}
+
+	for idx := range inventory.VolumeReplicationClasses {
+		replicationClass := &inventory.VolumeReplicationClasses[idx]
+		if replicationClass.SchedulingInterval == schedulingInterval &&
+			selector.Matches(labels.Set(replicationClass.Labels)) {
+			provisioners = append(provisioners, replicationClass.Provisioner)
+		}
+	}
+
+	return provisioners
+}
+
+// HasStorageClass returns true if the inventory contains a StorageClass of
+// the provisioner
+func (inventory *ClusterInventory) HasStorageClass(provisioner string) bool {
+	for idx := range inventory.StorageClasses {
+		if inventory.StorageClasses[idx].Provisioner == provisioner {
+			return true
+		}
+	}
+
+	return false
+}
+
+// OperatorNamespace returns the namespace of the ramen operator, which the hub
+// and dr-cluster operators are assumed to share
+func OperatorNamespace() string {
+	if namespace := os.Getenv("POD_NAMESPACE"); namespace != "" {
+		return namespace
+	}
+
+	return defaultOperatorNamespace
+}
+
+// clusterInventoryViewName returns the name of the ManagedClusterView, in the
+// namespace of each managed cluster on the hub, of the cluster inventory
+func clusterInventoryViewName() string {
+	return BuildManagedClusterViewName(ClusterInventoryName, OperatorNamespace(), "cm")
+}
+
+// ClusterInventoryFromConfigMap decodes the inventory held by the ConfigMap
+func ClusterInventoryFromConfigMap(configMap *corev1.ConfigMap) (*ClusterInventory, error) {
+	inventory := &ClusterInventory{}
+
+	data, ok := configMap.Data[ClusterInventoryKey]
+	if !ok {
+		return nil, fmt.Errorf("inventory key %s not found in ConfigMap %s/%s", ClusterInventoryKey,
+			configMap.Namespace, configMap.Name)
+	}
+
+	if err := json.Unmarshal([]byte(data), inventory); err != nil {
+		return nil, fmt.Errorf("failed to decode inventory of ConfigMap %s/%s, %w", configMap.Namespace,
+			configMap.Name, err)
+	}
+
+	return inventory, nil
+}
+
+// clusterInventoryRefreshInterval is the interval at which the inventory is
+// rebuilt, as VolumeSnapshotClasses are not watched, their CRD being optional
+const clusterInventoryRefreshInterval = 5 * time.Minute
+
+// isDefaultStorageClassAnnotation marks the default StorageClass of a cluster
+const isDefaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+
+// volumeSnapshotClassListGVK is the kind of VolumeSnapshotClass lists, which
+// are read as unstructured objects, as the snapshot API is optional
+var volumeSnapshotClassListGVK = schema.GroupVersionKind{
+	Group:   "snapshot.storage.k8s.io",
+	Version: "v1",
+	Kind:    "VolumeSnapshotClassList",
+}
+
+// ClusterInventoryReconciler publishes the DR capability inventory of the
+// cluster, on changes to its VolumeReplicationClasses, StorageClasses and CSI
+// drivers, and periodically
+type ClusterInventoryReconciler struct {
+	client.Client
+	APIReader client.Reader
+	Log       logr.Logger
+}
+
+//nolint:lll
+// +kubebuilder:rbac:groups=replication.storage.openshift.io,resources=volumereplicationclasses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses;csidrivers,verbs=get;list;watch
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshotclasses,verbs=get;list
+// +kubebuilder:rbac:groups="",namespace=system,resources=configmaps,verbs=get;create;update
+
+// Reconcile rebuilds the inventory from the VolumeReplicationClasses,
+// StorageClasses, CSI drivers and VolumeSnapshotClasses of the cluster,
+// regardless of the resource that changed
+func (r *ClusterInventoryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("name", req.Name)
+
+	inventory := ClusterInventory{OperatorVersion: OperatorVersion}
+
+	for _, add := range []func(context.Context, *ClusterInventory) error{
+		r.replicationClassesAdd,
+		r.storageClassesAdd,
+		r.csiDriversAdd,
+		r.volumeSnapshotClassesAdd,
+	} {
+		if err := add(ctx, &inventory); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	data, err := json.Marshal(inventory)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to encode inventory, %w", err)
+	}
+
+	if err := r.publish(ctx, string(data), log); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: clusterInventoryRefreshInterval}, nil
+}
+
+func (r *ClusterInventoryReconciler) replicationClassesAdd(ctx context.Context, inventory *ClusterInventory) error {
+	replicationClasses := &volrep.VolumeReplicationClassList{}
+	if err := r.List(ctx, replicationClasses); err != nil {
+		return fmt.Errorf("failed to list VolumeReplicationClasses, %w", err)
+	}
+
+	for idx := range replicationClasses.Items {
+		replicationClass := &replicationClasses.Items[idx]
+		inventory.VolumeReplicationClasses = append(inventory.VolumeReplicationClasses,
+			VolumeReplicationClassInfo{
+				Name:               replicationClass.Name,
+				Provisioner:        replicationClass.Spec.Provisioner,
+				SchedulingInterval: replicationClass.Spec.Parameters["schedulingInterval"],
+				Labels:             replicationClass.Labels,
+			})
+	}
+
+	return nil
+}
+
+func (r *ClusterInventoryReconciler) storageClassesAdd(ctx context.Context, inventory *ClusterInventory) error {
+	storageClasses := &storagev1.StorageClassList{}
+	if err := r.List(ctx, storageClasses); err != nil {
+		return fmt.Errorf("failed to list StorageClasses, %w", err)
+	}
+
+	for idx := range storageClasses.Items {
+		storageClass := &storageClasses.Items[idx]
+		inventory.StorageClasses = append(inventory.StorageClasses, StorageClassInfo{
+			Name:        storageClass.Name,
+			Provisioner: storageClass.Provisioner,
+			Default:     storageClass.Annotations[isDefaultStorageClassAnnotation] == "true",
+		})
+	}
+
+	return nil
+}
+
+func (r *ClusterInventoryReconciler) csiDriversAdd(ctx context.Context, inventory *ClusterInventory) error {
+	csiDrivers := &storagev1.CSIDriverList{}
+	if err := r.List(ctx, csiDrivers); err != nil {
+		return fmt.Errorf("failed to list CSIDrivers, %w", err)
+	}
+
+	for idx := range csiDrivers.Items {
+		inventory.CSIDrivers = append(inventory.CSIDrivers, csiDrivers.Items[idx].Name)
+	}
+
+	return nil
+}
+
+// volumeSnapshotClassesAdd adds the VolumeSnapshotClasses, if the snapshot API
+// is installed.  They are read using the APIReader, as they are not watched.
+func (r *ClusterInventoryReconciler) volumeSnapshotClassesAdd(ctx context.Context,
+	inventory *ClusterInventory) error {
+	snapshotClasses := &unstructured.UnstructuredList{}
+	snapshotClasses.SetGroupVersionKind(volumeSnapshotClassListGVK)
+
+	if err := r.APIReader.List(ctx, snapshotClasses); err != nil {
+		if meta.IsNoMatchError(err) || errors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to list VolumeSnapshotClasses, %w", err)
+	}
+
+	for idx := range snapshotClasses.Items {
+		snapshotClass := &snapshotClasses.Items[idx]
+		driver, _, _ := unstructured.NestedString(snapshotClass.Object, "driver")
+		inventory.VolumeSnapshotClasses = append(inventory.VolumeSnapshotClasses, VolumeSnapshotClassInfo{
+			Name:   snapshotClass.GetName(),
+			Driver: driver,
+		})
+	}
+
+	return nil
+}
+
+// publish creates or updates the inventory ConfigMap.  The ConfigMap is read
+// using the APIReader to avoid caching ConfigMaps of all namespaces.
+func (r *ClusterInventoryReconciler) publish(ctx context.Context, data string, log logr.Logger) error {
+	configMap := &corev1.ConfigMap{}
+	namespacedName := types.NamespacedName{Name: ClusterInventoryName, Namespace: OperatorNamespace()}
+
+	if err := r.APIReader.Get(ctx, namespacedName, configMap); err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to get inventory ConfigMap %s, %w", namespacedName, err)
+		}
+
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: namespacedName.Name, Namespace: namespacedName.Namespace},
+			Data:       map[string]string{ClusterInventoryKey: data},
+		}
+
+		log.Info("Creating inventory ConfigMap", "name", namespacedName)
+
+		if err := r.Create(ctx, configMap); err != nil {
+			return fmt.Errorf("failed to create inventory ConfigMap %s, %w", namespacedName, err)
+		}
+
+		return nil
+	}
+
+	if configMap.Data[ClusterInventoryKey] == data {
+		return nil
+	}
+
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+
+	configMap.Data[ClusterInventoryKey] = data
+
+	log.Info("Updating inventory ConfigMap", "name", namespacedName)
+
+	if err := r.Update(ctx, configMap); err != nil {
+		return fmt.Errorf("failed to update inventory ConfigMap %s, %w", namespacedName, err)
+	}
+
+	return nil
+}
+
+// inventoryMapFunc maps any of the resources of the inventory to a single
+// request, as the whole inventory is rebuilt on any change
+func inventoryMapFunc(obj client.Object) []reconcile.Request {
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: ClusterInventoryName}}}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterInventoryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&volrep.VolumeReplicationClass{}).
+		Watches(&source.Kind{Type: &storagev1.StorageClass{}}, handler.EnqueueRequestsFromMapFunc(inventoryMapFunc)).
+		Watches(&source.Kind{Type: &storagev1.CSIDriver{}}, handler.EnqueueRequestsFromMapFunc(inventoryMapFunc)).
+		Complete(r)
+}
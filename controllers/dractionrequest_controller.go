@@ -0,0 +1,305 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers/util"
+)
+
+// drActionRequestPendingInterval is the interval at which a pending request
+// rechecks whether the running request of its DRPC has completed
+const drActionRequestPendingInterval = 15 * time.Second
+
+// DRActionRequestReconciler reconciles a DRActionRequest object
+type DRActionRequestReconciler struct {
+	client.Client
+	APIReader     client.Reader
+	Log           logr.Logger
+	eventRecorder *util.EventReporter
+}
+
+//nolint:lll
+//+kubebuilder:rbac:groups=ramendr.openshift.io,resources=dractionrequests,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=ramendr.openshift.io,resources=dractionrequests/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=ramendr.openshift.io,resources=dractionrequests/finalizers,verbs=update
+// +kubebuilder:rbac:groups=ramendr.openshift.io,resources=drplacementcontrols,verbs=get;list;watch;update
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile requests the action of a DRActionRequest from its DRPC, once no
+// other request of the DRPC is running, and follows the action in the action
+// history of the DRPC to completion, recording its outcome in the status of
+// the request
+func (r *DRActionRequestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("name", req.NamespacedName.Name, "namespace", req.NamespacedName.Namespace)
+	log.Info("reconcile enter")
+
+	defer log.Info("reconcile exit")
+
+	request := &ramen.DRActionRequest{}
+	if err := r.Client.Get(ctx, req.NamespacedName, request); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(fmt.Errorf("get: %w", err))
+	}
+
+	if !request.GetDeletionTimestamp().IsZero() || drActionRequestCompleted(request) {
+		return ctrl.Result{}, nil
+	}
+
+	drpc := &ramen.DRPlacementControl{}
+	if err := r.APIReader.Get(ctx, types.NamespacedName{Name: request.Spec.DRPCName, Namespace: request.Namespace},
+		drpc); err != nil {
+		if !errors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("drpc get: %w", err)
+		}
+
+		return ctrl.Result{}, r.complete(ctx, request, ramen.DRActionRequestFailed,
+			fmt.Sprintf("DRPlacementControl %s not found", request.Spec.DRPCName))
+	}
+
+	if request.Status.Phase != ramen.DRActionRequestRunning {
+		if err := r.start(ctx, request, drpc, log); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if request.Status.Phase == ramen.DRActionRequestPending {
+			return ctrl.Result{RequeueAfter: drActionRequestPendingInterval}, nil
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	return ctrl.Result{}, r.progress(ctx, request, drpc)
+}
+
+// start requests the action from the DRPC, unless another request of the DRPC
+// is running, or the DRPC has already completed the action
+func (r *DRActionRequestReconciler) start(ctx context.Context, request *ramen.DRActionRequest,
+	drpc *ramen.DRPlacementControl, log logr.Logger,
+) error {
+	running, err := r.runningRequest(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	if running != "" {
+		return r.statusSet(ctx, request, ramen.DRActionRequestPending,
+			fmt.Sprintf("waiting for DRActionRequest %s of the DRPlacementControl to complete", running))
+	}
+
+	if drpcActionMatches(drpc, request.Spec.Action, request.Spec.TargetCluster) && drpcActionFinalPhase(drpc) {
+		return r.complete(ctx, request, ramen.DRActionRequestSucceeded,
+			fmt.Sprintf("DRPlacementControl is already %s to cluster %s", drpc.Status.Phase, request.Spec.TargetCluster))
+	}
+
+	drpcActionSet(drpc, request.Spec.Action, request.Spec.TargetCluster)
+
+	annotations := drpc.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	annotations[ramen.DRPCActionRequestAnnotation] = request.Name
+	drpc.SetAnnotations(annotations)
+
+	if err := r.Client.Update(ctx, drpc); err != nil {
+		return fmt.Errorf("drpc %s update: %w", drpcNamespacedName(drpc), err)
+	}
+
+	msg := fmt.Sprintf("%s to cluster %s requested by %s through DRActionRequest %s", request.Spec.Action,
+		request.Spec.TargetCluster, request.Spec.Requester, request.Name)
+	if request.Spec.Reason != "" {
+		msg += ": " + request.Spec.Reason
+	}
+
+	log.Info(msg)
+	util.ReportIfNotPresent(r.eventRecorder, drpc, corev1.EventTypeNormal, util.EventReasonActionRequested, msg)
+
+	now := metav1.Now()
+	request.Status.StartTime = &now
+
+	return r.statusSet(ctx, request, ramen.DRActionRequestRunning, "action requested from the DRPlacementControl")
+}
+
+// progress follows the action in the action history of the DRPC, and records
+// its outcome once it is no longer in progress
+func (r *DRActionRequestReconciler) progress(ctx context.Context, request *ramen.DRActionRequest,
+	drpc *ramen.DRPlacementControl,
+) error {
+	if !drpcActionMatches(drpc, request.Spec.Action, request.Spec.TargetCluster) {
+		return r.complete(ctx, request, ramen.DRActionRequestFailed,
+			fmt.Sprintf("superseded by %s to cluster %s", drpc.Spec.Action, drpcActionTargetCluster(drpc)))
+	}
+
+	record := drpcActionRecord(drpc, request.Spec.Action, request.Spec.TargetCluster, *request.Status.StartTime)
+	if record == nil {
+		return r.awaitStart(ctx, request, drpc)
+	}
+
+	switch record.Outcome {
+	case ramen.ActionSucceeded:
+		return r.complete(ctx, request, ramen.DRActionRequestSucceeded,
+			fmt.Sprintf("DRPlacementControl %s to cluster %s", drpc.Status.Phase, request.Spec.TargetCluster))
+	case ramen.ActionCancelled:
+		return r.complete(ctx, request, ramen.DRActionRequestFailed, "action cancelled")
+	case ramen.ActionFailed:
+		return r.complete(ctx, request, ramen.DRActionRequestFailed, record.FailureReason)
+	}
+
+	msg := fmt.Sprintf("DRPlacementControl phase %s", drpc.Status.Phase)
+	if record.FailureReason != "" {
+		msg += ": " + record.FailureReason
+	}
+
+	return r.statusSet(ctx, request, ramen.DRActionRequestRunning, msg)
+}
+
+// awaitStart reports the approval of the action of the request, if it
+// requires one, while the DRPC is yet to start it, and fails the request if
+// the action is rejected or expired
+func (r *DRActionRequestReconciler) awaitStart(ctx context.Context, request *ramen.DRActionRequest,
+	drpc *ramen.DRPlacementControl,
+) error {
+	approval := drpcActionApproval(drpc, request.Spec.Action, request.Spec.TargetCluster, *request.Status.StartTime)
+	if approval == nil {
+		return r.statusSet(ctx, request, ramen.DRActionRequestRunning,
+			"waiting for the DRPlacementControl to start the action")
+	}
+
+	switch approval.State {
+	case ramen.ActionApprovalRejected, ramen.ActionApprovalExpired:
+		return r.complete(ctx, request, ramen.DRActionRequestFailed, approval.Message)
+	case ramen.ActionApprovalPending:
+		return r.statusSet(ctx, request, ramen.DRActionRequestRunning, approval.Message)
+	}
+
+	return r.statusSet(ctx, request, ramen.DRActionRequestRunning,
+		"action approved, waiting for the DRPlacementControl to start it")
+}
+
+// runningRequest returns the name of another running request of the DRPC of
+// the request, if any
+func (r *DRActionRequestReconciler) runningRequest(ctx context.Context, request *ramen.DRActionRequest,
+) (string, error) {
+	requests := &ramen.DRActionRequestList{}
+	if err := r.APIReader.List(ctx, requests, client.InNamespace(request.Namespace)); err != nil {
+		return "", fmt.Errorf("dractionrequests list: %w", err)
+	}
+
+	for _, other := range requests.Items {
+		if other.Name != request.Name && other.Spec.DRPCName == request.Spec.DRPCName &&
+			other.Status.Phase == ramen.DRActionRequestRunning {
+			return other.Name, nil
+		}
+	}
+
+	return "", nil
+}
+
+// complete records the outcome of the request
+func (r *DRActionRequestReconciler) complete(ctx context.Context, request *ramen.DRActionRequest,
+	phase ramen.DRActionRequestPhase, msg string,
+) error {
+	now := metav1.Now()
+	request.Status.CompletionTime = &now
+
+	eventType, reason := corev1.EventTypeNormal, util.EventReasonActionRequestSucceeded
+	if phase == ramen.DRActionRequestFailed {
+		eventType, reason = corev1.EventTypeWarning, util.EventReasonActionRequestFailed
+	}
+
+	util.ReportIfNotPresent(r.eventRecorder, request, eventType, reason, msg)
+
+	return r.statusSet(ctx, request, phase, msg)
+}
+
+// statusSet updates the phase and message of the request, if changed
+func (r *DRActionRequestReconciler) statusSet(ctx context.Context, request *ramen.DRActionRequest,
+	phase ramen.DRActionRequestPhase, msg string,
+) error {
+	if request.Status.Phase == phase && request.Status.Message == msg {
+		return nil
+	}
+
+	request.Status.Phase = phase
+	request.Status.Message = msg
+
+	if err := r.Client.Status().Update(ctx, request); err != nil {
+		return fmt.Errorf("status update: %w", err)
+	}
+
+	return nil
+}
+
+// drActionRequestCompleted returns true if the request succeeded or failed
+func drActionRequestCompleted(request *ramen.DRActionRequest) bool {
+	return request.Status.Phase == ramen.DRActionRequestSucceeded ||
+		request.Status.Phase == ramen.DRActionRequestFailed
+}
+
+// drpcActionRequestsMapFunc maps a DRPC to its requests that have not completed
+func (r *DRActionRequestReconciler) drpcActionRequestsMapFunc(obj client.Object) []reconcile.Request {
+	requests := &ramen.DRActionRequestList{}
+	if err := r.Client.List(context.TODO(), requests, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.Log.Error(err, "dractionrequests list", "namespace", obj.GetNamespace())
+
+		return []reconcile.Request{}
+	}
+
+	reconcileRequests := []reconcile.Request{}
+
+	for idx := range requests.Items {
+		request := &requests.Items[idx]
+		if request.Spec.DRPCName != obj.GetName() || drActionRequestCompleted(request) {
+			continue
+		}
+
+		reconcileRequests = append(reconcileRequests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: request.Name, Namespace: request.Namespace},
+		})
+	}
+
+	return reconcileRequests
+}
+
+// SetupWithManager sets up the controller with the Manager.  Requests are
+// reconciled on changes to their DRPCs, including their status.
+func (r *DRActionRequestReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.eventRecorder = util.NewEventReporter(mgr.GetEventRecorderFor("controller_DRActionRequest"))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ramen.DRActionRequest{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &ramen.DRPlacementControl{}},
+			handler.EnqueueRequestsFromMapFunc(r.drpcActionRequestsMapFunc)).
+		Complete(r)
+}
@@ -0,0 +1,198 @@
+package controllers_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// actionDRPCCreate creates a DRPC of the DRPolicy, placed on the cluster if
+// set, whose actions are requested by the DRActionRequest, DRClusterAction and
+// DRPlacementControlGroup controllers.  Its PlacementRule does not exist, so
+// that its status is set by the tests alone.
+func actionDRPCCreate(name, drpolicyName, clusterName string, annotations map[string]string,
+) *ramen.DRPlacementControl {
+	drpc := &ramen.DRPlacementControl{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: `default`, Annotations: annotations},
+		Spec: ramen.DRPlacementControlSpec{
+			PlacementRef: corev1.ObjectReference{Name: name + `-placement`, Kind: `PlacementRule`},
+			DRPolicyRef:  corev1.ObjectReference{Name: drpolicyName},
+			PVCSelector:  metav1.LabelSelector{MatchLabels: map[string]string{`app`: name}},
+		},
+	}
+	Expect(k8sClient.Create(context.TODO(), drpc)).To(Succeed())
+
+	if clusterName != `` {
+		actionDRPCStatusSet(drpc, func(status *ramen.DRPlacementControlStatus) {
+			status.PreferredDecision.ClusterName = clusterName
+		})
+	}
+
+	return drpc
+}
+
+// actionDRPCGet returns the latest DRPC
+func actionDRPCGet(drpc *ramen.DRPlacementControl) *ramen.DRPlacementControl {
+	Expect(apiReader.Get(context.TODO(), types.NamespacedName{Name: drpc.Name, Namespace: drpc.Namespace},
+		drpc)).To(Succeed())
+
+	return drpc
+}
+
+// actionDRPCStatusSet updates the status of the DRPC, as its controller would
+func actionDRPCStatusSet(drpc *ramen.DRPlacementControl, update func(*ramen.DRPlacementControlStatus)) {
+	Eventually(func() error {
+		if err := apiReader.Get(context.TODO(), types.NamespacedName{Name: drpc.Name, Namespace: drpc.Namespace},
+			drpc); err != nil {
+			return err
+		}
+		update(&drpc.Status)
+		drpc.Status.LastUpdateTime = metav1.Now()
+
+		return k8sClient.Status().Update(context.TODO(), drpc)
+	}, 10, 0.25).Should(Succeed())
+}
+
+// actionDRPCActionRecord records the action of the DRPC in its action history
+// with the outcome, in the phase the DRPC reaches
+func actionDRPCActionRecord(drpc *ramen.DRPlacementControl, phase ramen.DRState, outcome ramen.ActionOutcome,
+	failureReason string,
+) {
+	actionDRPCStatusSet(drpc, func(status *ramen.DRPlacementControlStatus) {
+		now := metav1.Now()
+		record := ramen.ActionRecord{
+			Action:        drpc.Spec.Action,
+			SourceCluster: status.PreferredDecision.ClusterName,
+			TargetCluster: drpc.Spec.FailoverCluster,
+			StartTime:     now,
+			Outcome:       outcome,
+			FailureReason: failureReason,
+		}
+		if drpc.Spec.Action == ramen.ActionRelocate {
+			record.TargetCluster = drpc.Spec.PreferredCluster
+		}
+		if outcome != ramen.ActionInProgress {
+			record.EndTime = &now
+		}
+
+		status.Phase = phase
+		for idx := range status.ActionHistory {
+			if status.ActionHistory[idx].Action == record.Action &&
+				status.ActionHistory[idx].TargetCluster == record.TargetCluster &&
+				status.ActionHistory[idx].Outcome == ramen.ActionInProgress {
+				record.StartTime = status.ActionHistory[idx].StartTime
+				status.ActionHistory[idx] = record
+
+				return
+			}
+		}
+		status.ActionHistory = append(status.ActionHistory, record)
+	})
+}
+
+var _ = Describe("DRActionRequestController", func() {
+	When(`a request names a DRPlacementControl that does not exist`, func() {
+		It(`should fail`, func() {
+			request := &ramen.DRActionRequest{
+				ObjectMeta: metav1.ObjectMeta{Name: `dractionrequest-nodrpc`, Namespace: `default`},
+				Spec: ramen.DRActionRequestSpec{
+					DRPCName:      `drpc-nonexistent`,
+					Action:        ramen.ActionFailover,
+					TargetCluster: `west`,
+				},
+			}
+			Expect(k8sClient.Create(context.TODO(), request)).To(Succeed())
+			Eventually(func(g Gomega) {
+				g.Expect(apiReader.Get(context.TODO(), types.NamespacedName{
+					Name: request.Name, Namespace: request.Namespace,
+				}, request)).To(Succeed())
+				g.Expect(request.Status.Phase).To(Equal(ramen.DRActionRequestFailed))
+				g.Expect(request.Status.CompletionTime).ToNot(BeNil())
+			}, 10, 0.25).Should(Succeed())
+			Expect(k8sClient.Delete(context.TODO(), request)).To(Succeed())
+		})
+	})
+	When(`requests name an existing DRPlacementControl`, func() {
+		var drpc *ramen.DRPlacementControl
+		requestNew := func(name string, action ramen.DRAction, targetCluster string) *ramen.DRActionRequest {
+			return &ramen.DRActionRequest{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: `default`},
+				Spec: ramen.DRActionRequestSpec{
+					DRPCName:      `dractionrequest-drpc`,
+					Action:        action,
+					TargetCluster: targetCluster,
+				},
+			}
+		}
+		failoverRequest := requestNew(`dractionrequest-failover`, ramen.ActionFailover, `cluster-west`)
+		relocateRequest := requestNew(`dractionrequest-relocate`, ramen.ActionRelocate, `cluster-east`)
+		requestExpect := func(request *ramen.DRActionRequest, phase ramen.DRActionRequestPhase, message string,
+			timeout float64,
+		) {
+			Eventually(func(g Gomega) {
+				g.Expect(apiReader.Get(context.TODO(), types.NamespacedName{
+					Name: request.Name, Namespace: request.Namespace,
+				}, request)).To(Succeed())
+				g.Expect(request.Status.Phase).To(Equal(phase))
+				g.Expect(request.Status.Message).To(Equal(message))
+			}, timeout, 0.25).Should(Succeed())
+		}
+		drpcActionExpect := func(request *ramen.DRActionRequest, targetCluster func() string) {
+			Eventually(func(g Gomega) {
+				g.Expect(apiReader.Get(context.TODO(), types.NamespacedName{Name: drpc.Name, Namespace: drpc.Namespace},
+					drpc)).To(Succeed())
+				g.Expect(drpc.Spec.Action).To(Equal(request.Spec.Action))
+				g.Expect(targetCluster()).To(Equal(request.Spec.TargetCluster))
+				g.Expect(drpc.GetAnnotations()).To(HaveKeyWithValue(ramen.DRPCActionRequestAnnotation, request.Name))
+			}, 10, 0.25).Should(Succeed())
+		}
+		Specify(`a DRPlacementControl`, func() {
+			drpc = actionDRPCCreate(`dractionrequest-drpc`, `dractionrequest-drpolicy`, `cluster-east`, nil)
+		})
+		It(`should request the action of the first request from the DRPlacementControl`, func() {
+			Expect(k8sClient.Create(context.TODO(), failoverRequest)).To(Succeed())
+			drpcActionExpect(failoverRequest, func() string { return drpc.Spec.FailoverCluster })
+			requestExpect(failoverRequest, ramen.DRActionRequestRunning,
+				`waiting for the DRPlacementControl to start the action`, 10)
+			Expect(failoverRequest.Status.StartTime).ToNot(BeNil())
+		})
+		It(`should hold a second request until the first completes`, func() {
+			Expect(k8sClient.Create(context.TODO(), relocateRequest)).To(Succeed())
+			requestExpect(relocateRequest, ramen.DRActionRequestPending,
+				`waiting for DRActionRequest `+failoverRequest.Name+` of the DRPlacementControl to complete`, 10)
+			Consistently(func() ramen.DRAction {
+				return actionDRPCGet(drpc).Spec.Action
+			}, 2, 0.25).Should(Equal(ramen.ActionFailover))
+		})
+		It(`should follow the action of the first request in the action history of the DRPlacementControl`,
+			func() {
+				actionDRPCActionRecord(drpc, ramen.FailingOver, ramen.ActionInProgress, ``)
+				requestExpect(failoverRequest, ramen.DRActionRequestRunning, `DRPlacementControl phase FailingOver`, 10)
+			})
+		It(`should succeed the first request once its action succeeds`, func() {
+			actionDRPCActionRecord(drpc, ramen.FailedOver, ramen.ActionSucceeded, ``)
+			requestExpect(failoverRequest, ramen.DRActionRequestSucceeded,
+				`DRPlacementControl FailedOver to cluster cluster-west`, 10)
+			Expect(failoverRequest.Status.CompletionTime).ToNot(BeNil())
+		})
+		It(`should then request the action of the second request from the DRPlacementControl`, func() {
+			drpcActionExpect(relocateRequest, func() string { return drpc.Spec.PreferredCluster })
+			requestExpect(relocateRequest, ramen.DRActionRequestRunning,
+				`waiting for the DRPlacementControl to start the action`, 30)
+		})
+		It(`should fail the second request once its action fails`, func() {
+			actionDRPCActionRecord(drpc, ramen.Relocating, ramen.ActionFailed, `relocation failed`)
+			requestExpect(relocateRequest, ramen.DRActionRequestFailed, `relocation failed`, 10)
+		})
+		Specify(`cleanup`, func() {
+			Expect(k8sClient.Delete(context.TODO(), failoverRequest)).To(Succeed())
+			Expect(k8sClient.Delete(context.TODO(), relocateRequest)).To(Succeed())
+			Expect(k8sClient.Delete(context.TODO(), drpc)).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,357 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	spokeClusterV1 "github.com/open-cluster-management/api/cluster/v1"
+	viewv1beta1 "github.com/open-cluster-management/multicloud-operators-foundation/pkg/apis/view/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// DRCluster validated, available and inventory reported condition reasons
+const (
+	DRClusterReasonS3ProfileFound       = `S3ProfileFound`
+	DRClusterReasonS3ProfileNotFound    = `S3ProfileNotFound`
+	DRClusterReasonClusterAvailable     = `ClusterAvailable`
+	DRClusterReasonClusterNotFound      = `ClusterNotFound`
+	DRClusterReasonClusterNotJoined     = `ClusterNotJoined`
+	DRClusterReasonClusterUnavailable   = `ClusterUnavailable`
+	DRClusterReasonInventoryReported    = `InventoryReported`
+	DRClusterReasonInventoryUnavailable = `InventoryUnavailable`
+)
+
+// drclusterFinalizerName is the finalizer that holds the deletion of a
+// DRCluster until its NetworkFence, if any, is deleted
+const drclusterFinalizerName = "drclusters.ramendr.openshift.io/ramen"
+
+// DRClusterReconciler reconciles a DRCluster object
+type DRClusterReconciler struct {
+	client.Client
+	APIReader client.Reader
+	Log       logr.Logger
+	MCVGetter ManagedClusterViewGetter
+}
+
+//nolint:lll
+//+kubebuilder:rbac:groups=ramendr.openshift.io,resources=drclusters,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=ramendr.openshift.io,resources=drclusters/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=ramendr.openshift.io,resources=drclusters/finalizers,verbs=update
+// +kubebuilder:rbac:groups=ramendr.openshift.io,resources=drpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=work.open-cluster-management.io,resources=manifestworks,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=view.open-cluster-management.io,resources=managedclusterviews,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile checks the configuration and health of the cluster described by a
+// DRCluster, and reports them in its status conditions, along with the
+// inventory published by its dr-cluster operator.  The checks are repeated
+// periodically, as the Ramen config is not watched, and the status is updated
+// only if they change.  The cluster is also fenced or unfenced as its spec
+// requests, and rechecked more often until done.
+func (r *DRClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("name", req.NamespacedName.Name)
+	log.Info("reconcile enter")
+
+	defer log.Info("reconcile exit")
+
+	drcluster := &ramen.DRCluster{}
+	if err := r.Client.Get(ctx, req.NamespacedName, drcluster); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(fmt.Errorf("get: %w", err))
+	}
+
+	if !drcluster.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, r.delete(ctx, drcluster, log)
+	}
+
+	if err := r.finalizerAdd(ctx, drcluster, log); err != nil {
+		return ctrl.Result{}, fmt.Errorf("finalizer add: %w", err)
+	}
+
+	status := drcluster.Status.DeepCopy()
+
+	r.conditionSet(status, drcluster.Generation, ramen.DRClusterValidated, DRClusterReasonS3ProfileFound,
+		`s3 profile found`,
+		func() (string, error) {
+			if _, err := getRamenConfigS3StoreProfile(drcluster.Spec.S3ProfileName); err != nil {
+				return DRClusterReasonS3ProfileNotFound, err
+			}
+
+			return ``, nil
+		})
+	r.conditionSet(status, drcluster.Generation, ramen.DRClusterAvailable, DRClusterReasonClusterAvailable,
+		`managed cluster available`,
+		func() (string, error) {
+			return managedClusterAvailable(ctx, r.APIReader, drcluster.Name)
+		})
+	r.conditionSet(status, drcluster.Generation, ramen.DRClusterInventoryReported, DRClusterReasonInventoryReported,
+		`inventory reported`,
+		func() (string, error) {
+			inventory, err := r.MCVGetter.GetClusterInventoryFromManagedCluster(drcluster.Name)
+			if err != nil {
+				return DRClusterReasonInventoryUnavailable, err
+			}
+
+			drclusterInventorySet(status, inventory)
+
+			return ``, nil
+		})
+
+	fencing := r.fenceReconcile(ctx, drcluster, status, log)
+
+	if !reflect.DeepEqual(&drcluster.Status, status) {
+		now := metav1.Now()
+		status.LastHealthCheckTime = &now
+		drcluster.Status = *status
+
+		if err := r.Client.Status().Update(ctx, drcluster); err != nil {
+			return ctrl.Result{}, fmt.Errorf("status update: %w", err)
+		}
+	}
+
+	if fencing {
+		return ctrl.Result{RequeueAfter: drclusterFenceRequeueInterval}, nil
+	}
+
+	return ctrl.Result{RequeueAfter: drpolicyHealthCheckInterval}, nil
+}
+
+// conditionSet sets the condition to true with the reason and message if the
+// check succeeds, and to false with the reason and error of the check otherwise
+func (r *DRClusterReconciler) conditionSet(status *ramen.DRClusterStatus, generation int64,
+	conditionType, reason, message string, check func() (string, error),
+) {
+	condition := metav1.Condition{
+		Type:               conditionType,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	}
+
+	if reason, err := check(); err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reason
+		condition.Message = err.Error()
+	}
+
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
+// delete deletes the NetworkFence of the cluster, if any, as the fence peer
+// cluster would otherwise keep fencing it, and then lets the DRCluster go
+func (r *DRClusterReconciler) delete(ctx context.Context, drcluster *ramen.DRCluster, log logr.Logger) error {
+	log.Info("delete")
+
+	if peerCluster := drcluster.Status.FencePeerCluster; peerCluster != `` {
+		if err := r.networkFenceDelete(ctx, drcluster, peerCluster, log); err != nil {
+			return fmt.Errorf("network fence delete: %w", err)
+		}
+	}
+
+	if controllerutil.ContainsFinalizer(drcluster, drclusterFinalizerName) {
+		log.Info("finalizer remove")
+		controllerutil.RemoveFinalizer(drcluster, drclusterFinalizerName)
+
+		if err := r.Client.Update(ctx, drcluster); err != nil {
+			return fmt.Errorf("finalizer remove: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *DRClusterReconciler) finalizerAdd(ctx context.Context, drcluster *ramen.DRCluster, log logr.Logger) error {
+	if controllerutil.ContainsFinalizer(drcluster, drclusterFinalizerName) {
+		return nil
+	}
+
+	log.Info("finalizer add")
+	controllerutil.AddFinalizer(drcluster, drclusterFinalizerName)
+
+	return r.Client.Update(ctx, drcluster)
+}
+
+// managedClusterAvailable checks that the cluster is a ManagedCluster that has
+// joined the hub, and is available
+func managedClusterAvailable(ctx context.Context, apiReader client.Reader, clusterName string) (string, error) {
+	managedCluster := &spokeClusterV1.ManagedCluster{}
+
+	if err := apiReader.Get(ctx, types.NamespacedName{Name: clusterName}, managedCluster); err != nil {
+		if errors.IsNotFound(err) {
+			return DRClusterReasonClusterNotFound, fmt.Errorf(`%s: managed cluster not found`, clusterName)
+		}
+
+		return DRClusterReasonClusterNotFound, fmt.Errorf(`%s: %w`, clusterName, err)
+	}
+
+	if !meta.IsStatusConditionTrue(managedCluster.Status.Conditions, spokeClusterV1.ManagedClusterConditionJoined) {
+		return DRClusterReasonClusterNotJoined, fmt.Errorf(`%s: managed cluster not joined`, clusterName)
+	}
+
+	if !meta.IsStatusConditionTrue(managedCluster.Status.Conditions,
+		spokeClusterV1.ManagedClusterConditionAvailable) {
+		return DRClusterReasonClusterUnavailable, fmt.Errorf(`%s: managed cluster not available`, clusterName)
+	}
+
+	return ``, nil
+}
+
+// drclusterInventorySet copies the inventory to the DRCluster status
+func drclusterInventorySet(status *ramen.DRClusterStatus, inventory *ClusterInventory) {
+	status.OperatorVersion = inventory.OperatorVersion
+	status.CSIDrivers = inventory.CSIDrivers
+	status.ReplicationClasses = nil
+	status.StorageClasses = nil
+	status.VolumeSnapshotClasses = nil
+
+	for _, replicationClass := range inventory.VolumeReplicationClasses {
+		status.ReplicationClasses = append(status.ReplicationClasses,
+			ramen.DRClusterReplicationClass(replicationClass))
+	}
+
+	for _, storageClass := range inventory.StorageClasses {
+		status.StorageClasses = append(status.StorageClasses, ramen.DRClusterStorageClass(storageClass))
+	}
+
+	for _, snapshotClass := range inventory.VolumeSnapshotClasses {
+		status.VolumeSnapshotClasses = append(status.VolumeSnapshotClasses,
+			ramen.DRClusterVolumeSnapshotClass(snapshotClass))
+	}
+}
+
+// drclusterInventory returns the inventory reported in the DRCluster status
+func drclusterInventory(status *ramen.DRClusterStatus) *ClusterInventory {
+	inventory := &ClusterInventory{OperatorVersion: status.OperatorVersion, CSIDrivers: status.CSIDrivers}
+
+	for _, replicationClass := range status.ReplicationClasses {
+		inventory.VolumeReplicationClasses = append(inventory.VolumeReplicationClasses,
+			VolumeReplicationClassInfo(replicationClass))
+	}
+
+	for _, storageClass := range status.StorageClasses {
+		inventory.StorageClasses = append(inventory.StorageClasses, StorageClassInfo(storageClass))
+	}
+
+	for _, snapshotClass := range status.VolumeSnapshotClasses {
+		inventory.VolumeSnapshotClasses = append(inventory.VolumeSnapshotClasses,
+			VolumeSnapshotClassInfo(snapshotClass))
+	}
+
+	return inventory
+}
+
+// drclusterGet returns the DRCluster of the cluster, or nil if the cluster has
+// none, as DRClusters are optional
+func drclusterGet(ctx context.Context, reader client.Reader, clusterName string) (*ramen.DRCluster, error) {
+	drcluster := &ramen.DRCluster{}
+
+	if err := reader.Get(ctx, types.NamespacedName{Name: clusterName}, drcluster); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("drcluster %s get: %w", clusterName, err)
+	}
+
+	return drcluster, nil
+}
+
+// drclusterUnavailable returns a reason if the DRCluster reports its cluster as
+// unavailable, or as fenced, or an empty string otherwise
+func drclusterUnavailable(drcluster *ramen.DRCluster) string {
+	if drcluster.Spec.ClusterFence == ramen.ClusterFenceStateFenced {
+		return `cluster is fenced`
+	}
+
+	if !drclusterUnfenced(drcluster) {
+		return `cluster is not yet unfenced`
+	}
+
+	condition := meta.FindStatusCondition(drcluster.Status.Conditions, ramen.DRClusterAvailable)
+	if condition != nil && condition.Status == metav1.ConditionFalse {
+		return condition.Message
+	}
+
+	return ``
+}
+
+// drclusterChangedPredicate filters DRCluster updates that change neither its
+// spec nor its conditions, such as health check time updates
+func drclusterChangedPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldDRCluster, ok := e.ObjectOld.(*ramen.DRCluster)
+			if !ok {
+				return false
+			}
+
+			newDRCluster, ok := e.ObjectNew.(*ramen.DRCluster)
+			if !ok {
+				return false
+			}
+
+			return !reflect.DeepEqual(oldDRCluster.Spec, newDRCluster.Spec) ||
+				!reflect.DeepEqual(oldDRCluster.Status.Conditions, newDRCluster.Status.Conditions)
+		},
+	}
+}
+
+func (r *DRClusterReconciler) managedClusterMapFunc(obj client.Object) []reconcile.Request {
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: obj.GetName()}}}
+}
+
+// inventoryViewMapFunc maps a cluster inventory view, in the namespace of the
+// cluster, to the DRCluster of the cluster
+func (r *DRClusterReconciler) inventoryViewMapFunc(obj client.Object) []reconcile.Request {
+	if _, ok := obj.(*viewv1beta1.ManagedClusterView); !ok || obj.GetName() != clusterInventoryViewName() {
+		return []reconcile.Request{}
+	}
+
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: obj.GetNamespace()}}}
+}
+
+// SetupWithManager sets up the controller with the Manager.  DRClusters are
+// rechecked on changes to their spec, ManagedClusters and cluster inventories,
+// but not on their status updates.
+func (r *DRClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ramen.DRCluster{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &spokeClusterV1.ManagedCluster{}},
+			handler.EnqueueRequestsFromMapFunc(r.managedClusterMapFunc),
+			builder.WithPredicates(managedClusterConditionsChangedPredicate())).
+		Watches(&source.Kind{Type: &viewv1beta1.ManagedClusterView{}},
+			handler.EnqueueRequestsFromMapFunc(r.inventoryViewMapFunc),
+			builder.WithPredicates(ManagedClusterViewPredicateFunc())).
+		Complete(r)
+}
@@ -0,0 +1,223 @@
+package controllers_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	spokeClusterV1 "github.com/open-cluster-management/api/cluster/v1"
+	ocmworkv1 "github.com/open-cluster-management/api/work/v1"
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers"
+	"github.com/ramendr/ramen/controllers/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("DRClusterController", func() {
+	const (
+		clusterName        = `drcluster-east`
+		clusterNamePeer    = `drcluster-west`
+		schedulingInterval = `5m`
+	)
+
+	conditionStatusExpect := func(conditionType string, status metav1.ConditionStatus, reason string) {
+		Eventually(func(g Gomega) {
+			drcluster := &ramen.DRCluster{}
+			g.Expect(apiReader.Get(context.TODO(), types.NamespacedName{Name: clusterName}, drcluster)).To(Succeed())
+			condition := meta.FindStatusCondition(drcluster.Status.Conditions, conditionType)
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(condition.Status).To(Equal(status))
+			g.Expect(condition.Reason).To(Equal(reason))
+		}, 10, 0.25).Should(Succeed())
+	}
+	drclusterUpdate := func(update func(*ramen.DRCluster)) {
+		drcluster := &ramen.DRCluster{}
+		Expect(apiReader.Get(context.TODO(), types.NamespacedName{Name: clusterName}, drcluster)).To(Succeed())
+		update(drcluster)
+		Expect(k8sClient.Update(context.TODO(), drcluster)).To(Succeed())
+	}
+	networkFenceManifestWorkGet := func() error {
+		return apiReader.Get(context.TODO(), types.NamespacedName{
+			Name:      util.NetworkFenceManifestWorkName(clusterName),
+			Namespace: clusterNamePeer,
+		}, &ocmworkv1.ManifestWork{})
+	}
+	managedClusterAvailableSet := func(status metav1.ConditionStatus) {
+		managedCluster := &spokeClusterV1.ManagedCluster{}
+		Expect(apiReader.Get(context.TODO(), types.NamespacedName{Name: clusterName}, managedCluster)).To(Succeed())
+		meta.SetStatusCondition(&managedCluster.Status.Conditions, metav1.Condition{
+			Type:    spokeClusterV1.ManagedClusterConditionAvailable,
+			Status:  status,
+			Reason:  "ManagedClusterAvailable",
+			Message: "Managed cluster availability set",
+		})
+		Expect(k8sClient.Status().Update(context.TODO(), managedCluster)).To(Succeed())
+	}
+
+	Specify(`managed clusters and a replication class`, func() {
+		for _, name := range []string{clusterName, clusterNamePeer} {
+			managedCluster := &spokeClusterV1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(context.TODO(), managedCluster)).To(Succeed())
+			managedClusterJoin(managedCluster)
+			Expect(k8sClient.Create(context.TODO(),
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}})).To(Succeed())
+		}
+		replicationClassCreate(`drcluster`+schedulingInterval, schedulingInterval)
+	})
+	When(`a drcluster is created`, func() {
+		It(`should report its cluster available, and its inventory`, func() {
+			Expect(k8sClient.Create(context.TODO(), &ramen.DRCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterName},
+				Spec:       ramen.DRClusterSpec{S3ProfileName: s3ProfileNameConnectSucc, Region: `east`},
+			})).To(Succeed())
+			conditionStatusExpect(ramen.DRClusterValidated, metav1.ConditionTrue,
+				controllers.DRClusterReasonS3ProfileFound)
+			conditionStatusExpect(ramen.DRClusterAvailable, metav1.ConditionTrue,
+				controllers.DRClusterReasonClusterAvailable)
+			conditionStatusExpect(ramen.DRClusterInventoryReported, metav1.ConditionTrue,
+				controllers.DRClusterReasonInventoryReported)
+			drcluster := &ramen.DRCluster{}
+			Expect(apiReader.Get(context.TODO(), types.NamespacedName{Name: clusterName}, drcluster)).To(Succeed())
+			Expect(drcluster.Status.OperatorVersion).To(Equal(controllers.OperatorVersion))
+			Expect(drcluster.Status.ReplicationClasses).To(ContainElement(ramen.DRClusterReplicationClass{
+				Name:               `drcluster` + schedulingInterval,
+				Provisioner:        `drpolicy.test.provisioner`,
+				SchedulingInterval: schedulingInterval,
+			}))
+			Expect(drcluster.Status.StorageClasses).To(ContainElement(ramen.DRClusterStorageClass{
+				Name:        `drpolicy-test-storageclass`,
+				Provisioner: `drpolicy.test.provisioner`,
+			}))
+			Expect(drcluster.Status.LastHealthCheckTime).ToNot(BeNil())
+		})
+		It(`should not update its status on a recheck that changes nothing`, func() {
+			drcluster := &ramen.DRCluster{}
+			Expect(apiReader.Get(context.TODO(), types.NamespacedName{Name: clusterName}, drcluster)).To(Succeed())
+			resourceVersion := drcluster.ResourceVersion
+			managedCluster := &spokeClusterV1.ManagedCluster{}
+			Expect(apiReader.Get(context.TODO(), types.NamespacedName{Name: clusterName}, managedCluster)).To(Succeed())
+			meta.SetStatusCondition(&managedCluster.Status.Conditions, metav1.Condition{
+				Type:    `DRClusterTestRecheck`,
+				Status:  metav1.ConditionTrue,
+				Reason:  `Recheck`,
+				Message: `condition set to recheck the drcluster`,
+			})
+			Expect(k8sClient.Status().Update(context.TODO(), managedCluster)).To(Succeed())
+			Consistently(func(g Gomega) {
+				g.Expect(apiReader.Get(context.TODO(), types.NamespacedName{Name: clusterName}, drcluster)).To(Succeed())
+				g.Expect(drcluster.ResourceVersion).To(Equal(resourceVersion))
+			}, 2, 0.25).Should(Succeed())
+		})
+	})
+	When(`its managed cluster becomes unavailable`, func() {
+		It(`should report its cluster unavailable`, func() {
+			managedClusterAvailableSet(metav1.ConditionFalse)
+			conditionStatusExpect(ramen.DRClusterAvailable, metav1.ConditionFalse,
+				controllers.DRClusterReasonClusterUnavailable)
+		})
+	})
+	When(`its managed cluster becomes available`, func() {
+		It(`should report its cluster available`, func() {
+			managedClusterAvailableSet(metav1.ConditionTrue)
+			conditionStatusExpect(ramen.DRClusterAvailable, metav1.ConditionTrue,
+				controllers.DRClusterReasonClusterAvailable)
+		})
+	})
+	When(`a drpolicy specifies another s3 profile for its cluster`, func() {
+		It(`should set the drpolicy validated status condition to false with reason S3ProfileMismatch`, func() {
+			drpolicy := &ramen.DRPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: `drpolicy-drcluster`},
+				Spec: ramen.DRPolicySpec{
+					DRClusterSet: []ramen.ManagedCluster{
+						{Name: clusterName, S3ProfileName: `s3profile-other`},
+						{Name: clusterNamePeer, S3ProfileName: s3ProfileNameConnectSucc},
+					},
+					SchedulingInterval: schedulingInterval,
+				},
+			}
+			Expect(k8sClient.Create(context.TODO(), drpolicy)).To(Succeed())
+			Eventually(func(g Gomega) {
+				g.Expect(apiReader.Get(context.TODO(), types.NamespacedName{Name: drpolicy.Name}, drpolicy)).To(Succeed())
+				condition := meta.FindStatusCondition(drpolicy.Status.Conditions, ramen.DRPolicyValidated)
+				g.Expect(condition).ToNot(BeNil())
+				g.Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(condition.Reason).To(Equal(controllers.DRPolicyReasonS3ProfileMismatch))
+			}, 10, 0.25).Should(Succeed())
+			Expect(k8sClient.Delete(context.TODO(), drpolicy)).To(Succeed())
+		})
+	})
+	When(`a drcluster with cidrs is fenced`, func() {
+		It(`should create a network fence on a peer cluster, and report its cluster fenced`, func() {
+			drpolicy := &ramen.DRPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: `drpolicy-drcluster-fence`},
+				Spec: ramen.DRPolicySpec{
+					DRClusterSet: []ramen.ManagedCluster{
+						{Name: clusterName, S3ProfileName: s3ProfileNameConnectSucc},
+						{Name: clusterNamePeer, S3ProfileName: s3ProfileNameConnectSucc},
+					},
+					SchedulingInterval: schedulingInterval,
+				},
+			}
+			Expect(k8sClient.Create(context.TODO(), drpolicy)).To(Succeed())
+			drclusterUpdate(func(drcluster *ramen.DRCluster) {
+				drcluster.SetAnnotations(map[string]string{
+					controllers.DRClusterStorageDriverAnnotation:          `drcluster.test.driver`,
+					controllers.DRClusterStorageSecretNameAnnotation:      `drcluster-test-secret`,
+					controllers.DRClusterStorageSecretNamespaceAnnotation: `drcluster-test`,
+				})
+				drcluster.Spec.CIDRs = []string{`10.0.0.0/24`}
+				drcluster.Spec.ClusterFence = ramen.ClusterFenceStateFenced
+			})
+			conditionStatusExpect(ramen.DRClusterFenced, metav1.ConditionTrue, controllers.DRClusterReasonFenced)
+			Expect(networkFenceManifestWorkGet()).To(Succeed())
+			drcluster := &ramen.DRCluster{}
+			Expect(apiReader.Get(context.TODO(), types.NamespacedName{Name: clusterName}, drcluster)).To(Succeed())
+			Expect(drcluster.Status.FenceState).To(Equal(ramen.ClusterFenceStateFenced))
+			Expect(drcluster.Status.FencePeerCluster).To(Equal(clusterNamePeer))
+			Expect(k8sClient.Delete(context.TODO(), drpolicy)).To(Succeed())
+		})
+	})
+	When(`a fenced drcluster is unfenced`, func() {
+		It(`should delete its network fence, and report its cluster unfenced`, func() {
+			drclusterUpdate(func(drcluster *ramen.DRCluster) {
+				drcluster.Spec.ClusterFence = ramen.ClusterFenceStateUnfenced
+			})
+			conditionStatusExpect(ramen.DRClusterFenced, metav1.ConditionFalse, controllers.DRClusterReasonUnfenced)
+			Eventually(networkFenceManifestWorkGet, 10, 0.25).ShouldNot(Succeed())
+			drcluster := &ramen.DRCluster{}
+			Expect(apiReader.Get(context.TODO(), types.NamespacedName{Name: clusterName}, drcluster)).To(Succeed())
+			Expect(drcluster.Status.FenceState).To(Equal(ramen.ClusterFenceStateUnfenced))
+			Expect(drcluster.Status.FencePeerCluster).To(BeEmpty())
+		})
+	})
+	When(`a fenced drcluster is deleted`, func() {
+		It(`should delete its network fence, and be gone`, func() {
+			drpolicy := &ramen.DRPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: `drpolicy-drcluster-delete`},
+				Spec: ramen.DRPolicySpec{
+					DRClusterSet: []ramen.ManagedCluster{
+						{Name: clusterName, S3ProfileName: s3ProfileNameConnectSucc},
+						{Name: clusterNamePeer, S3ProfileName: s3ProfileNameConnectSucc},
+					},
+					SchedulingInterval: schedulingInterval,
+				},
+			}
+			Expect(k8sClient.Create(context.TODO(), drpolicy)).To(Succeed())
+			drclusterUpdate(func(drcluster *ramen.DRCluster) {
+				drcluster.Spec.ClusterFence = ramen.ClusterFenceStateFenced
+			})
+			conditionStatusExpect(ramen.DRClusterFenced, metav1.ConditionTrue, controllers.DRClusterReasonFenced)
+			Expect(networkFenceManifestWorkGet()).To(Succeed())
+			Expect(k8sClient.Delete(context.TODO(), drpolicy)).To(Succeed())
+			drcluster := &ramen.DRCluster{ObjectMeta: metav1.ObjectMeta{Name: clusterName}}
+			Expect(k8sClient.Delete(context.TODO(), drcluster)).To(Succeed())
+			Eventually(func() error {
+				return apiReader.Get(context.TODO(), types.NamespacedName{Name: clusterName}, drcluster)
+			}, 10, 0.25).ShouldNot(Succeed())
+			Expect(networkFenceManifestWorkGet()).ToNot(Succeed())
+		})
+	})
+})
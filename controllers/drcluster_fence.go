@@ -0,0 +1,337 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	viewv1beta1 "github.com/open-cluster-management/multicloud-operators-foundation/pkg/apis/view/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers/util"
+)
+
+// A DRCluster with storage client CIDRs is fenced by a NetworkFence, created on
+// a peer cluster of a DRPolicy it is in, as the fenced cluster may be
+// unreachable.  The NetworkFence is created through a ManifestWork, and its
+// result is read through a ManagedClusterView.  It is updated to unfence the
+// cluster, and deleted, with the ManagedClusterView, once the cluster is
+// unfenced.
+
+const (
+	// DRClusterStorageDriverAnnotation is the CSI driver of the storage to fence
+	// the cluster from
+	DRClusterStorageDriverAnnotation = "drcluster.ramendr.openshift.io/storage-driver"
+
+	// DRClusterStorageSecretNameAnnotation is the name of the secret holding the
+	// credentials of the storage on the fence peer cluster
+	DRClusterStorageSecretNameAnnotation = "drcluster.ramendr.openshift.io/storage-secret-name"
+
+	// DRClusterStorageSecretNamespaceAnnotation is the namespace of the secret
+	// holding the credentials of the storage on the fence peer cluster
+	DRClusterStorageSecretNamespaceAnnotation = "drcluster.ramendr.openshift.io/storage-secret-namespace"
+
+	// DRClusterStorageClusterIDAnnotation optionally identifies the storage
+	// cluster to the CSI driver
+	DRClusterStorageClusterIDAnnotation = "drcluster.ramendr.openshift.io/storage-clusterid"
+)
+
+// Fenced condition reasons of a DRCluster
+const (
+	DRClusterReasonFenced               = `Fenced`
+	DRClusterReasonManuallyFenced       = `ManuallyFenced`
+	DRClusterReasonFencing              = `Fencing`
+	DRClusterReasonFenceFailed          = `FenceFailed`
+	DRClusterReasonFencePeerNotFound    = `FencePeerNotFound`
+	DRClusterReasonFenceParametersUnset = `FenceParametersUnset`
+	DRClusterReasonUnfencing            = `Unfencing`
+	DRClusterReasonUnfenced             = `Unfenced`
+)
+
+// drclusterFenceRequeueInterval is the interval at which the result of a
+// NetworkFence is checked, while a cluster is being fenced or unfenced
+const drclusterFenceRequeueInterval = 15 * time.Second
+
+// fenceReconcile fences or unfences the cluster, as its spec requests, and
+// reports the progress in its Fenced condition.  It returns true while the
+// fencing or unfencing is in progress.
+func (r *DRClusterReconciler) fenceReconcile(ctx context.Context, drcluster *ramen.DRCluster,
+	status *ramen.DRClusterStatus, log logr.Logger,
+) bool {
+	const inProgress = true
+
+	switch {
+	case drcluster.Spec.ClusterFence == ramen.ClusterFenceStateFenced && len(drcluster.Spec.CIDRs) == 0:
+		status.FenceState = ramen.ClusterFenceStateFenced
+		fenceConditionSet(status, drcluster.Generation, metav1.ConditionTrue, DRClusterReasonManuallyFenced,
+			`cluster is fenced by the administrator, as it has no CIDRs`)
+
+		return !inProgress
+	case drcluster.Spec.ClusterFence == ramen.ClusterFenceStateFenced:
+		return r.fence(ctx, drcluster, status, log)
+	case status.FencePeerCluster != ``:
+		return r.unfence(ctx, drcluster, status, log)
+	case status.FenceState == ramen.ClusterFenceStateFenced:
+		status.FenceState = ramen.ClusterFenceStateUnfenced
+		fenceConditionSet(status, drcluster.Generation, metav1.ConditionFalse, DRClusterReasonUnfenced,
+			`cluster is unfenced`)
+	}
+
+	return !inProgress
+}
+
+// fence creates or updates the NetworkFence of the cluster on its fence peer
+// cluster, and returns true until the NetworkFence reports the cluster fenced
+func (r *DRClusterReconciler) fence(ctx context.Context, drcluster *ramen.DRCluster,
+	status *ramen.DRClusterStatus, log logr.Logger,
+) bool {
+	const inProgress = true
+
+	peerCluster := status.FencePeerCluster
+	if peerCluster == `` {
+		var err error
+
+		if peerCluster, err = r.fencePeerClusterSelect(ctx, drcluster.Name); err != nil {
+			fenceConditionSet(status, drcluster.Generation, metav1.ConditionFalse, DRClusterReasonFencePeerNotFound,
+				err.Error())
+
+			return inProgress
+		}
+	}
+
+	reason, err := r.networkFenceApply(ctx, drcluster, peerCluster, util.NetworkFenceStateFenced, log)
+	if reason != DRClusterReasonFenceParametersUnset {
+		// the NetworkFence may be created, and is to be deleted once unfenced
+		status.FencePeerCluster = peerCluster
+	}
+
+	if err != nil {
+		fenceConditionSet(status, drcluster.Generation, metav1.ConditionFalse, reason, err.Error())
+
+		return inProgress
+	}
+
+	status.FenceState = ramen.ClusterFenceStateFenced
+	fenceConditionSet(status, drcluster.Generation, metav1.ConditionTrue, DRClusterReasonFenced,
+		fmt.Sprintf(`cluster is fenced by NetworkFence %s on cluster %s`,
+			util.NetworkFenceName(drcluster.Name), peerCluster))
+
+	return !inProgress
+}
+
+// unfence updates the NetworkFence of the cluster to unfence it, and deletes it
+// once the cluster is unfenced.  It returns true until then.
+func (r *DRClusterReconciler) unfence(ctx context.Context, drcluster *ramen.DRCluster,
+	status *ramen.DRClusterStatus, log logr.Logger,
+) bool {
+	const inProgress = true
+
+	peerCluster := status.FencePeerCluster
+
+	reason, err := r.networkFenceApply(ctx, drcluster, peerCluster, util.NetworkFenceStateUnfenced, log)
+	if err != nil {
+		if reason == DRClusterReasonFencing {
+			reason = DRClusterReasonUnfencing
+		}
+
+		fenceConditionSet(status, drcluster.Generation, metav1.ConditionTrue, reason, err.Error())
+
+		return inProgress
+	}
+
+	if err := r.networkFenceDelete(ctx, drcluster, peerCluster, log); err != nil {
+		fenceConditionSet(status, drcluster.Generation, metav1.ConditionTrue, DRClusterReasonUnfencing, err.Error())
+
+		return inProgress
+	}
+
+	status.FencePeerCluster = ``
+	status.FenceState = ramen.ClusterFenceStateUnfenced
+	fenceConditionSet(status, drcluster.Generation, metav1.ConditionFalse, DRClusterReasonUnfenced,
+		`cluster is unfenced`)
+
+	return !inProgress
+}
+
+// networkFenceApply creates or updates the NetworkFence of the cluster on the
+// peer cluster with the fence state, and returns an error, along with a
+// condition reason, until the NetworkFence reports the state applied
+func (r *DRClusterReconciler) networkFenceApply(ctx context.Context, drcluster *ramen.DRCluster,
+	peerCluster string, fenceState util.NetworkFenceState, log logr.Logger,
+) (string, error) {
+	spec, err := networkFenceSpec(drcluster, fenceState)
+	if err != nil {
+		return DRClusterReasonFenceParametersUnset, err
+	}
+
+	if err := r.mwUtil(ctx, drcluster, log).CreateOrUpdateNetworkFenceManifestWork(
+		drcluster.Name, peerCluster, spec); err != nil {
+		return DRClusterReasonFencing, fmt.Errorf("network fence manifest work create or update: %w", err)
+	}
+
+	networkFence, err := r.MCVGetter.GetNetworkFenceFromManagedCluster(drcluster.Name, peerCluster)
+	if err != nil {
+		return DRClusterReasonFencing, fmt.Errorf("network fence get from cluster %s: %w", peerCluster, err)
+	}
+
+	if networkFence.Spec.FenceState != fenceState {
+		return DRClusterReasonFencing, fmt.Errorf("network fence on cluster %s is not yet updated to %s",
+			peerCluster, fenceState)
+	}
+
+	switch networkFence.Status.Result {
+	case util.NetworkFenceResultSucceeded:
+		return ``, nil
+	case util.NetworkFenceResultFailed:
+		return DRClusterReasonFenceFailed, fmt.Errorf("network fence on cluster %s failed: %s",
+			peerCluster, networkFence.Status.Message)
+	default:
+		return DRClusterReasonFencing, fmt.Errorf("network fence on cluster %s is in progress", peerCluster)
+	}
+}
+
+// networkFenceDelete deletes the NetworkFence of the cluster from the peer
+// cluster, along with the ManagedClusterView that reads it
+func (r *DRClusterReconciler) networkFenceDelete(ctx context.Context, drcluster *ramen.DRCluster,
+	peerCluster string, log logr.Logger,
+) error {
+	if err := r.mwUtil(ctx, drcluster, log).DeleteNetworkFenceManifestWork(drcluster.Name, peerCluster); err != nil {
+		return err
+	}
+
+	mcv := &viewv1beta1.ManagedClusterView{ObjectMeta: metav1.ObjectMeta{
+		Name:      networkFenceViewName(drcluster.Name),
+		Namespace: peerCluster,
+	}}
+
+	if err := r.Client.Delete(ctx, mcv); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("managedclusterview %s/%s delete: %w", mcv.Namespace, mcv.Name, err)
+	}
+
+	return nil
+}
+
+// networkFenceViewName returns the name of the ManagedClusterView, in the
+// namespace of the fence peer cluster on the hub, of the NetworkFence of the
+// fenced cluster
+func networkFenceViewName(fencedCluster string) string {
+	return BuildManagedClusterViewName("network-fence", fencedCluster, util.MWTypeNF)
+}
+
+// networkFenceSpec returns the NetworkFence spec of the cluster, with the
+// storage parameters of its annotations
+func networkFenceSpec(drcluster *ramen.DRCluster, fenceState util.NetworkFenceState,
+) (util.NetworkFenceSpec, error) {
+	annotations := drcluster.GetAnnotations()
+
+	for _, key := range []string{
+		DRClusterStorageDriverAnnotation,
+		DRClusterStorageSecretNameAnnotation,
+		DRClusterStorageSecretNamespaceAnnotation,
+	} {
+		if annotations[key] == `` {
+			return util.NetworkFenceSpec{}, fmt.Errorf("annotation %s is not set", key)
+		}
+	}
+
+	spec := util.NetworkFenceSpec{
+		Driver:     annotations[DRClusterStorageDriverAnnotation],
+		FenceState: fenceState,
+		Cidrs:      drcluster.Spec.CIDRs,
+		Secret: util.NetworkFenceSecret{
+			Name:      annotations[DRClusterStorageSecretNameAnnotation],
+			Namespace: annotations[DRClusterStorageSecretNamespaceAnnotation],
+		},
+	}
+
+	if clusterID := annotations[DRClusterStorageClusterIDAnnotation]; clusterID != `` {
+		spec.Parameters = map[string]string{"clusterID": clusterID}
+	}
+
+	return spec, nil
+}
+
+// fencePeerClusterSelect returns an available cluster that shares a DRPolicy
+// with the cluster, to fence the cluster from
+func (r *DRClusterReconciler) fencePeerClusterSelect(ctx context.Context, clusterName string) (string, error) {
+	drpolicies := &ramen.DRPolicyList{}
+	if err := r.APIReader.List(ctx, drpolicies); err != nil {
+		return ``, fmt.Errorf("drpolicies list: %w", err)
+	}
+
+	for i := range drpolicies.Items {
+		drpolicy := &drpolicies.Items[i]
+		if !util.DrpolicyContainsCluster(drpolicy, clusterName) {
+			continue
+		}
+
+		for _, peerCluster := range util.DrpolicyClusterNames(drpolicy) {
+			if peerCluster == clusterName {
+				continue
+			}
+
+			if _, err := managedClusterAvailable(ctx, r.APIReader, peerCluster); err == nil {
+				return peerCluster, nil
+			}
+		}
+	}
+
+	return ``, fmt.Errorf("no available cluster shares a drpolicy with cluster %s", clusterName)
+}
+
+func (r *DRClusterReconciler) mwUtil(ctx context.Context, drcluster *ramen.DRCluster,
+	log logr.Logger,
+) *util.MWUtil {
+	return &util.MWUtil{Client: r.Client, Ctx: ctx, Log: log, InstName: drcluster.Name}
+}
+
+func fenceConditionSet(status *ramen.DRClusterStatus, generation int64, conditionStatus metav1.ConditionStatus,
+	reason, message string,
+) {
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               ramen.DRClusterFenced,
+		Status:             conditionStatus,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// drclusterFenced returns true if the fencing of the cluster, as requested by
+// the current spec of its DRCluster, is confirmed
+func drclusterFenced(drcluster *ramen.DRCluster) bool {
+	condition := meta.FindStatusCondition(drcluster.Status.Conditions, ramen.DRClusterFenced)
+
+	return drcluster.Spec.ClusterFence == ramen.ClusterFenceStateFenced &&
+		drcluster.Status.FenceState == ramen.ClusterFenceStateFenced &&
+		condition != nil && condition.Status == metav1.ConditionTrue &&
+		condition.ObservedGeneration == drcluster.Generation
+}
+
+// drclusterUnfenced returns true if the cluster is neither requested to be
+// fenced, nor still fenced
+func drclusterUnfenced(drcluster *ramen.DRCluster) bool {
+	return drcluster.Spec.ClusterFence != ramen.ClusterFenceStateFenced &&
+		drcluster.Status.FenceState != ramen.ClusterFenceStateFenced &&
+		drcluster.Status.FencePeerCluster == ``
+}
@@ -0,0 +1,607 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers/util"
+)
+
+// drClusterActionDefaultConcurrency is the concurrency of a DRClusterAction
+// whose concurrency is not set
+const drClusterActionDefaultConcurrency = 10
+
+// DRClusterActionReconciler reconciles a DRClusterAction object
+type DRClusterActionReconciler struct {
+	client.Client
+	APIReader     client.Reader
+	Log           logr.Logger
+	MCVGetter     ManagedClusterViewGetter
+	eventRecorder *util.EventReporter
+}
+
+//nolint:lll
+//+kubebuilder:rbac:groups=ramendr.openshift.io,resources=drclusteractions,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=ramendr.openshift.io,resources=drclusteractions/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=ramendr.openshift.io,resources=drclusteractions/finalizers,verbs=update
+// +kubebuilder:rbac:groups=ramendr.openshift.io,resources=drplacementcontrols,verbs=get;list;watch;update
+// +kubebuilder:rbac:groups=ramendr.openshift.io,resources=drpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ramendr.openshift.io,resources=drclusters,verbs=get;list;watch;update
+// +kubebuilder:rbac:groups=cluster.open-cluster-management.io,resources=managedclusters,verbs=get;list;watch
+// +kubebuilder:rbac:groups=view.open-cluster-management.io,resources=managedclusterviews,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile plans the action of a DRClusterAction on the DRPCs of its cluster
+// once, when it is created, and then requests the action from the DRPCs in
+// priority order, with at most its concurrency in progress at once, following
+// each in the action history of the DRPC to completion.  A relocation is
+// requested from a DRPC once its data resyncs to the recovered cluster, which
+// is rechecked periodically, and which first requires the cluster to be
+// unfenced if it was fenced for the failover.
+func (r *DRClusterActionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("name", req.NamespacedName.Name)
+	log.Info("reconcile enter")
+
+	defer log.Info("reconcile exit")
+
+	clusterAction := &ramen.DRClusterAction{}
+	if err := r.Client.Get(ctx, req.NamespacedName, clusterAction); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(fmt.Errorf("get: %w", err))
+	}
+
+	if !clusterAction.GetDeletionTimestamp().IsZero() || drClusterActionCompleted(clusterAction) {
+		return ctrl.Result{}, nil
+	}
+
+	status := clusterAction.Status.DeepCopy()
+
+	if clusterAction.Status.Phase == "" {
+		if err := r.plan(ctx, clusterAction, log); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if clusterAction.Status.Phase == ramen.DRClusterActionRunning {
+		r.progress(ctx, clusterAction)
+
+		if err := r.start(ctx, clusterAction, log); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		r.phaseSet(clusterAction)
+	}
+
+	result := ctrl.Result{}
+	if clusterAction.Status.Phase == ramen.DRClusterActionRunning && clusterAction.Status.Progress.Resyncing != 0 {
+		result.RequeueAfter = drClusterActionResyncInterval
+	}
+
+	if reflect.DeepEqual(status, &clusterAction.Status) {
+		return result, nil
+	}
+
+	if err := r.Client.Status().Update(ctx, clusterAction); err != nil {
+		return ctrl.Result{}, fmt.Errorf("status update: %w", err)
+	}
+
+	return result, nil
+}
+
+// plan selects the DRPCs of the cluster, and their target clusters, and orders
+// them by priority.  A DRPC without a cluster to fail over to is recorded as
+// failed.  A dry run completes once planned.
+func (r *DRClusterActionReconciler) plan(ctx context.Context, clusterAction *ramen.DRClusterAction,
+	log logr.Logger,
+) error {
+	drpcs, err := r.drpcs(ctx, clusterAction)
+	if err != nil {
+		return err
+	}
+
+	drpolicies := map[string]*ramen.DRPolicy{}
+	entries := make([]ramen.DRClusterActionDRPC, 0, len(drpcs))
+
+	for idx := range drpcs {
+		drpc := &drpcs[idx]
+
+		entry := ramen.DRClusterActionDRPC{
+			Name:      drpc.Name,
+			Namespace: drpc.Namespace,
+			Priority:  drpcPriority(drpc),
+			State:     ramen.DRClusterActionDRPCPending,
+		}
+
+		if clusterAction.Spec.DryRun {
+			entry.State = ramen.DRClusterActionDRPCPlanned
+		}
+
+		entry.TargetCluster, entry.Message, err = r.targetCluster(ctx, clusterAction, drpc, drpolicies)
+		if err != nil {
+			return err
+		}
+
+		if entry.TargetCluster == "" {
+			now := metav1.Now()
+			entry.State = ramen.DRClusterActionDRPCFailed
+			entry.CompletionTime = &now
+		}
+
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Priority != entries[j].Priority {
+			return entries[i].Priority > entries[j].Priority
+		}
+
+		if entries[i].Namespace != entries[j].Namespace {
+			return entries[i].Namespace < entries[j].Namespace
+		}
+
+		return entries[i].Name < entries[j].Name
+	})
+
+	log.Info("planned", "drpcs", len(entries), "dryRun", clusterAction.Spec.DryRun)
+
+	now := metav1.Now()
+	clusterAction.Status.DRPCs = entries
+	clusterAction.Status.StartTime = &now
+	clusterAction.Status.Phase = ramen.DRClusterActionRunning
+	clusterAction.Status.Progress = drClusterActionProgress(entries)
+
+	if clusterAction.Spec.DryRun {
+		clusterAction.Status.Phase = ramen.DRClusterActionSucceeded
+		clusterAction.Status.Message = fmt.Sprintf("dry run: %s of %d DRPlacementControls %s cluster %s planned",
+			clusterAction.Spec.Action, len(entries), drClusterActionDirection(clusterAction),
+			clusterAction.Spec.ClusterName)
+		clusterAction.Status.CompletionTime = &now
+	}
+
+	return nil
+}
+
+// drpcs returns the DRPCs of the action, of its DRPolicy if set: those placed
+// on its cluster for a failover, and those failed over from its cluster for a
+// relocation
+func (r *DRClusterActionReconciler) drpcs(ctx context.Context, clusterAction *ramen.DRClusterAction,
+) ([]ramen.DRPlacementControl, error) {
+	drpcs := []ramen.DRPlacementControl{}
+
+	if clusterAction.Spec.DRPolicyName != "" {
+		var err error
+
+		drpcs, err = drpolicyDRPCs(ctx, r.APIReader, clusterAction.Spec.DRPolicyName)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		drpcList := &ramen.DRPlacementControlList{}
+		if err := r.APIReader.List(ctx, drpcList); err != nil {
+			return nil, fmt.Errorf("drpcs list: %w", err)
+		}
+
+		drpcs = drpcList.Items
+	}
+
+	selected := []ramen.DRPlacementControl{}
+
+	for idx := range drpcs {
+		drpc := &drpcs[idx]
+
+		if (clusterAction.Spec.Action == ramen.ActionRelocate &&
+			drpcFailedOverFrom(drpc, clusterAction.Spec.ClusterName)) ||
+			(clusterAction.Spec.Action != ramen.ActionRelocate && drpcPlacedOn(drpc, clusterAction.Spec.ClusterName)) {
+			selected = append(selected, *drpc)
+		}
+	}
+
+	return selected, nil
+}
+
+// targetCluster returns the cluster to relocate the DRPC back to, which is
+// the cluster of the action, or the cluster to fail the DRPC over to, which is
+// the target cluster of the action if set, or otherwise the most preferred
+// available peer cluster of the DRPolicy of the DRPC.  If there is none, it
+// returns the reason instead.
+func (r *DRClusterActionReconciler) targetCluster(ctx context.Context, clusterAction *ramen.DRClusterAction,
+	drpc *ramen.DRPlacementControl, drpolicies map[string]*ramen.DRPolicy,
+) (string, string, error) {
+	if clusterAction.Spec.Action == ramen.ActionRelocate {
+		return clusterAction.Spec.ClusterName, "", nil
+	}
+
+	drpolicyName := drpc.Spec.DRPolicyRef.Name
+
+	drpolicy, ok := drpolicies[drpolicyName]
+	if !ok {
+		drpolicy = &ramen.DRPolicy{}
+		if err := r.APIReader.Get(ctx, types.NamespacedName{Name: drpolicyName}, drpolicy); err != nil {
+			if !errors.IsNotFound(err) {
+				return "", "", fmt.Errorf("drpolicy %s get: %w", drpolicyName, err)
+			}
+
+			drpolicy = nil
+		}
+
+		drpolicies[drpolicyName] = drpolicy
+	}
+
+	if drpolicy == nil {
+		return "", fmt.Sprintf("DRPolicy %s not found", drpolicyName), nil
+	}
+
+	if targetCluster := clusterAction.Spec.TargetCluster; targetCluster != "" {
+		if !clusterListContains(util.DrpolicyClusterNames(drpolicy), targetCluster) {
+			return "", fmt.Sprintf("cluster %s is not a cluster of DRPolicy %s", targetCluster, drpolicyName), nil
+		}
+
+		return targetCluster, "", nil
+	}
+
+	targetCluster, err := failoverTargetCluster(ctx, r.APIReader, drpolicy, clusterAction.Spec.ClusterName)
+	if err != nil || targetCluster != "" {
+		return targetCluster, "", err
+	}
+
+	return "", fmt.Sprintf("no peer cluster of DRPolicy %s is available", drpolicyName), nil
+}
+
+// progress follows the actions in progress in the action history of their
+// DRPCs, and records their outcome once they are no longer in progress
+func (r *DRClusterActionReconciler) progress(ctx context.Context, clusterAction *ramen.DRClusterAction) {
+	action := clusterAction.Spec.Action
+
+	for idx := range clusterAction.Status.DRPCs {
+		entry := &clusterAction.Status.DRPCs[idx]
+		if entry.State != ramen.DRClusterActionDRPCInProgress {
+			continue
+		}
+
+		drpc, err := r.drpcGet(ctx, entry)
+		if err != nil {
+			entry.Message = err.Error()
+
+			continue
+		}
+
+		switch {
+		case drpc == nil:
+			drClusterActionDRPCComplete(entry, ramen.DRClusterActionDRPCFailed, "DRPlacementControl not found")
+		case !drpcActionMatches(drpc, action, entry.TargetCluster):
+			drClusterActionDRPCComplete(entry, ramen.DRClusterActionDRPCFailed,
+				fmt.Sprintf("superseded by %s to cluster %s", drpc.Spec.Action, drpcActionTargetCluster(drpc)))
+		default:
+			drClusterActionDRPCProgress(entry, drpc, action)
+		}
+	}
+}
+
+// drClusterActionDRPCProgress records the progress of the action of a DRPC
+// from its action history, or its approval while it is yet to start
+func drClusterActionDRPCProgress(entry *ramen.DRClusterActionDRPC, drpc *ramen.DRPlacementControl,
+	action ramen.DRAction,
+) {
+	record := drpcActionRecord(drpc, action, entry.TargetCluster, *entry.StartTime)
+	if record == nil {
+		approval := drpcActionApproval(drpc, action, entry.TargetCluster, *entry.StartTime)
+
+		switch {
+		case approval == nil:
+			entry.Message = "waiting for the DRPlacementControl to start the action"
+		case approval.State == ramen.ActionApprovalRejected || approval.State == ramen.ActionApprovalExpired:
+			drClusterActionDRPCComplete(entry, ramen.DRClusterActionDRPCFailed, approval.Message)
+		case approval.State == ramen.ActionApprovalPending:
+			entry.Message = approval.Message
+		default:
+			entry.Message = "action approved, waiting for the DRPlacementControl to start it"
+		}
+
+		return
+	}
+
+	switch record.Outcome {
+	case ramen.ActionSucceeded:
+		drClusterActionDRPCComplete(entry, ramen.DRClusterActionDRPCSucceeded,
+			fmt.Sprintf("DRPlacementControl %s to cluster %s", drpc.Status.Phase, entry.TargetCluster))
+	case ramen.ActionCancelled:
+		drClusterActionDRPCComplete(entry, ramen.DRClusterActionDRPCFailed, "action cancelled")
+	case ramen.ActionFailed:
+		drClusterActionDRPCComplete(entry, ramen.DRClusterActionDRPCFailed, record.FailureReason)
+	default:
+		entry.Message = fmt.Sprintf("DRPlacementControl phase %s", drpc.Status.Phase)
+		if record.FailureReason != "" {
+			entry.Message += ": " + record.FailureReason
+		}
+	}
+}
+
+// start requests the action from the pending DRPCs in order, until the
+// concurrency of the action is reached.  A DRPC to be relocated is skipped
+// while its data resyncs, or while its cluster is yet to be unfenced.
+func (r *DRClusterActionReconciler) start(ctx context.Context, clusterAction *ramen.DRClusterAction,
+	log logr.Logger,
+) error {
+	concurrency := clusterAction.Spec.Concurrency
+	if concurrency <= 0 {
+		concurrency = drClusterActionDefaultConcurrency
+	}
+
+	unfencePending := ""
+
+	if clusterAction.Spec.Action == ramen.ActionRelocate {
+		var err error
+
+		unfencePending, err = r.clusterUnfence(ctx, clusterAction, log)
+		if err != nil {
+			return err
+		}
+	}
+
+	inProgress := drClusterActionProgress(clusterAction.Status.DRPCs).InProgress
+
+	for idx := range clusterAction.Status.DRPCs {
+		if inProgress >= concurrency {
+			return nil
+		}
+
+		entry := &clusterAction.Status.DRPCs[idx]
+		if entry.State != ramen.DRClusterActionDRPCPending && entry.State != ramen.DRClusterActionDRPCResyncing {
+			continue
+		}
+
+		if clusterAction.Spec.Action == ramen.ActionRelocate {
+			resynced, err := r.drpcResynced(ctx, entry, unfencePending)
+			if err != nil {
+				return err
+			}
+
+			if !resynced {
+				continue
+			}
+		}
+
+		started, err := r.drpcStart(ctx, clusterAction, entry, log)
+		if err != nil {
+			return err
+		}
+
+		if started {
+			inProgress++
+		}
+	}
+
+	return nil
+}
+
+// drpcStart requests the action from the DRPC, unless it has already
+// completed it, and returns true if the action is in progress
+func (r *DRClusterActionReconciler) drpcStart(ctx context.Context, clusterAction *ramen.DRClusterAction,
+	entry *ramen.DRClusterActionDRPC, log logr.Logger,
+) (bool, error) {
+	action := clusterAction.Spec.Action
+
+	drpc, err := r.drpcGet(ctx, entry)
+	if err != nil {
+		return false, err
+	}
+
+	if drpc == nil {
+		drClusterActionDRPCComplete(entry, ramen.DRClusterActionDRPCFailed, "DRPlacementControl not found")
+
+		return false, nil
+	}
+
+	if drpcActionMatches(drpc, action, entry.TargetCluster) && drpcActionFinalPhase(drpc) {
+		drClusterActionDRPCComplete(entry, ramen.DRClusterActionDRPCSucceeded,
+			fmt.Sprintf("DRPlacementControl is already %s to cluster %s", drpc.Status.Phase, entry.TargetCluster))
+
+		return false, nil
+	}
+
+	drpcActionSet(drpc, action, entry.TargetCluster)
+
+	if err := r.Client.Update(ctx, drpc); err != nil {
+		return false, fmt.Errorf("drpc %s update: %w", drpcNamespacedName(drpc), err)
+	}
+
+	msg := fmt.Sprintf("%s of DRPlacementControl %s to cluster %s requested by DRClusterAction %s",
+		action, drpcNamespacedName(drpc), entry.TargetCluster, clusterAction.Name)
+	log.Info(msg)
+	util.ReportIfNotPresent(r.eventRecorder, drpc, corev1.EventTypeNormal, util.EventReasonClusterActionStarted, msg)
+
+	now := metav1.Now()
+	entry.State = ramen.DRClusterActionDRPCInProgress
+	entry.StartTime = &now
+	entry.Message = "action requested from the DRPlacementControl"
+
+	return true, nil
+}
+
+// phaseSet records the progress of the action, and its outcome once it has
+// completed for all its DRPCs
+func (r *DRClusterActionReconciler) phaseSet(clusterAction *ramen.DRClusterAction) {
+	progress := drClusterActionProgress(clusterAction.Status.DRPCs)
+	clusterAction.Status.Progress = progress
+
+	if progress.Pending != 0 || progress.Resyncing != 0 || progress.InProgress != 0 {
+		clusterAction.Status.Message = fmt.Sprintf("%d of %d DRPlacementControls completed, %d in progress",
+			progress.Succeeded+progress.Failed, progress.Total, progress.InProgress)
+		if progress.Resyncing != 0 {
+			clusterAction.Status.Message += fmt.Sprintf(", %d resyncing", progress.Resyncing)
+		}
+
+		return
+	}
+
+	now := metav1.Now()
+	clusterAction.Status.CompletionTime = &now
+
+	eventType, reason := corev1.EventTypeNormal, util.EventReasonClusterActionSucceeded
+	clusterAction.Status.Phase = ramen.DRClusterActionSucceeded
+	clusterAction.Status.Message = fmt.Sprintf("%s of %d DRPlacementControls %s cluster %s succeeded",
+		clusterAction.Spec.Action, progress.Total, drClusterActionDirection(clusterAction),
+		clusterAction.Spec.ClusterName)
+
+	if progress.Failed != 0 {
+		eventType, reason = corev1.EventTypeWarning, util.EventReasonClusterActionFailed
+		clusterAction.Status.Phase = ramen.DRClusterActionFailed
+		clusterAction.Status.Message = fmt.Sprintf("%s of %d of %d DRPlacementControls %s cluster %s failed",
+			clusterAction.Spec.Action, progress.Failed, progress.Total, drClusterActionDirection(clusterAction),
+			clusterAction.Spec.ClusterName)
+	}
+
+	util.ReportIfNotPresent(r.eventRecorder, clusterAction, eventType, reason, clusterAction.Status.Message)
+}
+
+// drpcGet returns the DRPC of the entry, or nil if it is not found
+func (r *DRClusterActionReconciler) drpcGet(ctx context.Context, entry *ramen.DRClusterActionDRPC,
+) (*ramen.DRPlacementControl, error) {
+	drpc := &ramen.DRPlacementControl{}
+	if err := r.APIReader.Get(ctx, types.NamespacedName{Name: entry.Name, Namespace: entry.Namespace},
+		drpc); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("drpc %s/%s get: %w", entry.Namespace, entry.Name, err)
+	}
+
+	return drpc, nil
+}
+
+// drClusterActionCompleted returns true if the action succeeded or failed
+func drClusterActionCompleted(clusterAction *ramen.DRClusterAction) bool {
+	return clusterAction.Status.Phase == ramen.DRClusterActionSucceeded ||
+		clusterAction.Status.Phase == ramen.DRClusterActionFailed
+}
+
+// drClusterActionDirection returns the direction of the action relative to its
+// cluster, for messages
+func drClusterActionDirection(clusterAction *ramen.DRClusterAction) string {
+	if clusterAction.Spec.Action == ramen.ActionRelocate {
+		return "back to"
+	}
+
+	return "from"
+}
+
+// drClusterActionDRPCComplete records the outcome of the action of a DRPC
+func drClusterActionDRPCComplete(entry *ramen.DRClusterActionDRPC, state ramen.DRClusterActionDRPCState,
+	msg string,
+) {
+	now := metav1.Now()
+	entry.State = state
+	entry.Message = msg
+	entry.CompletionTime = &now
+}
+
+// drClusterActionProgress counts the DRPCs by the state of their action.
+// Planned DRPCs are counted as pending.
+func drClusterActionProgress(entries []ramen.DRClusterActionDRPC) ramen.DRClusterActionProgress {
+	progress := ramen.DRClusterActionProgress{Total: len(entries)}
+
+	for idx := range entries {
+		switch entries[idx].State {
+		case ramen.DRClusterActionDRPCPlanned, ramen.DRClusterActionDRPCPending:
+			progress.Pending++
+		case ramen.DRClusterActionDRPCResyncing:
+			progress.Resyncing++
+		case ramen.DRClusterActionDRPCInProgress:
+			progress.InProgress++
+		case ramen.DRClusterActionDRPCSucceeded:
+			progress.Succeeded++
+		case ramen.DRClusterActionDRPCFailed:
+			progress.Failed++
+		}
+	}
+
+	return progress
+}
+
+// drpcPriority returns the priority of the DRPC from its priority annotation,
+// or 0 if it is not set, or not an integer
+func drpcPriority(drpc *ramen.DRPlacementControl) int {
+	priority, err := strconv.Atoi(drpc.GetAnnotations()[ramen.DRPCPriorityAnnotation])
+	if err != nil {
+		return 0
+	}
+
+	return priority
+}
+
+// drpcClusterActionsMapFunc maps a DRPC to the running DRClusterActions that
+// include it
+func (r *DRClusterActionReconciler) drpcClusterActionsMapFunc(obj client.Object) []reconcile.Request {
+	clusterActions := &ramen.DRClusterActionList{}
+	if err := r.Client.List(context.TODO(), clusterActions); err != nil {
+		r.Log.Error(err, "drclusteractions list")
+
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+
+	for idx := range clusterActions.Items {
+		clusterAction := &clusterActions.Items[idx]
+		if clusterAction.Status.Phase != ramen.DRClusterActionRunning {
+			continue
+		}
+
+		for _, entry := range clusterAction.Status.DRPCs {
+			if entry.Name == obj.GetName() && entry.Namespace == obj.GetNamespace() {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{Name: clusterAction.Name},
+				})
+
+				break
+			}
+		}
+	}
+
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.  Actions are
+// reconciled on changes to their DRPCs, including their status.
+func (r *DRClusterActionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.eventRecorder = util.NewEventReporter(mgr.GetEventRecorderFor("controller_DRClusterAction"))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ramen.DRClusterAction{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &ramen.DRPlacementControl{}},
+			handler.EnqueueRequestsFromMapFunc(r.drpcClusterActionsMapFunc)).
+		Complete(r)
+}
@@ -0,0 +1,153 @@
+package controllers_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("DRClusterActionController", func() {
+	When(`a dry run names a cluster that hosts no DRPlacementControl`, func() {
+		It(`should succeed with nothing planned`, func() {
+			clusterAction := &ramen.DRClusterAction{
+				ObjectMeta: metav1.ObjectMeta{Name: `drclusteraction-dryrun`},
+				Spec: ramen.DRClusterActionSpec{
+					ClusterName: `cluster-nodrpcs`,
+					Action:      ramen.ActionFailover,
+					DryRun:      true,
+				},
+			}
+			Expect(k8sClient.Create(context.TODO(), clusterAction)).To(Succeed())
+			Eventually(func(g Gomega) {
+				g.Expect(apiReader.Get(context.TODO(), types.NamespacedName{Name: clusterAction.Name},
+					clusterAction)).To(Succeed())
+				g.Expect(clusterAction.Status.Phase).To(Equal(ramen.DRClusterActionSucceeded))
+				g.Expect(clusterAction.Status.Progress.Total).To(BeZero())
+				g.Expect(clusterAction.Status.CompletionTime).ToNot(BeNil())
+			}, 10, 0.25).Should(Succeed())
+			Expect(k8sClient.Delete(context.TODO(), clusterAction)).To(Succeed())
+		})
+	})
+	When(`a relocation names a cluster from which no DRPlacementControl failed over`, func() {
+		It(`should succeed with nothing to relocate`, func() {
+			clusterAction := &ramen.DRClusterAction{
+				ObjectMeta: metav1.ObjectMeta{Name: `drclusteraction-relocate`},
+				Spec: ramen.DRClusterActionSpec{
+					ClusterName: `cluster-nodrpcs`,
+					Action:      ramen.ActionRelocate,
+				},
+			}
+			Expect(k8sClient.Create(context.TODO(), clusterAction)).To(Succeed())
+			Eventually(func(g Gomega) {
+				g.Expect(apiReader.Get(context.TODO(), types.NamespacedName{Name: clusterAction.Name},
+					clusterAction)).To(Succeed())
+				g.Expect(clusterAction.Status.Phase).To(Equal(ramen.DRClusterActionSucceeded))
+				g.Expect(clusterAction.Status.Progress.Total).To(BeZero())
+				g.Expect(clusterAction.Status.Progress.Resyncing).To(BeZero())
+			}, 10, 0.25).Should(Succeed())
+			Expect(k8sClient.Delete(context.TODO(), clusterAction)).To(Succeed())
+		})
+	})
+	When(`a failover names a cluster that hosts DRPlacementControls of different priorities`, func() {
+		drpolicy := &ramen.DRPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: `drclusteraction-drpolicy`},
+			Spec: ramen.DRPolicySpec{
+				DRClusterSet: []ramen.ManagedCluster{
+					{Name: `drclusteraction-east`, S3ProfileName: s3ProfileNameConnectSucc},
+					{Name: `drclusteraction-west`, S3ProfileName: s3ProfileNameConnectSucc},
+				},
+				SchedulingInterval: `1m`,
+			},
+		}
+		drpcs := map[string]*ramen.DRPlacementControl{}
+		drpcNames := []string{`drclusteraction-drpc-high`, `drclusteraction-drpc-mid`, `drclusteraction-drpc-low`}
+		clusterAction := &ramen.DRClusterAction{
+			ObjectMeta: metav1.ObjectMeta{Name: `drclusteraction-failover`},
+			Spec: ramen.DRClusterActionSpec{
+				ClusterName:   `drclusteraction-east`,
+				Action:        ramen.ActionFailover,
+				TargetCluster: `drclusteraction-west`,
+				DRPolicyName:  drpolicy.Name,
+				Concurrency:   1,
+			},
+		}
+		clusterActionExpect := func(phase ramen.DRClusterActionPhase, states ...ramen.DRClusterActionDRPCState) {
+			Eventually(func(g Gomega) {
+				g.Expect(apiReader.Get(context.TODO(), types.NamespacedName{Name: clusterAction.Name},
+					clusterAction)).To(Succeed())
+				g.Expect(clusterAction.Status.Phase).To(Equal(phase))
+				g.Expect(clusterAction.Status.DRPCs).To(HaveLen(len(states)))
+				for idx, entry := range clusterAction.Status.DRPCs {
+					g.Expect(entry.Name).To(Equal(drpcNames[idx]))
+					g.Expect(entry.State).To(Equal(states[idx]))
+				}
+			}, 10, 0.25).Should(Succeed())
+		}
+		drpcFailoverExpect := func(name string, requested bool) {
+			Eventually(func(g Gomega) {
+				drpc := actionDRPCGet(drpcs[name])
+				if !requested {
+					g.Expect(drpc.Spec.Action).To(BeEmpty())
+
+					return
+				}
+				g.Expect(drpc.Spec.Action).To(Equal(ramen.ActionFailover))
+				g.Expect(drpc.Spec.FailoverCluster).To(Equal(clusterAction.Spec.TargetCluster))
+			}, 10, 0.25).Should(Succeed())
+		}
+		Specify(`a DRPolicy, and DRPlacementControls of it on its first cluster`, func() {
+			Expect(k8sClient.Create(context.TODO(), drpolicy)).To(Succeed())
+			drpcs[`drclusteraction-drpc-low`] = actionDRPCCreate(`drclusteraction-drpc-low`, drpolicy.Name,
+				clusterAction.Spec.ClusterName, nil)
+			drpcs[`drclusteraction-drpc-high`] = actionDRPCCreate(`drclusteraction-drpc-high`, drpolicy.Name,
+				clusterAction.Spec.ClusterName, map[string]string{ramen.DRPCPriorityAnnotation: `10`})
+			drpcs[`drclusteraction-drpc-mid`] = actionDRPCCreate(`drclusteraction-drpc-mid`, drpolicy.Name,
+				clusterAction.Spec.ClusterName, map[string]string{ramen.DRPCPriorityAnnotation: `5`})
+		})
+		It(`should request the failover of the DRPlacementControl of the highest priority alone`, func() {
+			Expect(k8sClient.Create(context.TODO(), clusterAction)).To(Succeed())
+			clusterActionExpect(ramen.DRClusterActionRunning, ramen.DRClusterActionDRPCInProgress,
+				ramen.DRClusterActionDRPCPending, ramen.DRClusterActionDRPCPending)
+			Expect(clusterAction.Status.Progress.InProgress).To(Equal(1))
+			drpcFailoverExpect(`drclusteraction-drpc-high`, true)
+			Consistently(func() ramen.DRAction {
+				return actionDRPCGet(drpcs[`drclusteraction-drpc-mid`]).Spec.Action
+			}, 2, 0.25).Should(BeEmpty())
+			drpcFailoverExpect(`drclusteraction-drpc-low`, false)
+		})
+		It(`should request the failover of the next DRPlacementControl once the first succeeds`, func() {
+			actionDRPCActionRecord(drpcs[`drclusteraction-drpc-high`], ramen.FailedOver, ramen.ActionSucceeded, ``)
+			clusterActionExpect(ramen.DRClusterActionRunning, ramen.DRClusterActionDRPCSucceeded,
+				ramen.DRClusterActionDRPCInProgress, ramen.DRClusterActionDRPCPending)
+			drpcFailoverExpect(`drclusteraction-drpc-mid`, true)
+			drpcFailoverExpect(`drclusteraction-drpc-low`, false)
+		})
+		It(`should request the failover of the last DRPlacementControl once the second fails`, func() {
+			actionDRPCActionRecord(drpcs[`drclusteraction-drpc-mid`], ramen.FailingOver, ramen.ActionFailed,
+				`failover failed`)
+			clusterActionExpect(ramen.DRClusterActionRunning, ramen.DRClusterActionDRPCSucceeded,
+				ramen.DRClusterActionDRPCFailed, ramen.DRClusterActionDRPCInProgress)
+			Expect(clusterAction.Status.DRPCs[1].Message).To(Equal(`failover failed`))
+			drpcFailoverExpect(`drclusteraction-drpc-low`, true)
+		})
+		It(`should fail once the last DRPlacementControl completes, as one failed`, func() {
+			actionDRPCActionRecord(drpcs[`drclusteraction-drpc-low`], ramen.FailedOver, ramen.ActionSucceeded, ``)
+			clusterActionExpect(ramen.DRClusterActionFailed, ramen.DRClusterActionDRPCSucceeded,
+				ramen.DRClusterActionDRPCFailed, ramen.DRClusterActionDRPCSucceeded)
+			Expect(clusterAction.Status.Message).To(Equal(
+				`Failover of 1 of 3 DRPlacementControls from cluster drclusteraction-east failed`))
+			Expect(clusterAction.Status.CompletionTime).ToNot(BeNil())
+		})
+		Specify(`cleanup`, func() {
+			Expect(k8sClient.Delete(context.TODO(), clusterAction)).To(Succeed())
+			for _, drpc := range drpcs {
+				Expect(k8sClient.Delete(context.TODO(), drpc)).To(Succeed())
+			}
+			Expect(k8sClient.Delete(context.TODO(), drpolicy)).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,186 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers/util"
+)
+
+// drClusterActionResyncInterval is the interval at which a relocation
+// rechecks whether the data of its resyncing DRPCs has resynced
+const drClusterActionResyncInterval = 30 * time.Second
+
+// drpcFailedOverFrom returns true if the DRPC has failed over from the
+// cluster, which it prefers, to another cluster
+func drpcFailedOverFrom(drpc *ramen.DRPlacementControl, clusterName string) bool {
+	return drpc.GetDeletionTimestamp().IsZero() &&
+		drpc.Status.Phase == ramen.FailedOver &&
+		drpcFailingOverFrom(drpc, clusterName)
+}
+
+// clusterUnfence requests the unfencing of the cluster of the relocation if it
+// is fenced, as the data of the DRPCs cannot resync to it until it is, once
+// the VRG of every DRPC failed over from it is secondary on it, or deleted, as
+// a DRPC does before unfencing it.  It returns why the cluster may not yet be
+// unfenced, or an empty string once its unfencing is requested, or if it is
+// not fenced.
+func (r *DRClusterActionReconciler) clusterUnfence(ctx context.Context, clusterAction *ramen.DRClusterAction,
+	log logr.Logger,
+) (string, error) {
+	clusterName := clusterAction.Spec.ClusterName
+
+	drcluster, err := drclusterGet(ctx, r.APIReader, clusterName)
+	if err != nil || drcluster == nil || drcluster.Spec.ClusterFence != ramen.ClusterFenceStateFenced {
+		return "", err
+	}
+
+	reason, err := clusterUnfencePending(ctx, r.APIReader, r.MCVGetter, clusterName,
+		func(drpc *ramen.DRPlacementControl) bool {
+			return drpcFailingOverFrom(drpc, clusterName)
+		})
+	if err != nil {
+		return "", err
+	}
+
+	if reason != "" {
+		return fmt.Sprintf("waiting for cluster %s to be unfenced, as %s", clusterName, reason), nil
+	}
+
+	drcluster.Spec.ClusterFence = ramen.ClusterFenceStateUnfenced
+	if err := r.Client.Update(ctx, drcluster); err != nil {
+		return "", fmt.Errorf("drcluster %s unfence: %w", clusterName, err)
+	}
+
+	msg := fmt.Sprintf("Unfencing cluster %s to relocate the DRPlacementControls failed over from it back to it",
+		clusterName)
+	log.Info(msg)
+	util.ReportIfNotPresent(r.eventRecorder, clusterAction, corev1.EventTypeNormal, util.EventReasonUnfencing, msg)
+
+	return "", nil
+}
+
+// drpcResynced returns true if the DRPC of the entry may be relocated back to
+// the target cluster of the entry, because its data has resynced to the
+// cluster, or because it need not be relocated.  Otherwise, it records the
+// entry as resyncing, with the reason, which is why the cluster may not yet be
+// unfenced if it is pending.  The final sync of the data is ensured by the
+// relocation of the DRPC itself.
+func (r *DRClusterActionReconciler) drpcResynced(ctx context.Context, entry *ramen.DRClusterActionDRPC,
+	unfencePending string,
+) (bool, error) {
+	drpc, err := r.drpcGet(ctx, entry)
+	if err != nil {
+		return false, err
+	}
+
+	if drpc == nil ||
+		(drpcActionMatches(drpc, ramen.ActionRelocate, entry.TargetCluster) && drpcActionFinalPhase(drpc)) {
+		return true, nil
+	}
+
+	reason := unfencePending
+	if reason == "" {
+		reason, err = r.drpcResyncPending(ctx, drpc, entry.TargetCluster)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if reason == "" {
+		return true, nil
+	}
+
+	entry.State = ramen.DRClusterActionDRPCResyncing
+	entry.Message = reason
+
+	return false, nil
+}
+
+// drpcResyncPending returns why the data of the DRPC has not yet resynced to
+// the cluster, or an empty string if it has
+func (r *DRClusterActionReconciler) drpcResyncPending(ctx context.Context, drpc *ramen.DRPlacementControl,
+	clusterName string,
+) (string, error) {
+	drcluster, err := drclusterGet(ctx, r.APIReader, clusterName)
+	if err != nil {
+		return "", err
+	}
+
+	if drcluster == nil {
+		return fmt.Sprintf("DRCluster %s not found", clusterName), nil
+	}
+
+	if reason := drclusterUnavailable(drcluster); reason != "" {
+		return fmt.Sprintf("DRCluster %s is unavailable: %s", clusterName, reason), nil
+	}
+
+	if _, err := managedClusterAvailable(ctx, r.APIReader, clusterName); err != nil {
+		return err.Error(), nil
+	}
+
+	condition := meta.FindStatusCondition(drpc.Status.Conditions, ramen.ConditionPeerReady)
+	if condition == nil || condition.Status != metav1.ConditionTrue ||
+		condition.ObservedGeneration != drpc.Generation {
+		return "waiting for the DRPlacementControl to be ready for a relocation", nil
+	}
+
+	vrg, err := r.MCVGetter.GetVRGFromManagedCluster(drpc.Name, drpc.Namespace, clusterName)
+	if err != nil {
+		return fmt.Sprintf("VolumeReplicationGroup on cluster %s not yet available: %v", clusterName, err), nil
+	}
+
+	return vrgResyncPending(vrg, clusterName), nil
+}
+
+// vrgResyncPending returns why the data of the secondary VRG on the cluster
+// has not yet resynced, or an empty string if it has, which its DataProtected
+// condition reports as true, as for the relocation itself
+func vrgResyncPending(vrg *ramen.VolumeReplicationGroup, clusterName string) string {
+	if vrg.Status.State != ramen.SecondaryState {
+		return fmt.Sprintf("waiting for the VolumeReplicationGroup on cluster %s to become secondary", clusterName)
+	}
+
+	if meta.IsStatusConditionTrue(vrg.Status.Conditions, VRGConditionTypeResyncRequired) {
+		return fmt.Sprintf("VolumeReplicationGroup on cluster %s requires a resync", clusterName)
+	}
+
+	condition := meta.FindStatusCondition(vrg.Status.Conditions, VRGConditionTypeDataProtected)
+	if condition == nil {
+		return fmt.Sprintf("waiting for the data of the VolumeReplicationGroup on cluster %s to resync", clusterName)
+	}
+
+	if condition.Reason == VRGConditionReasonError || condition.Reason == VRGConditionReasonErrorUnknown {
+		return fmt.Sprintf("data of the VolumeReplicationGroup on cluster %s failed to resync: %s",
+			clusterName, condition.Message)
+	}
+
+	if condition.Status != metav1.ConditionTrue {
+		return fmt.Sprintf("waiting for the data of the VolumeReplicationGroup on cluster %s to resync", clusterName)
+	}
+
+	return ""
+}
@@ -35,6 +35,7 @@ import (
 	rmnutil "github.com/ramendr/ramen/controllers/util"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
 
@@ -57,7 +58,22 @@ func (d *DRPCInstance) startProcessing() bool {
 	d.log.Info("Starting to process placement")
 
 	requeue := true
-	done, processingErr := d.processPlacement()
+
+	var peerReadyBefore *metav1.Condition
+	if condition := findCondition(d.instance.Status.Conditions, rmn.ConditionPeerReady); condition != nil {
+		peerReadyBefore = condition.DeepCopy()
+	}
+
+	approved, done, processingErr := d.processActionApproval()
+	if approved {
+		done, processingErr = d.processPlacement()
+	}
+
+	if processingErr != nil {
+		d.actionRecordError(processingErr)
+	}
+
+	d.reportDRPolicyHealth(peerReadyBefore)
 
 	if d.shouldUpdateStatus() || d.statusUpdateTimeElapsed() {
 		if err := d.reconciler.updateDRPCStatus(d.instance, d.userPlacementRule); err != nil {
@@ -82,6 +98,26 @@ func (d *DRPCInstance) startProcessing() bool {
 func (d *DRPCInstance) processPlacement() (bool, error) {
 	d.log.Info("Process DRPC Placement", "DRAction", d.instance.Spec.Action)
 
+	if resolving, err := d.processSplitBrain(); resolving || err != nil {
+		return false, err
+	}
+
+	if d.instance.Spec.Action == rmn.ActionFailover || d.instance.Spec.Action == rmn.ActionRelocate {
+		if err := d.ensureVRGsNotPaused(); err != nil {
+			return false, err
+		}
+
+		if d.instance.Spec.CancelAction {
+			if handled, done, err := d.processCancel(); handled {
+				return done, err
+			}
+		}
+
+		if err := d.ensureTargetClusterAvailable(); err != nil {
+			return false, err
+		}
+	}
+
 	switch d.instance.Spec.Action {
 	case rmn.ActionFailover:
 		return d.RunFailover()
@@ -136,6 +172,17 @@ func (d *DRPCInstance) RunInitialDeployment() (bool, error) {
 		return done, nil
 	}
 
+	if !deployed {
+		if err := d.validateClusterInventory(homeCluster); err != nil {
+			d.setDRPCCondition(&d.instance.Status.Conditions, rmn.ConditionAvailable, d.instance.Generation,
+				d.getConditionStatusForTypeAvailable(), string(d.instance.Status.Phase), err.Error())
+			rmnutil.ReportIfNotPresent(d.reconciler.eventRecorder, d.instance, corev1.EventTypeWarning,
+				rmnutil.EventReasonDeployFail, err.Error())
+
+			return !done, err
+		}
+	}
+
 	result, err := d.startDeploying(homeCluster, homeClusterNamespace)
 	if err != nil {
 		d.setDRPCCondition(&d.instance.Status.Conditions, rmn.ConditionAvailable, d.instance.Generation,
@@ -311,6 +358,8 @@ func (d *DRPCInstance) RunFailover() (bool, error) {
 			return !done, err
 		}
 
+		d.actionRecordCleanupComplete()
+
 		return done, nil
 	}
 
@@ -319,6 +368,8 @@ func (d *DRPCInstance) RunFailover() (bool, error) {
 
 func (d *DRPCInstance) switchToFailoverCluster() (bool, error) {
 	const done = true
+	d.actionOriginRecord(d.instance.Spec.FailoverCluster)
+
 	// Make sure we record the state that we are failing over
 	d.setDRState(rmn.FailingOver)
 	d.setMetricsTimerFromDRState(rmn.FailingOver)
@@ -346,6 +397,12 @@ func (d *DRPCInstance) switchToFailoverCluster() (bool, error) {
 
 	newHomeCluster := d.instance.Spec.FailoverCluster
 
+	if curHomeCluster != newHomeCluster {
+		if err := d.ensureFailedClusterFenced(curHomeCluster); err != nil {
+			return !done, err
+		}
+	}
+
 	// Flip the ReplicationState for the current home cluster to secondary if
 	// we have not done so. IF current home cluster and the new home cluster are the same,
 	// then we are far along in processing the failover
@@ -450,6 +507,8 @@ func (d *DRPCInstance) RunRelocate() (bool, error) {
 			return !done, err
 		}
 
+		d.actionRecordCleanupComplete()
+
 		return done, nil
 	}
 
@@ -465,6 +524,261 @@ func (d *DRPCInstance) RunRelocate() (bool, error) {
 	return d.relocate(preferredCluster, preferredClusterNamespace, rmn.Relocating)
 }
 
+// ensureVRGsNotPaused returns an error if any of the VRGs is paused on its
+// managed cluster, as a failover or a relocation cannot progress while any of
+// the VRGs does not act on changes to its spec.  An action that has already
+// switched over, and is no longer progressing, is not held back.
+func (d *DRPCInstance) ensureVRGsNotPaused() error {
+	if !d.isInProgressingPhase() && d.hasAlreadySwitchedOver(drpcActionTargetCluster(d.instance)) {
+		return nil
+	}
+
+	for clusterName, vrg := range d.vrgs {
+		condition := findCondition(vrg.Status.Conditions, VRGConditionTypePaused)
+		if condition == nil || condition.Status != metav1.ConditionTrue {
+			continue
+		}
+
+		msg := fmt.Sprintf("%s refused, as VRG on cluster %s is paused (%s). Resume the VRG to proceed",
+			d.instance.Spec.Action, clusterName, condition.Message)
+		d.setDRPCCondition(&d.instance.Status.Conditions, rmn.ConditionAvailable, d.instance.Generation,
+			d.getConditionStatusForTypeAvailable(), string(d.instance.Status.Phase), msg)
+		rmnutil.ReportIfNotPresent(d.reconciler.eventRecorder, d.instance, corev1.EventTypeWarning,
+			rmnutil.EventReasonActionRefused, msg)
+
+		return fmt.Errorf(msg)
+	}
+
+	return nil
+}
+
+// validateClusterInventory returns an error if the inventory of the cluster
+// lacks a VolumeReplicationClass that the VRG would select, or a StorageClass
+// of its provisioner, as the VRG would otherwise fail to protect its PVCs
+func (d *DRPCInstance) validateClusterInventory(clusterName string) error {
+	inventory, err := clusterInventoryGet(d.ctx, d.reconciler.APIReader, d.reconciler.MCVGetter, clusterName)
+	if err != nil {
+		return fmt.Errorf("inventory of cluster %s unavailable: %w", clusterName, err)
+	}
+
+	if _, err := validateClusterInventory(d.drPolicy, inventory); err != nil {
+		return fmt.Errorf("cluster %s: %w", clusterName, err)
+	}
+
+	return nil
+}
+
+// ensureTargetClusterAvailable returns an error if the DRCluster of the cluster
+// to fail over or relocate to reports it as unavailable or fenced, unless the
+// action is already complete.  A fenced cluster to relocate to, as it fails
+// back, is unfenced first, once no DRPC failed over from it has a primary VRG
+// on it.
+func (d *DRPCInstance) ensureTargetClusterAvailable() error {
+	targetCluster := d.instance.Spec.FailoverCluster
+	if d.instance.Spec.Action == rmn.ActionRelocate {
+		targetCluster = d.instance.Spec.PreferredCluster
+	}
+
+	if targetCluster == "" || d.hasAlreadySwitchedOver(targetCluster) {
+		return nil
+	}
+
+	drcluster, err := drclusterGet(d.ctx, d.reconciler.APIReader, targetCluster)
+	if err != nil {
+		return err
+	}
+
+	if drcluster == nil {
+		return nil
+	}
+
+	if d.instance.Spec.Action == rmn.ActionRelocate {
+		if err := d.ensureClusterUnfenced(drcluster, false); err != nil {
+			return err
+		}
+	}
+
+	reason := drclusterUnavailable(drcluster)
+	if reason == "" {
+		return nil
+	}
+
+	msg := fmt.Sprintf("%s refused, as DRCluster %s is not available (%s)",
+		d.instance.Spec.Action, targetCluster, reason)
+	d.setDRPCCondition(&d.instance.Status.Conditions, rmn.ConditionAvailable, d.instance.Generation,
+		d.getConditionStatusForTypeAvailable(), string(d.instance.Status.Phase), msg)
+	rmnutil.ReportIfNotPresent(d.reconciler.eventRecorder, d.instance, corev1.EventTypeWarning,
+		rmnutil.EventReasonActionRefused, msg)
+
+	return fmt.Errorf(msg)
+}
+
+// ensureFailedClusterFenced returns an error until the cluster to fail over
+// from is fenced, if its DRCluster has storage client CIDRs to fence, or is
+// requested to be fenced.  In Sync replication mode, the cluster is required to
+// be fenced, as it could otherwise still write to the volumes stretched across
+// the clusters.  Fencing is skipped if the DRPC is annotated to skip it.
+func (d *DRPCInstance) ensureFailedClusterFenced(failedCluster string) error {
+	if d.instance.GetAnnotations()[DRPCSkipFencingAnnotation] == "true" {
+		rmnutil.ReportIfNotPresent(d.reconciler.eventRecorder, d.instance, corev1.EventTypeWarning,
+			rmnutil.EventReasonFencingSkipped,
+			fmt.Sprintf("Fencing of cluster %s skipped, as requested by annotation %s",
+				failedCluster, DRPCSkipFencingAnnotation))
+
+		return nil
+	}
+
+	drcluster, err := drclusterGet(d.ctx, d.reconciler.APIReader, failedCluster)
+	if err != nil {
+		return err
+	}
+
+	if drcluster == nil ||
+		(len(drcluster.Spec.CIDRs) == 0 && drcluster.Spec.ClusterFence != rmn.ClusterFenceStateFenced) {
+		if d.drPolicy.Spec.ReplicationMode != rmn.ReplicationModeSync {
+			return nil
+		}
+
+		return d.refuseAction(fmt.Sprintf("%s refused, as cluster %s is not fenced, as required in %s "+
+			"replication mode. Fence it through its DRCluster, or set the %s annotation, to proceed",
+			d.instance.Spec.Action, failedCluster, rmn.ReplicationModeSync, DRPCSkipFencingAnnotation))
+	}
+
+	if drcluster.Spec.ClusterFence != rmn.ClusterFenceStateFenced {
+		drcluster.Spec.ClusterFence = rmn.ClusterFenceStateFenced
+		if err := d.reconciler.Update(d.ctx, drcluster); err != nil {
+			return fmt.Errorf("drcluster %s fence: %w", failedCluster, err)
+		}
+
+		rmnutil.ReportIfNotPresent(d.reconciler.eventRecorder, d.instance, corev1.EventTypeNormal,
+			rmnutil.EventReasonFencing, fmt.Sprintf("Fencing cluster %s before %s", failedCluster,
+				d.instance.Spec.Action))
+	}
+
+	if drclusterFenced(drcluster) {
+		return nil
+	}
+
+	return d.waitForFence(fmt.Sprintf("%s waiting for cluster %s to be fenced", d.instance.Spec.Action,
+		failedCluster))
+}
+
+// ensureClusterUnfenced returns an error until the cluster of the DRCluster is
+// unfenced.  A fenced cluster is requested to be unfenced only once the VRG of
+// every DRPC failed over from it, this one included, is secondary on it, or
+// deleted, as unfencing it otherwise lets a VRG still primary on it write to
+// the volumes of a DRPC now primary on another cluster.  A DRClusterAction
+// relocating the DRPCs back to the cluster requests it under the same
+// condition.  A rollback of this DRPC to the cluster skips its own VRG, as it
+// is to be primary on it again.
+func (d *DRPCInstance) ensureClusterUnfenced(drcluster *rmn.DRCluster, rollingBack bool) error {
+	if drcluster.Spec.ClusterFence == rmn.ClusterFenceStateFenced {
+		reason, err := d.clusterUnfencePending(drcluster.Name, rollingBack)
+		if err != nil {
+			return err
+		}
+
+		if reason != "" {
+			return d.waitForFence(fmt.Sprintf("%s waiting for cluster %s to be unfenced, as %s",
+				d.instance.Spec.Action, drcluster.Name, reason))
+		}
+
+		drcluster.Spec.ClusterFence = rmn.ClusterFenceStateUnfenced
+		if err := d.reconciler.Update(d.ctx, drcluster); err != nil {
+			return fmt.Errorf("drcluster %s unfence: %w", drcluster.Name, err)
+		}
+
+		rmnutil.ReportIfNotPresent(d.reconciler.eventRecorder, d.instance, corev1.EventTypeNormal,
+			rmnutil.EventReasonUnfencing, fmt.Sprintf("Unfencing cluster %s before %s", drcluster.Name,
+				d.instance.Spec.Action))
+	}
+
+	if drclusterUnfenced(drcluster) {
+		return nil
+	}
+
+	return d.waitForFence(fmt.Sprintf("%s waiting for cluster %s to be unfenced", d.instance.Spec.Action,
+		drcluster.Name))
+}
+
+// clusterUnfencePending returns why the cluster may not yet be unfenced, or an
+// empty string once the VRG of every DRPC failing over, or failed over, from
+// the cluster, and of this DRPC unless rolling back, is secondary on the
+// cluster, or deleted
+func (d *DRPCInstance) clusterUnfencePending(clusterName string, rollingBack bool) (string, error) {
+	return clusterUnfencePending(d.ctx, d.reconciler.APIReader, d.reconciler.MCVGetter, clusterName,
+		func(drpc *rmn.DRPlacementControl) bool {
+			if drpc.Name == d.instance.Name && drpc.Namespace == d.instance.Namespace {
+				return !rollingBack
+			}
+
+			return drpcFailingOverFrom(drpc, clusterName)
+		})
+}
+
+// clusterUnfencePending returns why the cluster may not yet be unfenced, or an
+// empty string once the VRG of every DRPC the filter selects is secondary on
+// the cluster, or deleted
+func clusterUnfencePending(ctx context.Context, reader client.Reader, mcvGetter ManagedClusterViewGetter,
+	clusterName string, selected func(*rmn.DRPlacementControl) bool,
+) (string, error) {
+	drpcs := &rmn.DRPlacementControlList{}
+	if err := reader.List(ctx, drpcs); err != nil {
+		return "", fmt.Errorf("drpcs list: %w", err)
+	}
+
+	for idx := range drpcs.Items {
+		drpc := &drpcs.Items[idx]
+		if !selected(drpc) {
+			continue
+		}
+
+		vrg, err := mcvGetter.GetVRGFromManagedCluster(drpc.Name, drpc.Namespace, clusterName)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+
+			return fmt.Sprintf("the VRG of DRPC %s/%s on it is not yet known: %v", drpc.Namespace, drpc.Name,
+				err), nil
+		}
+
+		if vrg.Status.State != rmn.SecondaryState {
+			return fmt.Sprintf("the VRG of DRPC %s/%s on it is not yet secondary", drpc.Namespace, drpc.Name), nil
+		}
+	}
+
+	return "", nil
+}
+
+// drpcFailingOverFrom returns true if the DRPC fails over, or has failed over,
+// from the cluster, which it prefers, to another cluster
+func drpcFailingOverFrom(drpc *rmn.DRPlacementControl, clusterName string) bool {
+	return drpc.Spec.Action == rmn.ActionFailover &&
+		drpc.Spec.PreferredCluster == clusterName &&
+		drpc.Spec.FailoverCluster != clusterName
+}
+
+// waitForFence reports that the action waits for a cluster to be fenced or
+// unfenced, and returns it as an error for the action to be retried
+func (d *DRPCInstance) waitForFence(msg string) error {
+	d.log.Info(msg)
+	d.setDRPCCondition(&d.instance.Status.Conditions, rmn.ConditionAvailable, d.instance.Generation,
+		d.getConditionStatusForTypeAvailable(), string(d.instance.Status.Phase), msg)
+
+	return fmt.Errorf(msg)
+}
+
+// refuseAction reports the refusal of the action, and returns it as an error
+func (d *DRPCInstance) refuseAction(msg string) error {
+	d.setDRPCCondition(&d.instance.Status.Conditions, rmn.ConditionAvailable, d.instance.Generation,
+		d.getConditionStatusForTypeAvailable(), string(d.instance.Status.Phase), msg)
+	rmnutil.ReportIfNotPresent(d.reconciler.eventRecorder, d.instance, corev1.EventTypeWarning,
+		rmnutil.EventReasonActionRefused, msg)
+
+	return fmt.Errorf(msg)
+}
+
 func clusterListContains(clNames []string, cName string) bool {
 	for _, clName := range clNames {
 		if clName == cName {
@@ -573,6 +887,9 @@ func (d *DRPCInstance) readyToSwitchOver(homeCluster string) bool {
 
 func (d *DRPCInstance) relocate(preferredCluster, preferredClusterNamespace string, drState rmn.DRState) (bool, error) {
 	const done = true
+
+	d.actionOriginRecord(preferredCluster)
+
 	// Make sure we record the state that we are failing over
 	d.setDRState(drState)
 	d.setMetricsTimerFromDRState(drState)
@@ -673,6 +990,10 @@ func (d *DRPCInstance) executeRelocation(targetCluster, targetClusterNamespace s
 		if !restored {
 			return fmt.Errorf("%w)", WaitForPVRestoreToComplete)
 		}
+
+		if record := d.actionRecord(); record != nil {
+			d.actionRecordTimeSet(&record.DataRestoredTime)
+		}
 	}
 
 	err = d.updateUserPlacementRule(targetCluster, targetClusterNamespace)
@@ -680,6 +1001,10 @@ func (d *DRPCInstance) executeRelocation(targetCluster, targetClusterNamespace s
 		return err
 	}
 
+	if record := d.actionRecord(); record != nil {
+		d.actionRecordTimeSet(&record.PlacementUpdatedTime)
+	}
+
 	clusterToSkip := targetCluster
 
 	// Cleaning up the previous VRG primary requires many steps. In this step, we update
@@ -990,6 +1315,48 @@ func (d *DRPCInstance) EnsureCleanup(clusterToSkip string) error {
 	return nil
 }
 
+// reportDRPolicyHealth reflects a degraded DRPolicy in the PeerReady condition,
+// which placement processing may have reset, hence the condition prior to it is
+// restored if it already reported the same degradation.  Once the DRPolicy
+// recovers, PeerReady is restored to reflect the state of the peers.
+func (d *DRPCInstance) reportDRPolicyHealth(peerReadyBefore *metav1.Condition) {
+	condition := findCondition(d.instance.Status.Conditions, rmn.ConditionPeerReady)
+
+	if msg := rmnutil.DrpolicyDegradedMessage(d.drPolicy); msg != "" {
+		if condition != nil && peerReadyBefore != nil &&
+			peerReadyBefore.Status == metav1.ConditionFalse &&
+			peerReadyBefore.Reason == rmn.ReasonDRPolicyDegraded &&
+			peerReadyBefore.Message == msg &&
+			peerReadyBefore.ObservedGeneration == d.instance.Generation {
+			*condition = *peerReadyBefore
+
+			return
+		}
+
+		if SetDRPCStatusCondition(&d.instance.Status.Conditions, rmn.ConditionPeerReady, d.instance.Generation,
+			metav1.ConditionFalse, rmn.ReasonDRPolicyDegraded, msg) {
+			d.needStatusUpdate = true
+		}
+
+		return
+	}
+
+	if condition == nil || condition.Reason != rmn.ReasonDRPolicyDegraded {
+		return
+	}
+
+	// Cleanup of the peers is rechecked for an action, once it is not succeeded
+	status, reason, msg := metav1.ConditionTrue, rmn.ReasonSuccess, "DRPolicy is no longer degraded"
+	if d.instance.Spec.Action != "" {
+		status, reason = metav1.ConditionFalse, rmn.ReasonProgressing
+	}
+
+	if SetDRPCStatusCondition(&d.instance.Status.Conditions, rmn.ConditionPeerReady, d.instance.Generation,
+		status, reason, msg) {
+		d.needStatusUpdate = true
+	}
+}
+
 func (d *DRPCInstance) namespaceExistsOnManagedCluster(cluster string) (bool, error) {
 	exists := true
 
@@ -1566,5 +1933,7 @@ func (d *DRPCInstance) setMetricsTimer(
 
 func (d *DRPCInstance) setDRPCCondition(conditions *[]metav1.Condition, condType string,
 	observedGeneration int64, status metav1.ConditionStatus, reason, msg string) {
-	d.needStatusUpdate = SetDRPCStatusCondition(conditions, condType, observedGeneration, status, reason, msg)
+	if SetDRPCStatusCondition(conditions, condType, observedGeneration, status, reason, msg) {
+		d.needStatusUpdate = true
+	}
 }
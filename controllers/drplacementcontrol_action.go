@@ -0,0 +1,108 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// The DRActionRequest, DRClusterAction and DRPlacementControlGroup controllers
+// request a failover or relocation from a DRPC by setting its spec, and follow
+// it in the status of the DRPC.  An action is identified by its kind and its
+// target cluster, and an attempt of it by the time it was requested.
+
+// drpcActionTargetCluster returns the cluster the action of the DRPC moves the
+// application to
+func drpcActionTargetCluster(drpc *ramen.DRPlacementControl) string {
+	if drpc.Spec.Action == ramen.ActionFailover {
+		return drpc.Spec.FailoverCluster
+	}
+
+	return drpc.Spec.PreferredCluster
+}
+
+// drpcActionSet sets the action of the DRPC spec to the target cluster,
+// uncancelled
+func drpcActionSet(drpc *ramen.DRPlacementControl, action ramen.DRAction, targetCluster string) {
+	drpc.Spec.Action = action
+	drpc.Spec.CancelAction = false
+
+	if action == ramen.ActionFailover {
+		drpc.Spec.FailoverCluster = targetCluster
+	} else {
+		drpc.Spec.PreferredCluster = targetCluster
+	}
+}
+
+// drpcActionMatches returns true if the DRPC spec requests the action to the
+// target cluster, uncancelled
+func drpcActionMatches(drpc *ramen.DRPlacementControl, action ramen.DRAction, targetCluster string) bool {
+	return drpc.Spec.Action == action && drpcActionTargetCluster(drpc) == targetCluster && !drpc.Spec.CancelAction
+}
+
+// drpcActionFinalPhase returns true if the DRPC is in the final phase of its action
+func drpcActionFinalPhase(drpc *ramen.DRPlacementControl) bool {
+	return (drpc.Spec.Action == ramen.ActionFailover && drpc.Status.Phase == ramen.FailedOver) ||
+		(drpc.Spec.Action == ramen.ActionRelocate && drpc.Status.Phase == ramen.Relocated)
+}
+
+// drpcActionRecord returns the last record of the action to the target
+// cluster in the action history of the DRPC, that had not ended before the
+// action was requested
+func drpcActionRecord(drpc *ramen.DRPlacementControl, action ramen.DRAction, targetCluster string,
+	requestTime metav1.Time,
+) *ramen.ActionRecord {
+	// Times are recorded to the second
+	started := metav1.NewTime(requestTime.Truncate(time.Second))
+
+	for idx := len(drpc.Status.ActionHistory) - 1; idx >= 0; idx-- {
+		record := &drpc.Status.ActionHistory[idx]
+		if record.Action != action || record.TargetCluster != targetCluster {
+			continue
+		}
+
+		if record.EndTime != nil && record.EndTime.Before(&started) {
+			return nil
+		}
+
+		return record
+	}
+
+	return nil
+}
+
+// drpcActionApproval returns the approval of the action to the target cluster
+// recorded in the status of the DRPC, unless it precedes the request of the
+// action
+func drpcActionApproval(drpc *ramen.DRPlacementControl, action ramen.DRAction, targetCluster string,
+	requestTime metav1.Time,
+) *ramen.ActionApproval {
+	// Times are recorded to the second
+	started := metav1.NewTime(requestTime.Truncate(time.Second))
+
+	approval := drpc.Status.ActionApproval
+	if approval == nil || approval.Action != action || approval.TargetCluster != targetCluster ||
+		approval.RequestTime.Before(&started) {
+		return nil
+	}
+
+	return approval
+}
@@ -0,0 +1,240 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/controllers/util"
+)
+
+// A failover or relocation of a DRPC whose DRPolicy has an action approval
+// policy, or whose namespace is annotated to require approval, is held until a
+// user other than its requester approves it.  The requester and the approver
+// are recorded in annotations of the DRPC by the admission webhook, and the
+// approval in its status.  An action that is rejected, or that awaits a
+// decision past the expiry of the policy, is not started until the spec of
+// the DRPC changes.
+
+// defaultActionApprovalExpiry is how long an action of a DRPC in a namespace
+// that requires approval awaits it before it expires
+const defaultActionApprovalExpiry = 24 * time.Hour
+
+// processActionApproval holds the action of the DRPC until it is approved, if
+// approval is required.  It returns whether the action is approved, and if
+// not, whether it is done, as it is rejected or expired.
+func (d *DRPCInstance) processActionApproval() (bool, bool, error) {
+	const (
+		approved = true
+		done     = true
+	)
+
+	if d.instance.Spec.Action != rmn.ActionFailover && d.instance.Spec.Action != rmn.ActionRelocate {
+		return approved, !done, nil
+	}
+
+	targetCluster := d.actionTargetCluster()
+
+	// An action that has started, or has nothing to do, is not held
+	if origin := d.instance.Status.ActionOrigin; (origin != nil && origin.Action == d.instance.Spec.Action &&
+		origin.TargetCluster == targetCluster) ||
+		(d.getCurrentHomeClusterName() == targetCluster && !d.isInProgressingPhase()) {
+		return approved, !done, nil
+	}
+
+	policy, err := d.actionApprovalPolicy()
+	if err != nil || policy == nil {
+		return err == nil, !done, err
+	}
+
+	approval := d.actionApprovalRequest(targetCluster)
+
+	switch approval.State {
+	case rmn.ActionApprovalApproved:
+		return approved, !done, nil
+	case rmn.ActionApprovalRejected, rmn.ActionApprovalExpired:
+		return !approved, done, nil
+	}
+
+	if decision := d.instance.GetAnnotations()[rmn.DRPCActionApprovalAnnotation]; decision != "" {
+		if d.actionApprovalDecide(approval, policy, rmn.ActionApprovalState(decision)) {
+			return approval.State == rmn.ActionApprovalApproved, approval.State != rmn.ActionApprovalApproved, nil
+		}
+	}
+
+	if time.Since(approval.RequestTime.Time) >= policy.Expiry.Duration {
+		msg := fmt.Sprintf("%s to cluster %s expired awaiting approval", approval.Action, approval.TargetCluster)
+		d.actionApprovalSet(approval, rmn.ActionApprovalExpired, "", msg)
+		rmnutil.ReportIfNotPresent(d.reconciler.eventRecorder, d.instance, corev1.EventTypeWarning,
+			rmnutil.EventReasonActionApprovalExpired, msg)
+
+		return !approved, done, nil
+	}
+
+	return !approved, !done, nil
+}
+
+// actionApprovalPolicy returns the approval policy of the DRPolicy of the
+// DRPC, or the default policy if its namespace requires approval, or nil if
+// approval is not required
+func (d *DRPCInstance) actionApprovalPolicy() (*rmn.ActionApprovalPolicy, error) {
+	if policy := d.drPolicy.Spec.ActionApproval; policy != nil {
+		if policy.Expiry.Duration == 0 {
+			policy = policy.DeepCopy()
+			policy.Expiry.Duration = defaultActionApprovalExpiry
+		}
+
+		return policy, nil
+	}
+
+	namespace := &corev1.Namespace{}
+	if err := d.reconciler.APIReader.Get(d.ctx, types.NamespacedName{Name: d.instance.Namespace},
+		namespace); err != nil {
+		return nil, fmt.Errorf("namespace %s get: %w", d.instance.Namespace, err)
+	}
+
+	if namespace.GetAnnotations()[rmn.NamespaceActionApprovalRequiredAnnotation] != "true" {
+		return nil, nil
+	}
+
+	return &rmn.ActionApprovalPolicy{Expiry: metav1.Duration{Duration: defaultActionApprovalExpiry}}, nil
+}
+
+// actionApprovalRequest returns the approval of the action to the target
+// cluster, recording a new pending approval if the action is not recorded, or
+// is requested again after it was rejected or expired
+func (d *DRPCInstance) actionApprovalRequest(targetCluster string) *rmn.ActionApproval {
+	requester := d.instance.GetAnnotations()[rmn.DRPCActionRequesterAnnotation]
+
+	approval := d.instance.Status.ActionApproval
+	if approval != nil && approval.Action == d.instance.Spec.Action && approval.TargetCluster == targetCluster &&
+		(approval.State == rmn.ActionApprovalPending || approval.State == rmn.ActionApprovalApproved ||
+			approval.ObservedGeneration == d.instance.Generation) {
+		if approval.State == rmn.ActionApprovalPending &&
+			(approval.Requester != requester || approval.ObservedGeneration != d.instance.Generation) {
+			approval.Requester = requester
+			approval.ObservedGeneration = d.instance.Generation
+			d.needStatusUpdate = true
+		}
+
+		return approval
+	}
+
+	msg := fmt.Sprintf("%s to cluster %s requested by %s awaits approval", d.instance.Spec.Action, targetCluster,
+		requesterName(requester))
+	d.log.Info(msg)
+
+	d.instance.Status.ActionApproval = &rmn.ActionApproval{
+		Action:             d.instance.Spec.Action,
+		TargetCluster:      targetCluster,
+		Requester:          requester,
+		RequestTime:        metav1.Now(),
+		ObservedGeneration: d.instance.Generation,
+		State:              rmn.ActionApprovalPending,
+		Message:            msg,
+	}
+	d.needStatusUpdate = true
+
+	rmnutil.ReportIfNotPresent(d.reconciler.eventRecorder, d.instance, corev1.EventTypeNormal,
+		rmnutil.EventReasonActionApprovalRequired, msg)
+
+	return d.instance.Status.ActionApproval
+}
+
+// actionApprovalDecide records the decision of the approver recorded in the
+// annotations of the DRPC, and returns true, unless the approver may not
+// decide, in which case the reason is recorded and reported instead
+func (d *DRPCInstance) actionApprovalDecide(approval *rmn.ActionApproval, policy *rmn.ActionApprovalPolicy,
+	decision rmn.ActionApprovalState,
+) bool {
+	approver := d.instance.GetAnnotations()[rmn.DRPCActionApproverAnnotation]
+
+	invalid := ""
+
+	switch {
+	case decision != rmn.ActionApprovalApproved && decision != rmn.ActionApprovalRejected:
+		invalid = fmt.Sprintf("decision %s is neither %s nor %s", decision, rmn.ActionApprovalApproved,
+			rmn.ActionApprovalRejected)
+	case approver == "":
+		invalid = "the approver is not recorded, as the admission webhook is not enabled"
+	case approval.Requester == "":
+		invalid = "the requester is not recorded; request the action again"
+	case approver == approval.Requester:
+		invalid = fmt.Sprintf("%s requested the action, and may not approve or reject it", approver)
+	case len(policy.Approvers) != 0 && !containsString(policy.Approvers, approver):
+		invalid = fmt.Sprintf("%s is not one of the approvers of DRPolicy %s", approver, d.drPolicy.Name)
+	}
+
+	if invalid != "" {
+		msg := fmt.Sprintf("%s to cluster %s awaits approval; %s", approval.Action, approval.TargetCluster, invalid)
+		d.actionApprovalSet(approval, rmn.ActionApprovalPending, "", msg)
+		rmnutil.ReportIfNotPresent(d.reconciler.eventRecorder, d.instance, corev1.EventTypeWarning,
+			rmnutil.EventReasonActionApprovalInvalid, msg)
+
+		return false
+	}
+
+	msg := fmt.Sprintf("%s to cluster %s requested by %s %s by %s", approval.Action, approval.TargetCluster,
+		approval.Requester, decision, approver)
+	d.log.Info(msg)
+	d.actionApprovalSet(approval, decision, approver, msg)
+
+	eventType, reason := corev1.EventTypeNormal, rmnutil.EventReasonActionApproved
+	if decision == rmn.ActionApprovalRejected {
+		eventType, reason = corev1.EventTypeWarning, rmnutil.EventReasonActionRejected
+	}
+
+	rmnutil.ReportIfNotPresent(d.reconciler.eventRecorder, d.instance, eventType, reason, msg)
+
+	return true
+}
+
+// actionApprovalSet records the state of the approval of the action, and the
+// time of the decision once it is no longer pending
+func (d *DRPCInstance) actionApprovalSet(approval *rmn.ActionApproval, state rmn.ActionApprovalState,
+	approver, msg string,
+) {
+	if approval.State == state && approval.Approver == approver && approval.Message == msg {
+		return
+	}
+
+	approval.State = state
+	approval.Approver = approver
+	approval.Message = msg
+
+	if state != rmn.ActionApprovalPending {
+		now := metav1.Now()
+		approval.DecisionTime = &now
+	}
+
+	d.needStatusUpdate = true
+}
+
+// requesterName returns the requester, or a placeholder if it is not recorded
+func requesterName(requester string) string {
+	if requester == "" {
+		return "an unrecorded user"
+	}
+
+	return requester
+}
@@ -0,0 +1,231 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/controllers/util"
+)
+
+// A failover or relocation is cancelled by setting spec.cancelAction.  Until
+// the VRG of the target cluster reports Primary, the action is rolled back:
+// the VRG of the target cluster is demoted to Secondary, the source cluster is
+// unfenced if need be, its VRG promoted back to Primary, and the application
+// placed back on it.  Past that point, the volumes of the target cluster may
+// have diverged from those of the source cluster, and would need a resync to
+// be demoted, hence the cancellation is refused and the action proceeds.
+
+// actionTargetCluster returns the cluster the action of the DRPC moves the
+// application to
+func (d *DRPCInstance) actionTargetCluster() string {
+	return drpcActionTargetCluster(d.instance)
+}
+
+// actionOriginRecord records the home cluster and the phase the action starts
+// from, unless the action is already in progress
+func (d *DRPCInstance) actionOriginRecord(targetCluster string) {
+	origin := d.instance.Status.ActionOrigin
+	if origin != nil && origin.Action == d.instance.Spec.Action && origin.TargetCluster == targetCluster &&
+		d.isInProgressingPhase() {
+		return
+	}
+
+	d.instance.Status.ActionOrigin = &rmn.ActionOrigin{
+		Action:        d.instance.Spec.Action,
+		TargetCluster: targetCluster,
+		SourceCluster: d.getCurrentHomeClusterName(),
+		SourcePhase:   d.getLastDRState(),
+		StartTime:     metav1.Now(),
+	}
+	d.needStatusUpdate = true
+
+	d.actionRecordStart(d.instance.Status.ActionOrigin)
+}
+
+// actionCancellation returns the cancellation of the current action, if any
+func (d *DRPCInstance) actionCancellation(targetCluster string) *rmn.ActionCancellation {
+	cancellation := d.instance.Status.ActionCancellation
+	if cancellation == nil || cancellation.Action != d.instance.Spec.Action ||
+		cancellation.TargetCluster != targetCluster {
+		return nil
+	}
+
+	// A cancellation that precedes the start of the action is stale
+	if origin := d.instance.Status.ActionOrigin; origin != nil && cancellation.Time.Before(&origin.StartTime) {
+		return nil
+	}
+
+	return cancellation
+}
+
+// processCancel cancels the action of the DRPC.  It returns whether the
+// cancellation is handled, in which case the action is not processed any
+// further, and if so, whether it is done.
+func (d *DRPCInstance) processCancel() (bool, bool, error) {
+	const (
+		handled = true
+		done    = true
+	)
+
+	targetCluster := d.actionTargetCluster()
+
+	cancellation := d.actionCancellation(targetCluster)
+	if cancellation != nil {
+		switch cancellation.State {
+		case rmn.ActionCancellationRefused:
+			return !handled, !done, nil
+		case rmn.ActionCancellationRolledBack:
+			return handled, done, d.EnsureCleanup(cancellation.SourceCluster)
+		}
+	} else {
+		sourceCluster, refusal := d.cancelSourceCluster(targetCluster)
+		if refusal != "" {
+			msg := fmt.Sprintf("%s to cluster %s not cancelled, as %s", d.instance.Spec.Action, targetCluster, refusal)
+			d.actionCancellationSet(targetCluster, "", rmn.ActionCancellationRefused, msg)
+			rmnutil.ReportIfNotPresent(d.reconciler.eventRecorder, d.instance, corev1.EventTypeWarning,
+				rmnutil.EventReasonActionCancelRefused, msg)
+
+			return !handled, !done, nil
+		}
+
+		d.actionCancellationSet(targetCluster, sourceCluster, rmn.ActionCancellationRollingBack,
+			fmt.Sprintf("rolling back to cluster %s", sourceCluster))
+
+		cancellation = d.instance.Status.ActionCancellation
+	}
+
+	if err := d.rollBack(targetCluster, cancellation.SourceCluster); err != nil {
+		d.setDRPCCondition(&d.instance.Status.Conditions, rmn.ConditionAvailable, d.instance.Generation,
+			d.getConditionStatusForTypeAvailable(), string(d.instance.Status.Phase), err.Error())
+
+		return handled, !done, err
+	}
+
+	msg := fmt.Sprintf("%s to cluster %s cancelled, and rolled back to cluster %s", d.instance.Spec.Action,
+		targetCluster, cancellation.SourceCluster)
+	d.log.Info(msg)
+	d.actionCancellationSet(targetCluster, cancellation.SourceCluster, rmn.ActionCancellationRolledBack, msg)
+	d.actionRecordEnd(rmn.ActionCancelled)
+	rmnutil.ReportIfNotPresent(d.reconciler.eventRecorder, d.instance, corev1.EventTypeNormal,
+		rmnutil.EventReasonActionCancelled, msg)
+
+	// The rollback is complete, but the target cluster still needs cleaning up
+	return handled, !done, nil
+}
+
+// cancelSourceCluster returns the cluster to roll the action back to, or the
+// reason the action cannot be cancelled
+func (d *DRPCInstance) cancelSourceCluster(targetCluster string) (string, string) {
+	origin := d.instance.Status.ActionOrigin
+	if origin == nil || origin.Action != d.instance.Spec.Action || origin.TargetCluster != targetCluster {
+		// The action has not started; holding the application where it is cancels it
+		return d.getCurrentHomeClusterName(), ""
+	}
+
+	if !d.isInProgressingPhase() {
+		return "", "it has completed"
+	}
+
+	if d.hasAlreadySwitchedOver(targetCluster) {
+		return "", fmt.Sprintf("the application is already placed on cluster %s", targetCluster)
+	}
+
+	if vrg, ok := d.vrgs[targetCluster]; ok && vrg.Status.State == rmn.PrimaryState {
+		return "", fmt.Sprintf("the VRG on cluster %s already reports Primary", targetCluster)
+	}
+
+	if origin.SourceCluster == "" || origin.SourceCluster == targetCluster {
+		return "", "it has no other cluster to roll back to"
+	}
+
+	return origin.SourceCluster, ""
+}
+
+// rollBack demotes the VRG of the target cluster, and once it is Secondary,
+// promotes the VRG of the source cluster back to Primary and places the
+// application back on it.  It returns an error until the rollback completes.
+func (d *DRPCInstance) rollBack(targetCluster, sourceCluster string) error {
+	if sourceCluster == "" {
+		return nil
+	}
+
+	if targetCluster != sourceCluster {
+		if err := d.updateVRGStateToSecondary(targetCluster); err != nil {
+			return err
+		}
+
+		if !d.ensureVRGIsSecondaryOnCluster(targetCluster) {
+			return fmt.Errorf("cancellation waiting for VRG on cluster %s to be secondary", targetCluster)
+		}
+	}
+
+	drcluster, err := drclusterGet(d.ctx, d.reconciler.APIReader, sourceCluster)
+	if err != nil {
+		return err
+	}
+
+	if drcluster != nil {
+		if err := d.ensureClusterUnfenced(drcluster, true); err != nil {
+			return err
+		}
+	}
+
+	if _, err := d.createVRGManifestWorkAsPrimary(sourceCluster); err != nil {
+		return err
+	}
+
+	if err := d.updateUserPlacementRule(sourceCluster, sourceCluster); err != nil {
+		return err
+	}
+
+	if origin := d.instance.Status.ActionOrigin; origin != nil && origin.SourcePhase != "" &&
+		d.instance.Status.Phase != origin.SourcePhase && d.isInProgressingPhase() {
+		d.instance.Status.Phase = origin.SourcePhase
+		d.needStatusUpdate = true
+	}
+
+	d.setDRPCCondition(&d.instance.Status.Conditions, rmn.ConditionAvailable, d.instance.Generation,
+		d.getConditionStatusForTypeAvailable(), string(d.instance.Status.Phase),
+		fmt.Sprintf("%s cancelled", d.instance.Spec.Action))
+
+	return nil
+}
+
+// actionCancellationSet records the state of the cancellation of the action
+func (d *DRPCInstance) actionCancellationSet(targetCluster, sourceCluster string,
+	state rmn.ActionCancellationState, msg string) {
+	cancellation := d.instance.Status.ActionCancellation
+	if cancellation != nil && cancellation.Action == d.instance.Spec.Action &&
+		cancellation.TargetCluster == targetCluster && cancellation.State == state && cancellation.Message == msg {
+		return
+	}
+
+	d.instance.Status.ActionCancellation = &rmn.ActionCancellation{
+		Action:        d.instance.Spec.Action,
+		TargetCluster: targetCluster,
+		SourceCluster: sourceCluster,
+		State:         state,
+		Message:       msg,
+		Time:          metav1.Now(),
+	}
+	d.needStatusUpdate = true
+}
@@ -52,6 +52,10 @@ const (
 	// DRPC CR finalizer
 	DRPCFinalizer string = "drpc.ramendr.openshift.io/finalizer"
 
+	// DRPCSkipFencingAnnotation when set to "true" on a DRPC, lets it fail over
+	// without fencing the cluster it fails over from
+	DRPCSkipFencingAnnotation = "drplacementcontrols.ramendr.openshift.io/skip-fencing"
+
 	// Ramen scheduler
 	RamenScheduler string = "ramen"
 
@@ -74,6 +78,10 @@ type ManagedClusterViewGetter interface {
 		resourceName, resourceNamespace, managedCluster string) (*rmn.VolumeReplicationGroup, error)
 
 	GetNamespaceFromManagedCluster(resourceName, resourceNamespace, managedCluster string) (*corev1.Namespace, error)
+
+	GetClusterInventoryFromManagedCluster(managedCluster string) (*ClusterInventory, error)
+
+	GetNetworkFenceFromManagedCluster(fencedCluster, managedCluster string) (*rmnutil.NetworkFence, error)
 }
 
 type ManagedClusterViewGetterImpl struct {
@@ -128,6 +136,58 @@ func (m ManagedClusterViewGetterImpl) GetNamespaceFromManagedCluster(
 	return namespace, err
 }
 
+func (m ManagedClusterViewGetterImpl) GetClusterInventoryFromManagedCluster(
+	managedCluster string) (*ClusterInventory, error) {
+	logger := ctrl.Log.WithName("MCV").WithValues("resouceName", ClusterInventoryName)
+
+	// get the inventory ConfigMap published by the dr-cluster operator through ManagedClusterView
+	mcvMeta := metav1.ObjectMeta{
+		Name:      clusterInventoryViewName(),
+		Namespace: managedCluster,
+	}
+
+	mcvViewscope := viewv1beta1.ViewScope{
+		Resource:  "ConfigMap",
+		Name:      ClusterInventoryName,
+		Namespace: OperatorNamespace(),
+	}
+
+	configMap := &corev1.ConfigMap{}
+
+	if err := m.getManagedClusterResource(mcvMeta, mcvViewscope, configMap, logger); err != nil {
+		return nil, err
+	}
+
+	return ClusterInventoryFromConfigMap(configMap)
+}
+
+// GetNetworkFenceFromManagedCluster returns the NetworkFence of the fenced
+// cluster, created on its fence peer cluster
+func (m ManagedClusterViewGetterImpl) GetNetworkFenceFromManagedCluster(
+	fencedCluster, managedCluster string) (*rmnutil.NetworkFence, error) {
+	logger := ctrl.Log.WithName("MCV").WithValues("resouceName", rmnutil.NetworkFenceName(fencedCluster))
+
+	mcvMeta := metav1.ObjectMeta{
+		Name:      networkFenceViewName(fencedCluster),
+		Namespace: managedCluster,
+	}
+
+	mcvViewscope := viewv1beta1.ViewScope{
+		Group:   rmnutil.NetworkFenceGroup,
+		Version: rmnutil.NetworkFenceVersion,
+		Kind:    rmnutil.NetworkFenceKind,
+		Name:    rmnutil.NetworkFenceName(fencedCluster),
+	}
+
+	networkFence := &rmnutil.NetworkFence{}
+
+	if err := m.getManagedClusterResource(mcvMeta, mcvViewscope, networkFence, logger); err != nil {
+		return nil, err
+	}
+
+	return networkFence, nil
+}
+
 /*
 Description: queries a managed cluster for a resource type, and populates a variable with the results.
 Requires:
@@ -364,6 +424,60 @@ func filterUsrPlRule(usrPlRule *plrv1.PlacementRule) []ctrl.Request {
 	}
 }
 
+// DRPolicyPredicateFunc filters DRPolicy events other than changes to its
+// status conditions, which report the health of the DRPolicy
+func DRPolicyPredicateFunc() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldDRPolicy, ok := e.ObjectOld.(*rmn.DRPolicy)
+			if !ok {
+				return false
+			}
+
+			newDRPolicy, ok := e.ObjectNew.(*rmn.DRPolicy)
+			if !ok {
+				return false
+			}
+
+			return rmnutil.DrpolicyDegradedMessage(oldDRPolicy) != rmnutil.DrpolicyDegradedMessage(newDRPolicy)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+	}
+}
+
+// filterDRPolicy returns the DRPCs that refer to the drpolicy
+func (r *DRPlacementControlReconciler) filterDRPolicy(drpolicy *rmn.DRPolicy) []ctrl.Request {
+	requests := []ctrl.Request{}
+
+	drpcs := &rmn.DRPlacementControlList{}
+	if err := r.Client.List(context.TODO(), drpcs); err != nil {
+		ctrl.Log.Info(fmt.Sprintf("Failed to list DRPCs of DRPolicy %s (%v)", drpolicy.Name, err))
+
+		return requests
+	}
+
+	for idx := range drpcs.Items {
+		drpc := &drpcs.Items[idx]
+		if drpc.Spec.DRPolicyRef.Name != drpolicy.Name {
+			continue
+		}
+
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: drpc.Name, Namespace: drpc.Namespace},
+		})
+	}
+
+	return requests
+}
+
 func SetDRPCStatusCondition(conditions *[]metav1.Condition, condType string,
 	observedGeneration int64, status metav1.ConditionStatus, reason, msg string) bool {
 	newCondition := metav1.Condition{
@@ -430,6 +544,19 @@ func (r *DRPlacementControlReconciler) SetupWithManager(mgr ctrl.Manager) error
 		return filterUsrPlRule(usrPlRule)
 	}))
 
+	drpolicyPred := DRPolicyPredicateFunc()
+
+	drpolicyMapFun := handler.EnqueueRequestsFromMapFunc(handler.MapFunc(func(obj client.Object) []reconcile.Request {
+		drpolicy, ok := obj.(*rmn.DRPolicy)
+		if !ok {
+			return []reconcile.Request{}
+		}
+
+		ctrl.Log.Info(fmt.Sprintf("Filtering DRPolicy (%s)", drpolicy.Name))
+
+		return r.filterDRPolicy(drpolicy)
+	}))
+
 	r.eventRecorder = rmnutil.NewEventReporter(mgr.GetEventRecorderFor("controller_DRPlacementControl"))
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -438,6 +565,7 @@ func (r *DRPlacementControlReconciler) SetupWithManager(mgr ctrl.Manager) error
 		Watches(&source.Kind{Type: &ocmworkv1.ManifestWork{}}, mwMapFun, builder.WithPredicates(mwPred)).
 		Watches(&source.Kind{Type: &viewv1beta1.ManagedClusterView{}}, mcvMapFun, builder.WithPredicates(mcvPred)).
 		Watches(&source.Kind{Type: &plrv1.PlacementRule{}}, usrPlRuleMapFun, builder.WithPredicates(usrPlRulePred)).
+		Watches(&source.Kind{Type: &rmn.DRPolicy{}}, drpolicyMapFun, builder.WithPredicates(drpolicyPred)).
 		Complete(r)
 }
 
@@ -446,12 +574,14 @@ func (r *DRPlacementControlReconciler) SetupWithManager(mgr ctrl.Manager) error
 // +kubebuilder:rbac:groups=ramendr.openshift.io,resources=drplacementcontrols/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=ramendr.openshift.io,resources=drplacementcontrols/finalizers,verbs=update
 // +kubebuilder:rbac:groups=ramendr.openshift.io,resources=drpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ramendr.openshift.io,resources=drclusters,verbs=get;list;watch;update
 // +kubebuilder:rbac:groups=apps.open-cluster-management.io,resources=placementrules,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps.open-cluster-management.io,resources=placementrules/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=cluster.open-cluster-management.io,resources=managedclusters,verbs=get;list;watch
 // +kubebuilder:rbac:groups=work.open-cluster-management.io,resources=manifestworks,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=view.open-cluster-management.io,resources=managedclusterviews,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;create;patch;update
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -542,6 +672,14 @@ func (r *DRPlacementControlReconciler) createDRPCInstance(ctx context.Context,
 		return nil, fmt.Errorf("DRPolicy not valid %w", err)
 	}
 
+	// Hold a DRPC whose primary is on a cluster being removed from its DRPolicy,
+	// as the cluster is no longer a peer to relocate or fail over from
+	if homeCluster := drpc.Status.PreferredDecision.ClusterName; homeCluster != "" &&
+		rmnutil.DrpolicyClusterRemovalPending(drPolicy, homeCluster) {
+		return nil, fmt.Errorf("DRPolicy %s cluster %s, hosting the primary, is being removed from it",
+			drPolicy.Name, homeCluster)
+	}
+
 	// We only create DRPC PlacementRule if the preferred cluster is not configured
 	drpcPlRule, err := r.getDRPCPlacementRule(ctx, drpc, usrPlRule, drPolicy)
 	if err != nil {
@@ -553,6 +691,10 @@ func (r *DRPlacementControlReconciler) createDRPCInstance(ctx context.Context,
 		return nil, fmt.Errorf("%w", InitialWaitTimeForDRPCPlacementRule)
 	}
 
+	if err := r.selectFailoverCluster(ctx, drpc, usrPlRule, drPolicy); err != nil {
+		return nil, err
+	}
+
 	vrgs, err := r.getVRGsFromManagedClusters(drpc, drPolicy)
 	if err != nil {
 		return nil, err
@@ -865,7 +1007,7 @@ func (r *DRPlacementControlReconciler) getOrClonePlacementRule(ctx context.Conte
 	clonedPlRule, err := r.getClonedPlacementRule(ctx, clonedPlRuleName, drpc.Namespace)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			clonedPlRule, err = r.clonePlacementRule(ctx, drPolicy, userPlRule, clonedPlRuleName)
+			clonedPlRule, err = r.clonePlacementRule(ctx, drpc, drPolicy, userPlRule, clonedPlRuleName)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create cloned placementrule error: %w", err)
 			}
@@ -893,7 +1035,7 @@ func (r *DRPlacementControlReconciler) getClonedPlacementRule(ctx context.Contex
 	return clonedPlRule, nil
 }
 
-func (r *DRPlacementControlReconciler) clonePlacementRule(ctx context.Context,
+func (r *DRPlacementControlReconciler) clonePlacementRule(ctx context.Context, drpc *rmn.DRPlacementControl,
 	drPolicy *rmn.DRPolicy, userPlRule *plrv1.PlacementRule,
 	clonedPlRuleName string) (*plrv1.PlacementRule, error) {
 	r.Log.Info("Creating a clone placementRule from", "name", userPlRule.Name)
@@ -906,7 +1048,7 @@ func (r *DRPlacementControlReconciler) clonePlacementRule(ctx context.Context,
 	clonedPlRule.ResourceVersion = ""
 	clonedPlRule.Spec.SchedulerName = ""
 
-	err := r.addClusterPeersToPlacementRule(drPolicy, clonedPlRule)
+	err := r.addClusterPeersToPlacementRule(ctx, drpc, drPolicy, userPlRule, clonedPlRule)
 	if err != nil {
 		r.Log.Error(err, "Failed to add cluster peers to cloned placementRule", "name", clonedPlRuleName)
 
@@ -974,15 +1116,36 @@ func (r *DRPlacementControlReconciler) deleteClonedPlacementRule(ctx context.Con
 	return nil
 }
 
-func (r *DRPlacementControlReconciler) addClusterPeersToPlacementRule(
-	drPolicy *rmn.DRPolicy, plRule *plrv1.PlacementRule) error {
+// addClusterPeersToPlacementRule adds the clusters of the DRPolicy to the
+// PlacementRule.  When the DRPC is failing over from its home cluster, the peers
+// in a different region, and then in a different zone, from it come first, and
+// the home cluster last, so that the placement prefers them.
+func (r *DRPlacementControlReconciler) addClusterPeersToPlacementRule(ctx context.Context,
+	drpc *rmn.DRPlacementControl, drPolicy *rmn.DRPolicy,
+	userPlRule *plrv1.PlacementRule, plRule *plrv1.PlacementRule) error {
 	if len(drPolicy.Spec.DRClusterSet) == 0 {
 		return fmt.Errorf("DRPolicy %s is missing DR clusters", drPolicy.Name)
 	}
 
+	clusterNames := make([]string, 0, len(drPolicy.Spec.DRClusterSet))
 	for idx := range drPolicy.Spec.DRClusterSet {
+		clusterNames = append(clusterNames, drPolicy.Spec.DRClusterSet[idx].Name)
+	}
+
+	if homeCluster := drpcHomeCluster(drpc, userPlRule); drpc.Spec.Action == rmn.ActionFailover &&
+		homeCluster != "" {
+		topologies, err := clusterTopologies(ctx, drPolicy, r.APIReader)
+		if err != nil {
+			return err
+		}
+
+		clusterNames = clustersByTopologyPreference(drPolicy, topologies, homeCluster)
+		clusterNames = append(clusterNames, homeCluster)
+	}
+
+	for _, clusterName := range clusterNames {
 		plRule.Spec.Clusters = append(plRule.Spec.Clusters, plrv1.GenericClusterReference{
-			Name: drPolicy.Spec.DRClusterSet[idx].Name,
+			Name: clusterName,
 		})
 	}
 
@@ -991,6 +1154,65 @@ func (r *DRPlacementControlReconciler) addClusterPeersToPlacementRule(
 	return nil
 }
 
+// selectFailoverCluster sets the failover cluster of a failover request that
+// does not specify one to a peer of its home cluster that no DRCluster reports
+// as unavailable, preferring one in a different region, and then in a
+// different zone, from the home cluster
+func (r *DRPlacementControlReconciler) selectFailoverCluster(ctx context.Context,
+	drpc *rmn.DRPlacementControl, usrPlRule *plrv1.PlacementRule, drPolicy *rmn.DRPolicy) error {
+	if drpc.Spec.Action != rmn.ActionFailover || drpc.Spec.FailoverCluster != "" {
+		return nil
+	}
+
+	homeCluster := drpcHomeCluster(drpc, usrPlRule)
+	if homeCluster == "" {
+		// RunFailover reports the request as invalid
+		return nil
+	}
+
+	topologies, err := clusterTopologies(ctx, drPolicy, r.APIReader)
+	if err != nil {
+		return err
+	}
+
+	for _, clusterName := range clustersByTopologyPreference(drPolicy, topologies, homeCluster) {
+		drcluster, err := drclusterGet(ctx, r.APIReader, clusterName)
+		if err != nil {
+			return err
+		}
+
+		if drcluster != nil {
+			if reason := drclusterUnavailable(drcluster); reason != "" {
+				r.Log.Info("Skipping failover cluster candidate", "cluster", clusterName, "reason", reason)
+
+				continue
+			}
+		}
+
+		drpc.Spec.FailoverCluster = clusterName
+		if err := r.Update(ctx, drpc); err != nil {
+			return fmt.Errorf("failed to update DRPC failover cluster %s (%w)", clusterName, err)
+		}
+
+		rmnutil.ReportIfNotPresent(r.eventRecorder, drpc, corev1.EventTypeNormal,
+			rmnutil.EventReasonFailoverClusterSelected,
+			fmt.Sprintf("Selected failover cluster %s to fail over from %s", clusterName, homeCluster))
+
+		return nil
+	}
+
+	return fmt.Errorf("no available cluster in DRPolicy %s to fail over to from %s", drPolicy.Name, homeCluster)
+}
+
+// drpcHomeCluster returns the cluster where the DRPC workload is placed
+func drpcHomeCluster(drpc *rmn.DRPlacementControl, usrPlRule *plrv1.PlacementRule) string {
+	if usrPlRule != nil && len(usrPlRule.Status.Decisions) > 0 {
+		return usrPlRule.Status.Decisions[0].ClusterName
+	}
+
+	return drpc.Status.PreferredDecision.ClusterName
+}
+
 // statusUpdateTimeElapsed returns whether it is time to update DRPC status or not
 // DRPC status is updated at least once every SanityCheckDelay in order to refresh
 // the VRG status.
@@ -1069,6 +1291,8 @@ func (r *DRPlacementControlReconciler) updateDRPCStatus(
 	}
 
 	drpc.Status.LastUpdateTime = metav1.Now()
+	drpc.Status.ObservedGeneration = drpc.Generation
+
 	for i, condition := range drpc.Status.Conditions {
 		if condition.ObservedGeneration != drpc.Generation {
 			drpc.Status.Conditions[i].ObservedGeneration = drpc.Generation
@@ -25,7 +25,9 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	errorswrapper "github.com/pkg/errors"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	machineryruntime "k8s.io/apimachinery/pkg/runtime"
@@ -33,6 +35,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	volrep "github.com/csi-addons/volume-replication-operator/api/v1alpha1"
 	spokeClusterV1 "github.com/open-cluster-management/api/cluster/v1"
 	ocmworkv1 "github.com/open-cluster-management/api/work/v1"
 	plrv1 "github.com/open-cluster-management/multicloud-operators-placementrule/pkg/apis/apps/v1"
@@ -122,6 +125,9 @@ func FakeProgressCallback(drpcName string, state string) {
 
 var restorePVs = true
 
+// vrgPaused reports the VRGs read from the ManifestWorks as paused
+var vrgPaused = false
+
 type FakeMCVGetter struct{}
 
 //nolint:dogsled
@@ -143,6 +149,48 @@ func (f FakeMCVGetter) GetNamespaceFromManagedCluster(
 	return appNamespaceObj, errorswrapper.Wrap(err, "failed to get Namespace from managedcluster")
 }
 
+// GetClusterInventoryFromManagedCluster returns the inventory published by the
+// ClusterInventoryReconciler, as all managed clusters share the test cluster
+func (f FakeMCVGetter) GetClusterInventoryFromManagedCluster(managedCluster string) (
+	*controllers.ClusterInventory, error) {
+	configMap := &corev1.ConfigMap{}
+
+	err := k8sClient.Get(context.TODO(), types.NamespacedName{
+		Name:      controllers.ClusterInventoryName,
+		Namespace: controllers.OperatorNamespace(),
+	}, configMap)
+	if err != nil {
+		return nil, errorswrapper.Wrap(err, "failed to get inventory from managedcluster")
+	}
+
+	return controllers.ClusterInventoryFromConfigMap(configMap)
+}
+
+// GetNetworkFenceFromManagedCluster returns the NetworkFence of the ManifestWork
+// created for the fenced cluster, with its requested fence state succeeded
+func (f FakeMCVGetter) GetNetworkFenceFromManagedCluster(fencedCluster, managedCluster string) (
+	*rmnutil.NetworkFence, error) {
+	mw := &ocmworkv1.ManifestWork{}
+
+	err := k8sClient.Get(context.TODO(), types.NamespacedName{
+		Name:      rmnutil.NetworkFenceManifestWorkName(fencedCluster),
+		Namespace: managedCluster,
+	}, mw)
+	if err != nil {
+		return nil, errorswrapper.Wrap(err, "failed to get NetworkFence from managedcluster")
+	}
+
+	networkFence := &rmnutil.NetworkFence{}
+
+	if err := json.Unmarshal(mw.Spec.Workload.Manifests[0].Raw, networkFence); err != nil {
+		return nil, errorswrapper.Wrap(err, "failed to unmarshal NetworkFence")
+	}
+
+	networkFence.Status.Result = rmnutil.NetworkFenceResultSucceeded
+
+	return networkFence, nil
+}
+
 func (f FakeMCVGetter) GetVRGFromManagedCluster(
 	resourceName, resourceNamespace, managedCluster string) (*rmn.VolumeReplicationGroup, error) {
 	conType := controllers.VRGConditionTypeDataReady
@@ -194,7 +242,34 @@ func (f FakeMCVGetter) GetVRGFromManagedCluster(
 		return nil, errors.NewNotFound(schema.GroupResource{}, "requested resource not found in ManagedCluster")
 
 	case "getVRGsFromManagedClusters":
-		return getVRGFromManifestWork(managedCluster)
+		// A Primary VRG reports it once its ManifestWork is applied
+		vrg, err := getVRGFromManifestWork(managedCluster)
+		if err == nil && vrg.Spec.ReplicationState == rmn.Primary && vrgManifestWorkApplied(managedCluster) {
+			vrg.Status.State = rmn.PrimaryState
+		}
+
+		return vrg, err
+
+	case "clusterUnfencePending":
+		vrg, err := getVRGFromManifestWork(managedCluster)
+		if err == nil && vrg.Spec.ReplicationState == rmn.Secondary {
+			vrg.Status.State = rmn.SecondaryState
+		}
+
+		return vrg, err
+
+	case "drpcResyncPending":
+		// The data of a failed over DRPC has resynced to its recovered cluster
+		vrg.Status.State = rmn.SecondaryState
+		vrg.Status.Conditions = append(vrg.Status.Conditions, metav1.Condition{
+			Type:               controllers.VRGConditionTypeDataProtected,
+			Reason:             controllers.VRGConditionReasonDataProtected,
+			Status:             metav1.ConditionTrue,
+			Message:            "Data protected",
+			LastTransitionTime: metav1.Now(),
+		})
+
+		return vrg, nil
 	}
 
 	return nil, fmt.Errorf("unknonw caller %s", getFunctionNameAtIndex(2))
@@ -237,9 +312,31 @@ func getVRGFromManifestWork(managedCluster string) (*rmn.VolumeReplicationGroup,
 		ObservedGeneration: vrg.Generation,
 	})
 
+	if vrgPaused {
+		vrg.Status.Conditions = append(vrg.Status.Conditions, metav1.Condition{
+			Type:               controllers.VRGConditionTypePaused,
+			Reason:             controllers.VRGConditionReasonPaused,
+			Status:             metav1.ConditionTrue,
+			Message:            "Testing paused VRG",
+			LastTransitionTime: metav1.Now(),
+			ObservedGeneration: vrg.Generation,
+		})
+	}
+
 	return vrg, nil
 }
 
+func vrgManifestWorkApplied(managedCluster string) bool {
+	mw := &ocmworkv1.ManifestWork{}
+
+	err := k8sClient.Get(context.TODO(), types.NamespacedName{
+		Name:      rmnutil.ManifestWorkName(DRPCName, DRPCNamespaceName, "vrg"),
+		Namespace: managedCluster,
+	}, mw)
+
+	return err == nil && meta.IsStatusConditionTrue(mw.Status.Conditions, ocmworkv1.WorkApplied)
+}
+
 func createPlacementRule(name, namespace string) *plrv1.PlacementRule {
 	namereq := metav1.LabelSelectorRequirement{}
 	namereq.Key = "key1"
@@ -424,8 +521,62 @@ func createManagedClusters() {
 
 			err := k8sClient.Create(context.TODO(), clinstance)
 			Expect(err).NotTo(HaveOccurred())
+
+			managedClusterJoin(clinstance)
 		}
 	}
+
+	replicationClassCreate("drpc-replication-class", schedulingInterval)
+}
+
+// managedClusterJoin sets the joined condition of the managed cluster, as
+// required to validate a DRPolicy that includes it
+func managedClusterJoin(managedCluster *spokeClusterV1.ManagedCluster) {
+	meta.SetStatusCondition(&managedCluster.Status.Conditions, metav1.Condition{
+		Type:    spokeClusterV1.ManagedClusterConditionJoined,
+		Status:  metav1.ConditionTrue,
+		Reason:  "ManagedClusterJoined",
+		Message: "Managed cluster joined",
+	})
+	meta.SetStatusCondition(&managedCluster.Status.Conditions, metav1.Condition{
+		Type:    spokeClusterV1.ManagedClusterConditionAvailable,
+		Status:  metav1.ConditionTrue,
+		Reason:  "ManagedClusterAvailable",
+		Message: "Managed cluster is available",
+	})
+	Expect(k8sClient.Status().Update(context.TODO(), managedCluster)).To(Succeed())
+}
+
+// replicationClassCreate creates a VolumeReplicationClass for the scheduling
+// interval, and a StorageClass of its provisioner, that the inventory reports
+// as offered by all managed clusters
+func replicationClassCreate(name, schedulingInterval string) {
+	const provisioner = "drpolicy.test.provisioner"
+
+	replicationClass := &volrep.VolumeReplicationClass{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: volrep.VolumeReplicationClassSpec{
+			Provisioner: provisioner,
+			Parameters:  map[string]string{"schedulingInterval": schedulingInterval},
+		},
+	}
+
+	err := k8sClient.Create(context.TODO(), replicationClass)
+	if errors.IsAlreadyExists(err) {
+		err = nil
+	}
+
+	Expect(err).NotTo(HaveOccurred())
+
+	err = k8sClient.Create(context.TODO(), &storagev1.StorageClass{
+		ObjectMeta:  metav1.ObjectMeta{Name: "drpolicy-test-storageclass"},
+		Provisioner: provisioner,
+	})
+	if errors.IsAlreadyExists(err) {
+		err = nil
+	}
+
+	Expect(err).NotTo(HaveOccurred())
 }
 
 func createDRPolicy() {
@@ -781,6 +932,95 @@ func getDRPCCondition(status *rmn.DRPlacementControlStatus, conditionType string
 	return -1, nil
 }
 
+// touchDRPC labels the DRPC to have it reconciled
+func touchDRPC(value string) {
+	Eventually(func() error {
+		latestDRPC := getLatestDRPC()
+		latestDRPC.SetLabels(map[string]string{"test-touch": value})
+
+		return k8sClient.Update(context.TODO(), latestDRPC)
+	}, timeout, interval).Should(Succeed())
+}
+
+func getEastDRCluster() *rmn.DRCluster {
+	drcluster := &rmn.DRCluster{}
+	Expect(apiReader.Get(context.TODO(), types.NamespacedName{Name: EastManagedCluster}, drcluster)).To(Succeed())
+
+	return drcluster
+}
+
+func getDRPCSplitBrainReason() string {
+	drpc := getLatestDRPC()
+	if _, condition := getDRPCCondition(&drpc.Status, rmn.ConditionSplitBrain); condition != nil {
+		return condition.Reason
+	}
+
+	return ""
+}
+
+func setDRPCSplitBrainWinner(winner string) {
+	Eventually(func() error {
+		latestDRPC := getLatestDRPC()
+		latestDRPC.Spec.SplitBrainWinner = winner
+
+		return k8sClient.Update(context.TODO(), latestDRPC)
+	}, timeout, interval).Should(Succeed())
+}
+
+// copyVRGManifestWork copies the VRG ManifestWork of a cluster to another one,
+// for the other cluster to report the VRG as Primary too
+func copyVRGManifestWork(fromCluster, toCluster string) {
+	mw := &ocmworkv1.ManifestWork{}
+	Expect(k8sClient.Get(context.TODO(), types.NamespacedName{
+		Name:      rmnutil.ManifestWorkName(DRPCName, DRPCNamespaceName, "vrg"),
+		Namespace: fromCluster,
+	}, mw)).To(Succeed())
+	Expect(k8sClient.Create(context.TODO(), &ocmworkv1.ManifestWork{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        mw.Name,
+			Namespace:   toCluster,
+			Labels:      mw.Labels,
+			Annotations: mw.Annotations,
+		},
+		Spec: mw.Spec,
+	})).To(Succeed())
+}
+
+func setDRPCCancelAction(cancelAction bool) {
+	Eventually(func() error {
+		latestDRPC := getLatestDRPC()
+		latestDRPC.Spec.CancelAction = cancelAction
+
+		return k8sClient.Update(context.TODO(), latestDRPC)
+	}, timeout, interval).Should(Succeed())
+}
+
+func getDRPCActionCancellation() rmn.ActionCancellation {
+	if cancellation := getLatestDRPC().Status.ActionCancellation; cancellation != nil {
+		return *cancellation
+	}
+
+	return rmn.ActionCancellation{}
+}
+
+func getVRGManifestWorkReplicationState(clusterName string) rmn.ReplicationState {
+	vrg, err := getVRGFromManifestWork(clusterName)
+	if err != nil {
+		return ""
+	}
+
+	return vrg.Spec.ReplicationState
+}
+
+func getDRPCAvailableMessage() string {
+	drpc := getLatestDRPC()
+	if _, condition := getDRPCCondition(&drpc.Status, rmn.ConditionAvailable); condition != nil {
+		return condition.Message
+	}
+
+	return ""
+}
+
 func relocateToPreferredCluster(userPlacementRule *plrv1.PlacementRule) {
 	setDRPCSpecExpectationTo(rmn.ActionRelocate)
 
@@ -873,6 +1113,23 @@ var _ = Describe("DRPlacementControl Reconciler", func() {
 				Expect(userPlacementRule.Status.Decisions[0].ClusterName).To(Equal(WestManagedCluster))
 			})
 		})
+		When("A VRG is paused after failover", func() {
+			It("Should not refuse the completed failover", func() {
+				vrgPaused = true
+				touchDRPC("paused")
+				Consistently(getDRPCAvailableMessage, timeout, interval).ShouldNot(ContainSubstring("refused"))
+				Expect(getLatestDRPC().Status.Phase).To(Equal(rmn.FailedOver))
+			})
+			It("Should refuse a relocation until the VRG is resumed", func() {
+				setDRPCSpecExpectationTo(rmn.ActionRelocate)
+				Eventually(getDRPCAvailableMessage, timeout, interval).Should(ContainSubstring("refused"))
+				verifyUserPlacementRuleDecisionUnchanged(userPlacementRule.Name, userPlacementRule.Namespace,
+					WestManagedCluster)
+				Expect(getLatestDRPC().Status.Phase).To(Equal(rmn.FailedOver))
+				vrgPaused = false
+				touchDRPC("resumed")
+			})
+		})
 		When("DRAction is set to Relocate", func() {
 			It("Should relocate to Primary (EastManagedCluster)", func() {
 				// ----------------------------- RELOCATION TO PRIMARY --------------------------------------
@@ -895,6 +1152,19 @@ var _ = Describe("DRPlacementControl Reconciler", func() {
 				val, err := rmnutil.GetMetricValueSingle("ramen_relocate_time", dto.MetricType_GAUGE)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(val).NotTo(Equal(0.0)) // failover time should be non-zero
+
+				Eventually(func() []string {
+					records := []string{}
+					for _, record := range getLatestDRPC().Status.ActionHistory {
+						records = append(records, fmt.Sprintf("%s %s %s", record.Action, record.TargetCluster,
+							record.Outcome))
+					}
+
+					return records
+				}, timeout, interval).Should(Equal([]string{
+					fmt.Sprintf("%s %s %s", rmn.ActionFailover, WestManagedCluster, rmn.ActionSucceeded),
+					fmt.Sprintf("%s %s %s", rmn.ActionRelocate, EastManagedCluster, rmn.ActionSucceeded),
+				}))
 			})
 		})
 		When("DRAction is cleared after relocation", func() {
@@ -916,6 +1186,89 @@ var _ = Describe("DRPlacementControl Reconciler", func() {
 			})
 		})
 		When("DRAction is changed to Failover after relocation", func() {
+			It("Should fence EastManagedCluster, whose DRCluster has CIDRs, before failing over", func() {
+				Expect(k8sClient.Create(context.TODO(), &rmn.DRCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: EastManagedCluster},
+					Spec: rmn.DRClusterSpec{
+						S3ProfileName: "fakeS3Profile",
+						CIDRs:         []string{"10.0.0.0/24"},
+					},
+				})).To(Succeed())
+			})
+			It("Should hold the failover until approved, if the namespace requires approval", func() {
+				namespaceAnnotate := func(value string) {
+					namespace := &corev1.Namespace{}
+					Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Name: DRPCNamespaceName},
+						namespace)).To(Succeed())
+					namespace.SetAnnotations(map[string]string{rmn.NamespaceActionApprovalRequiredAnnotation: value})
+					Expect(k8sClient.Update(context.TODO(), namespace)).To(Succeed())
+				}
+				drpcAnnotate := func(annotations map[string]string) {
+					Eventually(func() error {
+						latestDRPC := getLatestDRPC()
+						latestDRPC.SetAnnotations(annotations)
+						latestDRPC.Spec.Action = rmn.ActionFailover
+						latestDRPC.Spec.FailoverCluster = WestManagedCluster
+
+						return k8sClient.Update(context.TODO(), latestDRPC)
+					}, timeout, interval).Should(Succeed())
+				}
+				approvalExpect := func(state rmn.ActionApprovalState, message string) {
+					Eventually(func(g Gomega) {
+						approval := getLatestDRPC().Status.ActionApproval
+						g.Expect(approval).ToNot(BeNil())
+						g.Expect(approval.State).To(Equal(state))
+						g.Expect(approval.Message).To(ContainSubstring(message))
+					}, timeout, interval).Should(Succeed())
+				}
+
+				// The admission webhook records the requester and the approver, but is
+				// not enabled in this test environment
+				namespaceAnnotate("true")
+				drpcAnnotate(map[string]string{rmn.DRPCActionRequesterAnnotation: "requester"})
+				approvalExpect(rmn.ActionApprovalPending, "requested by requester awaits approval")
+				verifyUserPlacementRuleDecisionUnchanged(userPlacementRule.Name, userPlacementRule.Namespace,
+					EastManagedCluster)
+
+				drpcAnnotate(map[string]string{
+					rmn.DRPCActionRequesterAnnotation: "requester",
+					rmn.DRPCActionApprovalAnnotation:  string(rmn.ActionApprovalApproved),
+					rmn.DRPCActionApproverAnnotation:  "requester",
+				})
+				approvalExpect(rmn.ActionApprovalPending, "may not approve or reject it")
+
+				drpcAnnotate(map[string]string{
+					rmn.DRPCActionRequesterAnnotation: "requester",
+					rmn.DRPCActionApprovalAnnotation:  string(rmn.ActionApprovalApproved),
+					rmn.DRPCActionApproverAnnotation:  "approver",
+				})
+				approvalExpect(rmn.ActionApprovalApproved, "Approved by approver")
+				namespaceAnnotate("false")
+			})
+			It("Should wait for EastManagedCluster to be fenced before failing over", func() {
+				// The DRCluster lacks the NetworkFence parameters, so it is not fenced
+				Eventually(getDRPCAvailableMessage, timeout, interval).Should(
+					ContainSubstring(fmt.Sprintf("waiting for cluster %s to be fenced", EastManagedCluster)))
+				Expect(getEastDRCluster().Spec.ClusterFence).To(Equal(rmn.ClusterFenceStateFenced))
+				verifyUserPlacementRuleDecisionUnchanged(userPlacementRule.Name, userPlacementRule.Namespace,
+					EastManagedCluster)
+
+				Eventually(func() error {
+					drcluster := getEastDRCluster()
+					drcluster.SetAnnotations(map[string]string{
+						controllers.DRClusterStorageDriverAnnotation:          "drpc.test.driver",
+						controllers.DRClusterStorageSecretNameAnnotation:      "drpc-test-secret",
+						controllers.DRClusterStorageSecretNamespaceAnnotation: "drpc-test",
+					})
+
+					return k8sClient.Update(context.TODO(), drcluster)
+				}, timeout, interval).Should(Succeed())
+				// Annotations are applied on the next fence recheck of the DRCluster
+				Eventually(func() rmn.ClusterFenceState {
+					return getEastDRCluster().Status.FenceState
+				}, 2*timeout, interval).Should(Equal(rmn.ClusterFenceStateFenced))
+				touchDRPC("fenced")
+			})
 			It("Should failover again to Secondary (WestManagedCluster)", func() {
 				// ----------------------------- FAILOVER TO SECONDARY --------------------------------------
 				By("\n\n*** Failover - 3\n\n")
@@ -976,7 +1329,12 @@ var _ = Describe("DRPlacementControl Reconciler", func() {
 			It("Should relocate to Primary (EastManagedCluster)", func() {
 				// ----------------------------- RELOCATION TO PRIMARY --------------------------------------
 				By("\n\n*** relocate 2\n\n")
+				Expect(getEastDRCluster().Spec.ClusterFence).To(Equal(rmn.ClusterFenceStateFenced))
 				relocateToPreferredCluster(userPlacementRule)
+				drcluster := getEastDRCluster()
+				Expect(drcluster.Spec.ClusterFence).To(Equal(rmn.ClusterFenceStateUnfenced))
+				Expect(drcluster.Status.FenceState).To(Equal(rmn.ClusterFenceStateUnfenced))
+				Expect(k8sClient.Delete(context.TODO(), drcluster)).To(Succeed())
 				Expect(getManifestWorkCount(EastManagedCluster)).Should(Equal(2)) // MWs for VRG+ROLES
 				Expect(getManifestWorkCount(WestManagedCluster)).Should(Equal(1)) // Roles MW
 
@@ -992,6 +1350,114 @@ var _ = Describe("DRPlacementControl Reconciler", func() {
 				Expect(userPlacementRule.Status.Decisions[0].ClusterName).To(Equal(EastManagedCluster))
 			})
 		})
+		When("WestManagedCluster reports the VRG as Primary too after relocation", func() {
+			It("Should report a split-brain, and not resolve it until a winner is set", func() {
+				copyVRGManifestWork(EastManagedCluster, WestManagedCluster)
+				touchDRPC("split-brain")
+				Eventually(getDRPCSplitBrainReason, timeout, interval).Should(Equal(rmn.ReasonMultiplePrimaries))
+				drpc = getLatestDRPC()
+				Expect(drpc.Status.SplitBrainPrimaries).To(ConsistOf(EastManagedCluster, WestManagedCluster))
+				Expect(drpc.Status.SplitBrainResolution).To(BeNil())
+				Consistently(func() rmn.ReplicationState {
+					return getVRGManifestWorkReplicationState(WestManagedCluster)
+				}, timeout/5, interval).Should(Equal(rmn.Primary))
+			})
+			It("Should demote the VRG of WestManagedCluster once EastManagedCluster is set as the winner", func() {
+				setDRPCSplitBrainWinner(EastManagedCluster)
+				Eventually(func() rmn.ReplicationState {
+					return getVRGManifestWorkReplicationState(WestManagedCluster)
+				}, timeout, interval).Should(Equal(rmn.Secondary))
+				Expect(getVRGManifestWorkReplicationState(EastManagedCluster)).To(Equal(rmn.Primary))
+				drpc = getLatestDRPC()
+				Expect(drpc.Status.SplitBrainResolution).NotTo(BeNil())
+				Expect(drpc.Status.SplitBrainResolution.Winner).To(Equal(EastManagedCluster))
+				Expect(drpc.Status.SplitBrainResolution.Demoted).To(ConsistOf(WestManagedCluster))
+				verifyUserPlacementRuleDecision(userPlacementRule.Name, userPlacementRule.Namespace, EastManagedCluster)
+			})
+			It("Should clear the winner once the split-brain is resolved", func() {
+				Eventually(func() string {
+					return getLatestDRPC().Spec.SplitBrainWinner
+				}, timeout, interval).Should(BeEmpty())
+				Eventually(getDRPCSplitBrainReason, timeout, interval).Should(Equal(rmn.ReasonSinglePrimary))
+				waitForVRGMWDeletion(WestManagedCluster)
+				waitForUpdateDRPCStatus()
+			})
+		})
+		When("A split-brain winner is set while there is no split-brain", func() {
+			It("Should clear it, and not resolve a later split-brain with it", func() {
+				setDRPCSplitBrainWinner(WestManagedCluster)
+				Eventually(func() string {
+					return getLatestDRPC().Spec.SplitBrainWinner
+				}, timeout, interval).Should(BeEmpty())
+				waitForUpdateDRPCStatus()
+				copyVRGManifestWork(EastManagedCluster, WestManagedCluster)
+				touchDRPC("split-brain-again")
+				Eventually(getDRPCSplitBrainReason, timeout, interval).Should(Equal(rmn.ReasonMultiplePrimaries))
+				Consistently(func() bool {
+					return getVRGManifestWorkReplicationState(EastManagedCluster) == rmn.Primary &&
+						getVRGManifestWorkReplicationState(WestManagedCluster) == rmn.Primary
+				}, timeout/5, interval).Should(BeTrue())
+				verifyUserPlacementRuleDecisionUnchanged(userPlacementRule.Name, userPlacementRule.Namespace,
+					EastManagedCluster)
+			})
+			It("Should report the end of the split-brain once WestManagedCluster no longer reports the VRG", func() {
+				Expect(k8sClient.Delete(context.TODO(), &ocmworkv1.ManifestWork{ObjectMeta: metav1.ObjectMeta{
+					Name:      rmnutil.ManifestWorkName(DRPCName, DRPCNamespaceName, "vrg"),
+					Namespace: WestManagedCluster,
+				}})).To(Succeed())
+				touchDRPC("split-brain-ended")
+				Eventually(getDRPCSplitBrainReason, timeout, interval).Should(Equal(rmn.ReasonSinglePrimary))
+			})
+		})
+		When("A failover is cancelled before the VRG of WestManagedCluster reports Primary", func() {
+			It("Should roll the failover back to EastManagedCluster", func() {
+				By("\n\n*** Failover - cancelled\n\n")
+				restorePVs = false
+				setDRPCSpecExpectationTo(rmn.ActionFailover)
+				Eventually(func() rmn.ReplicationState {
+					return getVRGManifestWorkReplicationState(WestManagedCluster)
+				}, timeout, interval).Should(Equal(rmn.Primary))
+				setDRPCCancelAction(true)
+				Eventually(func() rmn.ActionCancellationState {
+					return getDRPCActionCancellation().State
+				}, timeout, interval).Should(Equal(rmn.ActionCancellationRolledBack))
+				Expect(getDRPCActionCancellation().SourceCluster).To(Equal(EastManagedCluster))
+				waitForVRGMWDeletion(WestManagedCluster)
+				Expect(getVRGManifestWorkReplicationState(EastManagedCluster)).To(Equal(rmn.Primary))
+				verifyUserPlacementRuleDecisionUnchanged(userPlacementRule.Name, userPlacementRule.Namespace,
+					EastManagedCluster)
+				Expect(getLatestDRPC().Status.Phase).To(Equal(rmn.Relocated))
+			})
+		})
+		When("The failover is started again, and cancelled once the VRG of WestManagedCluster reports Primary", func() {
+			It("Should refuse the cancellation", func() {
+				setDRPCCancelAction(false)
+				updateManifestWorkStatus(WestManagedCluster, "vrg", ocmworkv1.WorkApplied)
+				Eventually(func() rmn.DRState {
+					return getLatestDRPC().Status.Phase
+				}, timeout, interval).Should(Equal(rmn.FailingOver))
+				setDRPCCancelAction(true)
+				Eventually(func() rmn.ActionCancellationState {
+					return getDRPCActionCancellation().State
+				}, timeout, interval).Should(Equal(rmn.ActionCancellationRefused))
+				Expect(getDRPCActionCancellation().Message).To(ContainSubstring(
+					fmt.Sprintf("the VRG on cluster %s already reports Primary", WestManagedCluster)))
+				Expect(getVRGManifestWorkReplicationState(WestManagedCluster)).To(Equal(rmn.Primary))
+			})
+			It("Should proceed with the failover, and place the application on WestManagedCluster", func() {
+				restorePVs = true
+				verifyUserPlacementRuleDecision(userPlacementRule.Name, userPlacementRule.Namespace, WestManagedCluster)
+				waitForVRGMWDeletion(EastManagedCluster)
+				Eventually(func() rmn.DRState {
+					return getLatestDRPC().Status.Phase
+				}, timeout, interval).Should(Equal(rmn.FailedOver))
+				Expect(getDRPCActionCancellation().State).To(Equal(rmn.ActionCancellationRefused))
+			})
+			It("Should relocate back to EastManagedCluster once the cancellation is cleared", func() {
+				setDRPCCancelAction(false)
+				relocateToPreferredCluster(userPlacementRule)
+			})
+		})
 
 		When("Deleting user PlacementRule", func() {
 			It("Should cleanup DRPC", func() {
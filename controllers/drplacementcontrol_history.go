@@ -0,0 +1,114 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// actionHistoryLength is the number of failovers and relocations a DRPC keeps
+// in its action history
+const actionHistoryLength = 10
+
+// actionRecordStart records the start of an action in the action history,
+// failing the action in progress, if any, as superseded by it
+func (d *DRPCInstance) actionRecordStart(origin *rmn.ActionOrigin) {
+	if record := d.actionRecord(); record != nil {
+		d.actionRecordEnd(rmn.ActionFailed)
+
+		record.FailureReason = fmt.Sprintf("superseded by %s to cluster %s", origin.Action, origin.TargetCluster)
+	}
+
+	history := append(d.instance.Status.ActionHistory, rmn.ActionRecord{
+		Action:        origin.Action,
+		SourceCluster: origin.SourceCluster,
+		TargetCluster: origin.TargetCluster,
+		StartTime:     origin.StartTime,
+		Outcome:       rmn.ActionInProgress,
+	})
+
+	if len(history) > actionHistoryLength {
+		history = history[len(history)-actionHistoryLength:]
+	}
+
+	d.instance.Status.ActionHistory = history
+	d.needStatusUpdate = true
+}
+
+// actionRecord returns the record of the action in progress, if any
+func (d *DRPCInstance) actionRecord() *rmn.ActionRecord {
+	history := d.instance.Status.ActionHistory
+	if len(history) == 0 || history[len(history)-1].Outcome != rmn.ActionInProgress {
+		return nil
+	}
+
+	return &history[len(history)-1]
+}
+
+// actionRecordTimeSet records the time of a phase of the action in progress,
+// unless already recorded
+func (d *DRPCInstance) actionRecordTimeSet(phaseTime **metav1.Time) {
+	if *phaseTime != nil {
+		return
+	}
+
+	now := metav1.Now()
+	*phaseTime = &now
+	d.needStatusUpdate = true
+}
+
+// actionRecordEnd records the end of the action in progress, and its outcome
+func (d *DRPCInstance) actionRecordEnd(outcome rmn.ActionOutcome) {
+	record := d.actionRecord()
+	if record == nil {
+		return
+	}
+
+	now := metav1.Now()
+	record.EndTime = &now
+	record.Outcome = outcome
+
+	if outcome != rmn.ActionFailed {
+		record.FailureReason = ""
+	}
+
+	d.needStatusUpdate = true
+}
+
+// actionRecordError records the last error of the action in progress
+func (d *DRPCInstance) actionRecordError(err error) {
+	record := d.actionRecord()
+	if record == nil || record.FailureReason == err.Error() {
+		return
+	}
+
+	record.FailureReason = err.Error()
+	d.needStatusUpdate = true
+}
+
+// actionRecordCleanupComplete records the completion of the clean up of the
+// secondaries of the action in progress, which completes it
+func (d *DRPCInstance) actionRecordCleanupComplete() {
+	if record := d.actionRecord(); record != nil {
+		d.actionRecordTimeSet(&record.CleanupCompleteTime)
+		d.actionRecordEnd(rmn.ActionSucceeded)
+	}
+}
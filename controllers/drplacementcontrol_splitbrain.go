@@ -0,0 +1,265 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/controllers/util"
+)
+
+// A split-brain is more than one cluster reporting the VRG of a DRPC as
+// Primary.  It is reported in the SplitBrain condition of the DRPC, with the
+// replication state of the PVCs of each Primary VRG, and is resolved once the
+// administrator sets the split-brain winner: the VRGs of the other clusters
+// are demoted to Secondary, and resynced from the winner in Async replication
+// mode.  The winner answers only the split-brain it is set for: it is cleared
+// once at most one cluster reports the VRG as Primary, so that it does not
+// resolve a later split-brain.  A failover or relocation has more than one
+// Primary VRG until it cleans up its secondaries, hence no split-brain is
+// detected while one is in progress.
+
+// processSplitBrain reports a split-brain, if any, and resolves it if a winner
+// is set.  It returns true if the resolution is in progress, in which case the
+// placement is not processed any further.
+func (d *DRPCInstance) processSplitBrain() (bool, error) {
+	const resolving = true
+
+	if d.isInProgressingPhase() || d.actionRecord() != nil {
+		return !resolving, nil
+	}
+
+	primaries := d.primaryVRGClusters()
+	if len(primaries) <= 1 {
+		d.splitBrainClear()
+
+		return !resolving, d.splitBrainWinnerClear()
+	}
+
+	d.splitBrainStatusSet(primaries)
+
+	winner := d.instance.Spec.SplitBrainWinner
+	if winner == "" {
+		msg := fmt.Sprintf("clusters %s report the VRG as Primary; set spec.splitBrainWinner to resolve",
+			strings.Join(primaries, ", "))
+		d.log.Info(msg)
+		d.setDRPCCondition(&d.instance.Status.Conditions, rmn.ConditionSplitBrain, d.instance.Generation,
+			metav1.ConditionTrue, rmn.ReasonMultiplePrimaries, msg)
+		rmnutil.ReportIfNotPresent(d.reconciler.eventRecorder, d.instance, corev1.EventTypeWarning,
+			rmnutil.EventReasonSplitBrainDetected, msg)
+
+		return !resolving, nil
+	}
+
+	if !clusterListContains(primaries, winner) {
+		d.setDRPCCondition(&d.instance.Status.Conditions, rmn.ConditionSplitBrain, d.instance.Generation,
+			metav1.ConditionTrue, rmn.ReasonMultiplePrimaries,
+			fmt.Sprintf("clusters %s report the VRG as Primary", strings.Join(primaries, ", ")))
+
+		return resolving, d.refuseAction(fmt.Sprintf("split-brain winner %s is not one of the Primary clusters %s",
+			winner, strings.Join(primaries, ", ")))
+	}
+
+	return resolving, d.resolveSplitBrain(winner, primaries)
+}
+
+// resolveSplitBrain demotes the VRGs of the Primary clusters other than the
+// winner, places the application on the winner, and records the resolution
+func (d *DRPCInstance) resolveSplitBrain(winner string, primaries []string) error {
+	resyncRequestID := ""
+	if d.drPolicy.Spec.ReplicationMode != rmn.ReplicationModeSync {
+		resyncRequestID = fmt.Sprintf("split-brain-%d", d.instance.Generation)
+	}
+
+	demoted := []string{}
+
+	for _, clusterName := range primaries {
+		if clusterName == winner {
+			continue
+		}
+
+		if err := d.demoteVRGWithResync(clusterName, resyncRequestID); err != nil {
+			return err
+		}
+
+		demoted = append(demoted, clusterName)
+	}
+
+	if err := d.updateUserPlacementRule(winner, winner); err != nil {
+		return err
+	}
+
+	resolution := d.instance.Status.SplitBrainResolution
+	if resolution == nil || resolution.Winner != winner || resolution.ResyncRequestID != resyncRequestID ||
+		!reflect.DeepEqual(resolution.Demoted, demoted) {
+		d.instance.Status.SplitBrainResolution = &rmn.SplitBrainResolution{
+			Winner:          winner,
+			Demoted:         demoted,
+			ResyncRequestID: resyncRequestID,
+			Time:            metav1.Now(),
+		}
+		d.needStatusUpdate = true
+	}
+
+	msg := fmt.Sprintf("split-brain resolved in favor of cluster %s; VRGs of clusters %s demoted to Secondary",
+		winner, strings.Join(demoted, ", "))
+	d.log.Info(msg, "resyncRequestID", resyncRequestID)
+	d.setDRPCCondition(&d.instance.Status.Conditions, rmn.ConditionSplitBrain, d.instance.Generation,
+		metav1.ConditionTrue, rmn.ReasonSplitBrainResolving, msg)
+	rmnutil.ReportIfNotPresent(d.reconciler.eventRecorder, d.instance, corev1.EventTypeNormal,
+		rmnutil.EventReasonSplitBrainResolved, msg)
+
+	return nil
+}
+
+// demoteVRGWithResync updates the VRG ManifestWork of a cluster to Secondary,
+// requesting a resync of all its PVCs if a resync request ID is given
+func (d *DRPCInstance) demoteVRGWithResync(clusterName, resyncRequestID string) error {
+	vrgMWName := d.mwu.BuildManifestWorkName(rmnutil.MWTypeVRG)
+
+	mw, err := d.mwu.FindManifestWork(vrgMWName, clusterName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to demote VRG for %s, in namespace %s (%w)", vrgMWName, clusterName, err)
+	}
+
+	vrg, err := d.extractVRGFromManifestWork(mw)
+	if err != nil {
+		return err
+	}
+
+	if vrg.Spec.ReplicationState == rmn.Secondary &&
+		(resyncRequestID == "" || (vrg.Spec.Resync != nil && vrg.Spec.Resync.RequestID == resyncRequestID)) {
+		return nil
+	}
+
+	vrg.Spec.ReplicationState = rmn.Secondary
+	if resyncRequestID != "" {
+		vrg.Spec.Resync = &rmn.ResyncRequest{RequestID: resyncRequestID}
+	}
+
+	vrgClientManifest, err := d.mwu.GenerateManifest(vrg)
+	if err != nil {
+		return fmt.Errorf("failed to generate VRG manifest (%w)", err)
+	}
+
+	mw.Spec.Workload.Manifests[0] = *vrgClientManifest
+
+	if err := d.reconciler.Update(d.ctx, mw); err != nil {
+		return fmt.Errorf("failed to update MW (%w)", err)
+	}
+
+	d.log.Info("Demoted split-brain VRG to secondary", "cluster", clusterName, "resyncRequestID", resyncRequestID)
+
+	return nil
+}
+
+// primaryVRGClusters returns the sorted names of the clusters that report the
+// VRG as Primary
+func (d *DRPCInstance) primaryVRGClusters() []string {
+	primaries := []string{}
+
+	for clusterName, vrg := range d.vrgs {
+		if d.isVRGPrimary(vrg) {
+			primaries = append(primaries, clusterName)
+		}
+	}
+
+	sort.Strings(primaries)
+
+	return primaries
+}
+
+// splitBrainStatusSet records the Primary clusters of a split-brain, and the
+// replication state of the PVCs of their VRGs
+func (d *DRPCInstance) splitBrainStatusSet(primaries []string) {
+	pvcs := []rmn.SplitBrainPVC{}
+
+	for _, clusterName := range primaries {
+		for _, protectedPVC := range d.vrgs[clusterName].Status.ProtectedPVCs {
+			pvc := rmn.SplitBrainPVC{ClusterName: clusterName, Name: protectedPVC.Name}
+
+			if condition := findCondition(protectedPVC.Conditions, VRGConditionTypeDataReady); condition != nil {
+				pvc.DataReady = condition.Status
+			}
+
+			if condition := findCondition(protectedPVC.Conditions, VRGConditionTypeDataProtected); condition != nil {
+				pvc.DataProtected = condition.Status
+			}
+
+			pvcs = append(pvcs, pvc)
+		}
+	}
+
+	if !reflect.DeepEqual(d.instance.Status.SplitBrainPrimaries, primaries) ||
+		!reflect.DeepEqual(d.instance.Status.SplitBrainPVCs, pvcs) {
+		d.instance.Status.SplitBrainPrimaries = primaries
+		d.instance.Status.SplitBrainPVCs = pvcs
+		d.needStatusUpdate = true
+	}
+}
+
+// splitBrainWinnerClear clears the split-brain winner, once there is no
+// split-brain for it to resolve
+func (d *DRPCInstance) splitBrainWinnerClear() error {
+	winner := d.instance.Spec.SplitBrainWinner
+	if winner == "" {
+		return nil
+	}
+
+	drpc := d.instance.DeepCopy()
+	drpc.Spec.SplitBrainWinner = ""
+
+	if err := d.reconciler.Update(d.ctx, drpc); err != nil {
+		return fmt.Errorf("failed to clear split-brain winner %s (%w)", winner, err)
+	}
+
+	d.instance.Spec.SplitBrainWinner = ""
+	d.instance.ObjectMeta.ResourceVersion = drpc.ObjectMeta.ResourceVersion
+	d.instance.ObjectMeta.Generation = drpc.ObjectMeta.Generation
+
+	d.log.Info("Cleared split-brain winner, as at most one cluster reports the VRG as Primary", "winner", winner)
+
+	return nil
+}
+
+// splitBrainClear reports the end of a split-brain, if one was reported
+func (d *DRPCInstance) splitBrainClear() {
+	if findCondition(d.instance.Status.Conditions, rmn.ConditionSplitBrain) == nil {
+		return
+	}
+
+	if d.instance.Status.SplitBrainPrimaries != nil || d.instance.Status.SplitBrainPVCs != nil {
+		d.instance.Status.SplitBrainPrimaries = nil
+		d.instance.Status.SplitBrainPVCs = nil
+		d.needStatusUpdate = true
+	}
+
+	d.setDRPCCondition(&d.instance.Status.Conditions, rmn.ConditionSplitBrain, d.instance.Generation,
+		metav1.ConditionFalse, rmn.ReasonSinglePrimary, "at most one cluster reports the VRG as Primary")
+}
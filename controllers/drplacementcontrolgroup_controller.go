@@ -0,0 +1,482 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers/util"
+)
+
+// DRPlacementControlGroupReconciler reconciles a DRPlacementControlGroup object
+type DRPlacementControlGroupReconciler struct {
+	client.Client
+	APIReader     client.Reader
+	Log           logr.Logger
+	eventRecorder *util.EventReporter
+}
+
+//nolint:lll
+//+kubebuilder:rbac:groups=ramendr.openshift.io,resources=drplacementcontrolgroups,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=ramendr.openshift.io,resources=drplacementcontrolgroups/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=ramendr.openshift.io,resources=drplacementcontrolgroups/finalizers,verbs=update
+// +kubebuilder:rbac:groups=ramendr.openshift.io,resources=drplacementcontrols,verbs=get;list;watch;update
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile starts the action of a DRPlacementControlGroup once its action or
+// target cluster changes, and performs it on the members wave by wave: the
+// action is requested from the members of a wave once the members of the
+// preceding waves have completed it and are available
+func (r *DRPlacementControlGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("name", req.NamespacedName.Name)
+	log.Info("reconcile enter")
+
+	defer log.Info("reconcile exit")
+
+	group := &ramen.DRPlacementControlGroup{}
+	if err := r.Client.Get(ctx, req.NamespacedName, group); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(fmt.Errorf("get: %w", err))
+	}
+
+	if !group.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	status := group.Status.DeepCopy()
+
+	switch {
+	case group.Spec.Action == "":
+		r.stop(group)
+	case group.Status.Action != group.Spec.Action || group.Status.TargetCluster != group.Spec.TargetCluster:
+		r.start(group, log)
+	}
+
+	if group.Status.Phase == ramen.DRPlacementControlGroupRunning {
+		r.progress(ctx, group)
+
+		if err := r.waveStart(ctx, group, log); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		r.phaseSet(group)
+	}
+
+	if reflect.DeepEqual(status, &group.Status) {
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.Client.Status().Update(ctx, group); err != nil {
+		return ctrl.Result{}, fmt.Errorf("status update: %w", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// start starts the action of the group, with all its members pending, in
+// order of their waves
+func (r *DRPlacementControlGroupReconciler) start(group *ramen.DRPlacementControlGroup, log logr.Logger) {
+	members := make([]ramen.DRPlacementControlGroupMemberStatus, 0, len(group.Spec.Members))
+
+	for _, member := range group.Spec.Members {
+		members = append(members, ramen.DRPlacementControlGroupMemberStatus{
+			Name:      member.Name,
+			Namespace: member.Namespace,
+			Wave:      member.Wave,
+			State:     ramen.DRPlacementControlGroupMemberPending,
+		})
+	}
+
+	sort.SliceStable(members, func(i, j int) bool {
+		return members[i].Wave < members[j].Wave
+	})
+
+	log.Info("action started", "action", group.Spec.Action, "targetCluster", group.Spec.TargetCluster)
+
+	now := metav1.Now()
+	group.Status = ramen.DRPlacementControlGroupStatus{
+		Action:        group.Spec.Action,
+		TargetCluster: group.Spec.TargetCluster,
+		Phase:         ramen.DRPlacementControlGroupRunning,
+		Members:       members,
+		StartTime:     &now,
+	}
+}
+
+// stop stops the action of the group once its action is cleared, so that no
+// further member is requested to perform it, and so that it may be started
+// again
+func (r *DRPlacementControlGroupReconciler) stop(group *ramen.DRPlacementControlGroup) {
+	if group.Status.Action == "" {
+		return
+	}
+
+	if group.Status.Phase == ramen.DRPlacementControlGroupRunning {
+		for idx := range group.Status.Members {
+			member := &group.Status.Members[idx]
+			if member.State == ramen.DRPlacementControlGroupMemberPending ||
+				member.State == ramen.DRPlacementControlGroupMemberInProgress {
+				drpcGroupMemberComplete(member, ramen.DRPlacementControlGroupMemberSkipped, "action stopped")
+			}
+		}
+
+		now := metav1.Now()
+		group.Status.Phase = ramen.DRPlacementControlGroupFailed
+		group.Status.Message = fmt.Sprintf("%s to cluster %s stopped at wave %d, as the action is cleared",
+			group.Status.Action, group.Status.TargetCluster, group.Status.CurrentWave)
+		group.Status.CompletionTime = &now
+
+		util.ReportIfNotPresent(r.eventRecorder, group, corev1.EventTypeWarning, util.EventReasonGroupActionFailed,
+			group.Status.Message)
+	}
+
+	group.Status.Action = ""
+	group.Status.TargetCluster = ""
+}
+
+// progress follows the actions of the members in progress in the action
+// history of their DRPCs, and records their outcome once they are no longer in
+// progress and, if they succeeded, once the DRPCs are available
+func (r *DRPlacementControlGroupReconciler) progress(ctx context.Context, group *ramen.DRPlacementControlGroup) {
+	action, targetCluster := group.Status.Action, group.Status.TargetCluster
+
+	for idx := range group.Status.Members {
+		member := &group.Status.Members[idx]
+		if member.State != ramen.DRPlacementControlGroupMemberInProgress {
+			continue
+		}
+
+		drpc, err := r.drpcGet(ctx, member)
+		if err != nil {
+			member.Message = err.Error()
+
+			continue
+		}
+
+		switch {
+		case drpc == nil:
+			drpcGroupMemberComplete(member, ramen.DRPlacementControlGroupMemberFailed, "DRPlacementControl not found")
+		case !drpcActionMatches(drpc, action, targetCluster):
+			drpcGroupMemberComplete(member, ramen.DRPlacementControlGroupMemberFailed,
+				fmt.Sprintf("superseded by %s to cluster %s", drpc.Spec.Action, drpcActionTargetCluster(drpc)))
+		case drpcActionAvailable(drpc):
+			drpcGroupMemberComplete(member, ramen.DRPlacementControlGroupMemberSucceeded,
+				fmt.Sprintf("DRPlacementControl %s to cluster %s, and available", drpc.Status.Phase, targetCluster))
+		default:
+			drpcGroupMemberProgress(member, drpc, action, targetCluster)
+		}
+	}
+}
+
+// drpcGroupMemberProgress records the progress of the action of a member from
+// the action history of its DRPC, or its approval while it is yet to start
+func drpcGroupMemberProgress(member *ramen.DRPlacementControlGroupMemberStatus, drpc *ramen.DRPlacementControl,
+	action ramen.DRAction, targetCluster string,
+) {
+	record := drpcActionRecord(drpc, action, targetCluster, *member.StartTime)
+	if record == nil {
+		approval := drpcActionApproval(drpc, action, targetCluster, *member.StartTime)
+
+		switch {
+		case approval == nil:
+			member.Message = "waiting for the DRPlacementControl to start the action"
+		case approval.State == ramen.ActionApprovalRejected || approval.State == ramen.ActionApprovalExpired:
+			drpcGroupMemberComplete(member, ramen.DRPlacementControlGroupMemberFailed, approval.Message)
+		case approval.State == ramen.ActionApprovalPending:
+			member.Message = approval.Message
+		default:
+			member.Message = "action approved, waiting for the DRPlacementControl to start it"
+		}
+
+		return
+	}
+
+	switch record.Outcome {
+	case ramen.ActionCancelled:
+		drpcGroupMemberComplete(member, ramen.DRPlacementControlGroupMemberFailed, "action cancelled")
+	case ramen.ActionFailed:
+		drpcGroupMemberComplete(member, ramen.DRPlacementControlGroupMemberFailed, record.FailureReason)
+	default:
+		member.Message = fmt.Sprintf("DRPlacementControl phase %s, waiting for it to be available",
+			drpc.Status.Phase)
+		if record.FailureReason != "" {
+			member.Message += ": " + record.FailureReason
+		}
+	}
+}
+
+// waveStart requests the action from the pending members of the current wave,
+// which is the first wave with members pending or in progress, unless the
+// action halts on the failure of a member.  A wave whose members all complete
+// at once, as they fail to start, is followed by the next.
+func (r *DRPlacementControlGroupReconciler) waveStart(ctx context.Context, group *ramen.DRPlacementControlGroup,
+	log logr.Logger,
+) error {
+	for {
+		if drpcGroupHalted(group) {
+			for idx := range group.Status.Members {
+				member := &group.Status.Members[idx]
+				if member.State == ramen.DRPlacementControlGroupMemberPending {
+					drpcGroupMemberComplete(member, ramen.DRPlacementControlGroupMemberSkipped,
+						"action halted, as it failed for a member")
+				}
+			}
+
+			return nil
+		}
+
+		wave, found := drpcGroupCurrentWave(group)
+		if !found {
+			return nil
+		}
+
+		group.Status.CurrentWave = wave
+		inProgress := false
+
+		for idx := range group.Status.Members {
+			member := &group.Status.Members[idx]
+			if member.Wave != wave {
+				continue
+			}
+
+			if member.State == ramen.DRPlacementControlGroupMemberPending {
+				if err := r.memberStart(ctx, group, member, log); err != nil {
+					return err
+				}
+			}
+
+			inProgress = inProgress || member.State == ramen.DRPlacementControlGroupMemberInProgress
+		}
+
+		if inProgress {
+			return nil
+		}
+	}
+}
+
+// memberStart requests the action from the DRPC of the member, unless it has
+// already completed it
+func (r *DRPlacementControlGroupReconciler) memberStart(ctx context.Context, group *ramen.DRPlacementControlGroup,
+	member *ramen.DRPlacementControlGroupMemberStatus, log logr.Logger,
+) error {
+	action, targetCluster := group.Status.Action, group.Status.TargetCluster
+
+	drpc, err := r.drpcGet(ctx, member)
+	if err != nil {
+		return err
+	}
+
+	if drpc == nil {
+		drpcGroupMemberComplete(member, ramen.DRPlacementControlGroupMemberFailed, "DRPlacementControl not found")
+
+		return nil
+	}
+
+	now := metav1.Now()
+	member.State = ramen.DRPlacementControlGroupMemberInProgress
+	member.StartTime = &now
+
+	if drpcActionMatches(drpc, action, targetCluster) {
+		member.Message = "action already requested from the DRPlacementControl"
+
+		return nil
+	}
+
+	drpcActionSet(drpc, action, targetCluster)
+
+	if err := r.Client.Update(ctx, drpc); err != nil {
+		return fmt.Errorf("drpc %s update: %w", drpcNamespacedName(drpc), err)
+	}
+
+	msg := fmt.Sprintf("%s of DRPlacementControl %s to cluster %s requested by DRPlacementControlGroup %s, wave %d",
+		action, drpcNamespacedName(drpc), targetCluster, group.Name, member.Wave)
+	log.Info(msg)
+	util.ReportIfNotPresent(r.eventRecorder, drpc, corev1.EventTypeNormal, util.EventReasonGroupActionStarted, msg)
+
+	member.Message = "action requested from the DRPlacementControl"
+
+	return nil
+}
+
+// phaseSet records the outcome of the action once no member is pending or in
+// progress
+func (r *DRPlacementControlGroupReconciler) phaseSet(group *ramen.DRPlacementControlGroup) {
+	counts := map[ramen.DRPlacementControlGroupMemberState]int{}
+	for _, member := range group.Status.Members {
+		counts[member.State]++
+	}
+
+	if counts[ramen.DRPlacementControlGroupMemberPending] != 0 ||
+		counts[ramen.DRPlacementControlGroupMemberInProgress] != 0 {
+		group.Status.Message = fmt.Sprintf("wave %d in progress; %d of %d members completed",
+			group.Status.CurrentWave, counts[ramen.DRPlacementControlGroupMemberSucceeded]+
+				counts[ramen.DRPlacementControlGroupMemberFailed], len(group.Status.Members))
+
+		return
+	}
+
+	now := metav1.Now()
+	group.Status.CompletionTime = &now
+
+	eventType, reason := corev1.EventTypeNormal, util.EventReasonGroupActionSucceeded
+	group.Status.Phase = ramen.DRPlacementControlGroupSucceeded
+	group.Status.Message = fmt.Sprintf("%s of %d members to cluster %s succeeded", group.Status.Action,
+		len(group.Status.Members), group.Status.TargetCluster)
+
+	if failed := counts[ramen.DRPlacementControlGroupMemberFailed]; failed != 0 {
+		eventType, reason = corev1.EventTypeWarning, util.EventReasonGroupActionFailed
+		group.Status.Phase = ramen.DRPlacementControlGroupFailed
+		group.Status.Message = fmt.Sprintf("%s to cluster %s failed for %d of %d members", group.Status.Action,
+			group.Status.TargetCluster, failed, len(group.Status.Members))
+
+		if skipped := counts[ramen.DRPlacementControlGroupMemberSkipped]; skipped != 0 {
+			group.Status.Message += fmt.Sprintf(", and halted at wave %d, skipping %d members",
+				group.Status.CurrentWave, skipped)
+		}
+	}
+
+	util.ReportIfNotPresent(r.eventRecorder, group, eventType, reason, group.Status.Message)
+}
+
+// drpcGet returns the DRPC of the member, or nil if it is not found
+func (r *DRPlacementControlGroupReconciler) drpcGet(ctx context.Context,
+	member *ramen.DRPlacementControlGroupMemberStatus,
+) (*ramen.DRPlacementControl, error) {
+	drpc := &ramen.DRPlacementControl{}
+	if err := r.APIReader.Get(ctx, types.NamespacedName{Name: member.Name, Namespace: member.Namespace},
+		drpc); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("drpc %s/%s get: %w", member.Namespace, member.Name, err)
+	}
+
+	return drpc, nil
+}
+
+// drpcGroupHalted returns true if the action of the group halts on failure,
+// and has failed for a member
+func drpcGroupHalted(group *ramen.DRPlacementControlGroup) bool {
+	if group.Spec.FailurePolicy == ramen.DRPlacementControlGroupContinue {
+		return false
+	}
+
+	for _, member := range group.Status.Members {
+		if member.State == ramen.DRPlacementControlGroupMemberFailed {
+			return true
+		}
+	}
+
+	return false
+}
+
+// drpcGroupCurrentWave returns the first wave with members pending or in
+// progress, and whether there is one
+func drpcGroupCurrentWave(group *ramen.DRPlacementControlGroup) (int, bool) {
+	for _, member := range group.Status.Members {
+		if member.State == ramen.DRPlacementControlGroupMemberPending ||
+			member.State == ramen.DRPlacementControlGroupMemberInProgress {
+			return member.Wave, true
+		}
+	}
+
+	return 0, false
+}
+
+// drpcGroupMemberComplete records the outcome of the action of a member
+func drpcGroupMemberComplete(member *ramen.DRPlacementControlGroupMemberStatus,
+	state ramen.DRPlacementControlGroupMemberState, msg string,
+) {
+	now := metav1.Now()
+	member.State = state
+	member.Message = msg
+	member.CompletionTime = &now
+}
+
+// drpcActionAvailable returns true if the DRPC is in the final phase of its
+// action, and its Available condition is true for its current spec
+func drpcActionAvailable(drpc *ramen.DRPlacementControl) bool {
+	if !drpcActionFinalPhase(drpc) {
+		return false
+	}
+
+	condition := meta.FindStatusCondition(drpc.Status.Conditions, ramen.ConditionAvailable)
+
+	return condition != nil && condition.Status == metav1.ConditionTrue &&
+		condition.ObservedGeneration == drpc.Generation
+}
+
+// drpcGroupsMapFunc maps a DRPC to the running groups it is a member of
+func (r *DRPlacementControlGroupReconciler) drpcGroupsMapFunc(obj client.Object) []reconcile.Request {
+	groups := &ramen.DRPlacementControlGroupList{}
+	if err := r.Client.List(context.TODO(), groups); err != nil {
+		r.Log.Error(err, "drplacementcontrolgroups list")
+
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+
+	for idx := range groups.Items {
+		group := &groups.Items[idx]
+		if group.Status.Phase != ramen.DRPlacementControlGroupRunning {
+			continue
+		}
+
+		for _, member := range group.Status.Members {
+			if member.Name == obj.GetName() && member.Namespace == obj.GetNamespace() {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{Name: group.Name},
+				})
+
+				break
+			}
+		}
+	}
+
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.  Groups are
+// reconciled on changes to their members, including their status.
+func (r *DRPlacementControlGroupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.eventRecorder = util.NewEventReporter(mgr.GetEventRecorderFor("controller_DRPlacementControlGroup"))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ramen.DRPlacementControlGroup{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &ramen.DRPlacementControl{}},
+			handler.EnqueueRequestsFromMapFunc(r.drpcGroupsMapFunc)).
+		Complete(r)
+}
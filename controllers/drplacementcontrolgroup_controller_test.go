@@ -0,0 +1,214 @@
+package controllers_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("DRPlacementControlGroupController", func() {
+	When(`the first wave of a group names a DRPlacementControl that does not exist`, func() {
+		It(`should halt, skipping the following waves`, func() {
+			group := &ramen.DRPlacementControlGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: `drpcgroup-nodrpc`},
+				Spec: ramen.DRPlacementControlGroupSpec{
+					Members: []ramen.DRPlacementControlGroupMember{
+						{Name: `drpc-nonexistent`, Namespace: `default`},
+						{Name: `drpc-nonexistent-next`, Namespace: `default`, Wave: 1},
+					},
+					Action:        ramen.ActionFailover,
+					TargetCluster: `west`,
+					FailurePolicy: ramen.DRPlacementControlGroupHalt,
+				},
+			}
+			Expect(k8sClient.Create(context.TODO(), group)).To(Succeed())
+			Eventually(func(g Gomega) {
+				g.Expect(apiReader.Get(context.TODO(), types.NamespacedName{Name: group.Name}, group)).To(Succeed())
+				g.Expect(group.Status.Phase).To(Equal(ramen.DRPlacementControlGroupFailed))
+				g.Expect(group.Status.Members).To(HaveLen(2))
+				g.Expect(group.Status.Members[0].State).To(Equal(ramen.DRPlacementControlGroupMemberFailed))
+				g.Expect(group.Status.Members[1].State).To(Equal(ramen.DRPlacementControlGroupMemberSkipped))
+			}, 10, 0.25).Should(Succeed())
+			Expect(k8sClient.Delete(context.TODO(), group)).To(Succeed())
+		})
+	})
+	Context(`groups of DRPlacementControls`, func() {
+		drpcs := map[string]*ramen.DRPlacementControl{}
+		groupNew := func(name string, failurePolicy ramen.DRPlacementControlGroupFailurePolicy,
+			waves map[string]int,
+		) *ramen.DRPlacementControlGroup {
+			group := &ramen.DRPlacementControlGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec: ramen.DRPlacementControlGroupSpec{
+					Action:        ramen.ActionFailover,
+					TargetCluster: `drpcgroup-west`,
+					FailurePolicy: failurePolicy,
+				},
+			}
+			for drpcName, wave := range waves {
+				group.Spec.Members = append(group.Spec.Members,
+					ramen.DRPlacementControlGroupMember{Name: drpcName, Namespace: `default`, Wave: wave})
+			}
+
+			return group
+		}
+		haltGroup := groupNew(`drpcgroup-halt`, ramen.DRPlacementControlGroupHalt, map[string]int{
+			`drpcgroup-halt-wave0a`: 0, `drpcgroup-halt-wave0b`: 0, `drpcgroup-halt-wave1`: 1, `drpcgroup-halt-wave2`: 2,
+		})
+		continueGroup := groupNew(`drpcgroup-continue`, ramen.DRPlacementControlGroupContinue, map[string]int{
+			`drpcgroup-continue-wave0`: 0, `drpcgroup-continue-wave1`: 1,
+		})
+		memberState := func(group *ramen.DRPlacementControlGroup, name string,
+		) ramen.DRPlacementControlGroupMemberState {
+			Expect(apiReader.Get(context.TODO(), types.NamespacedName{Name: group.Name}, group)).To(Succeed())
+			for _, member := range group.Status.Members {
+				if member.Name == name {
+					return member.State
+				}
+			}
+
+			return ``
+		}
+		membersExpect := func(group *ramen.DRPlacementControlGroup, wave int,
+			states map[string]ramen.DRPlacementControlGroupMemberState,
+		) {
+			Eventually(func(g Gomega) {
+				g.Expect(apiReader.Get(context.TODO(), types.NamespacedName{Name: group.Name}, group)).To(Succeed())
+				g.Expect(group.Status.CurrentWave).To(Equal(wave))
+				for _, member := range group.Status.Members {
+					g.Expect(member.State).To(Equal(states[member.Name]), member.Name)
+				}
+			}, 10, 0.25).Should(Succeed())
+		}
+		drpcFailoverRequested := func(name string) bool {
+			drpc := actionDRPCGet(drpcs[name])
+
+			return drpc.Spec.Action == ramen.ActionFailover && drpc.Spec.FailoverCluster == `drpcgroup-west`
+		}
+		// memberSucceed fails the DRPC of the member over, and sets it available
+		// until the group observes it, as its controller resets its availability,
+		// since its PlacementRule does not exist
+		memberSucceed := func(group *ramen.DRPlacementControlGroup, name string) {
+			drpc := drpcs[name]
+			actionDRPCActionRecord(drpc, ramen.FailedOver, ramen.ActionSucceeded, ``)
+			Eventually(func() ramen.DRPlacementControlGroupMemberState {
+				state := memberState(group, name)
+				if state == ramen.DRPlacementControlGroupMemberSucceeded {
+					return state
+				}
+				if err := apiReader.Get(context.TODO(), types.NamespacedName{Name: drpc.Name, Namespace: drpc.Namespace},
+					drpc); err == nil {
+					meta.SetStatusCondition(&drpc.Status.Conditions, metav1.Condition{
+						Type:               ramen.ConditionAvailable,
+						Status:             metav1.ConditionTrue,
+						ObservedGeneration: drpc.Generation,
+						Reason:             string(ramen.FailedOver),
+						Message:            `failed over`,
+					})
+					_ = k8sClient.Status().Update(context.TODO(), drpc)
+				}
+
+				return state
+			}, 10, 0.25).Should(Equal(ramen.DRPlacementControlGroupMemberSucceeded))
+		}
+		Specify(`DRPlacementControls for the members of the groups`, func() {
+			for _, group := range []*ramen.DRPlacementControlGroup{haltGroup, continueGroup} {
+				for _, member := range group.Spec.Members {
+					drpcs[member.Name] = actionDRPCCreate(member.Name, `drpcgroup-drpolicy`, `drpcgroup-east`, nil)
+				}
+			}
+		})
+		When(`a group that halts on failure starts`, func() {
+			It(`should request the action from the members of the first wave alone`, func() {
+				Expect(k8sClient.Create(context.TODO(), haltGroup)).To(Succeed())
+				membersExpect(haltGroup, 0, map[string]ramen.DRPlacementControlGroupMemberState{
+					`drpcgroup-halt-wave0a`: ramen.DRPlacementControlGroupMemberInProgress,
+					`drpcgroup-halt-wave0b`: ramen.DRPlacementControlGroupMemberInProgress,
+					`drpcgroup-halt-wave1`:  ramen.DRPlacementControlGroupMemberPending,
+					`drpcgroup-halt-wave2`:  ramen.DRPlacementControlGroupMemberPending,
+				})
+				Expect(drpcFailoverRequested(`drpcgroup-halt-wave0a`)).To(BeTrue())
+				Expect(drpcFailoverRequested(`drpcgroup-halt-wave0b`)).To(BeTrue())
+				Expect(drpcFailoverRequested(`drpcgroup-halt-wave1`)).To(BeFalse())
+			})
+		})
+		When(`a member of the first wave succeeds while the other is in progress`, func() {
+			It(`should not start the next wave`, func() {
+				memberSucceed(haltGroup, `drpcgroup-halt-wave0a`)
+				Consistently(func() bool {
+					return drpcFailoverRequested(`drpcgroup-halt-wave1`)
+				}, 2, 0.25).Should(BeFalse())
+				Expect(memberState(haltGroup, `drpcgroup-halt-wave0b`)).To(
+					Equal(ramen.DRPlacementControlGroupMemberInProgress))
+			})
+		})
+		When(`all the members of the first wave succeed`, func() {
+			It(`should request the action from the members of the next wave`, func() {
+				memberSucceed(haltGroup, `drpcgroup-halt-wave0b`)
+				membersExpect(haltGroup, 1, map[string]ramen.DRPlacementControlGroupMemberState{
+					`drpcgroup-halt-wave0a`: ramen.DRPlacementControlGroupMemberSucceeded,
+					`drpcgroup-halt-wave0b`: ramen.DRPlacementControlGroupMemberSucceeded,
+					`drpcgroup-halt-wave1`:  ramen.DRPlacementControlGroupMemberInProgress,
+					`drpcgroup-halt-wave2`:  ramen.DRPlacementControlGroupMemberPending,
+				})
+				Expect(drpcFailoverRequested(`drpcgroup-halt-wave1`)).To(BeTrue())
+				Expect(drpcFailoverRequested(`drpcgroup-halt-wave2`)).To(BeFalse())
+			})
+		})
+		When(`the member of the second wave fails`, func() {
+			It(`should halt, skipping the last wave`, func() {
+				actionDRPCActionRecord(drpcs[`drpcgroup-halt-wave1`], ramen.FailingOver, ramen.ActionFailed,
+					`failover failed`)
+				membersExpect(haltGroup, 1, map[string]ramen.DRPlacementControlGroupMemberState{
+					`drpcgroup-halt-wave0a`: ramen.DRPlacementControlGroupMemberSucceeded,
+					`drpcgroup-halt-wave0b`: ramen.DRPlacementControlGroupMemberSucceeded,
+					`drpcgroup-halt-wave1`:  ramen.DRPlacementControlGroupMemberFailed,
+					`drpcgroup-halt-wave2`:  ramen.DRPlacementControlGroupMemberSkipped,
+				})
+				Expect(haltGroup.Status.Phase).To(Equal(ramen.DRPlacementControlGroupFailed))
+				Expect(haltGroup.Status.Message).To(Equal(`Failover to cluster drpcgroup-west failed for 1 of 4 members,` +
+					` and halted at wave 1, skipping 1 members`))
+				Expect(drpcFailoverRequested(`drpcgroup-halt-wave2`)).To(BeFalse())
+			})
+		})
+		When(`a member of the first wave of a group that continues on failure fails`, func() {
+			It(`should request the action from the members of the next wave`, func() {
+				Expect(k8sClient.Create(context.TODO(), continueGroup)).To(Succeed())
+				Eventually(func() bool {
+					return drpcFailoverRequested(`drpcgroup-continue-wave0`)
+				}, 10, 0.25).Should(BeTrue())
+				actionDRPCActionRecord(drpcs[`drpcgroup-continue-wave0`], ramen.FailingOver, ramen.ActionFailed,
+					`failover failed`)
+				membersExpect(continueGroup, 1, map[string]ramen.DRPlacementControlGroupMemberState{
+					`drpcgroup-continue-wave0`: ramen.DRPlacementControlGroupMemberFailed,
+					`drpcgroup-continue-wave1`: ramen.DRPlacementControlGroupMemberInProgress,
+				})
+				Expect(drpcFailoverRequested(`drpcgroup-continue-wave1`)).To(BeTrue())
+			})
+		})
+		When(`the member of the last wave of a group that continues on failure succeeds`, func() {
+			It(`should fail, as a member failed`, func() {
+				memberSucceed(continueGroup, `drpcgroup-continue-wave1`)
+				Eventually(func(g Gomega) {
+					g.Expect(apiReader.Get(context.TODO(), types.NamespacedName{Name: continueGroup.Name},
+						continueGroup)).To(Succeed())
+					g.Expect(continueGroup.Status.Phase).To(Equal(ramen.DRPlacementControlGroupFailed))
+					g.Expect(continueGroup.Status.Message).To(Equal(
+						`Failover to cluster drpcgroup-west failed for 1 of 2 members`))
+				}, 10, 0.25).Should(Succeed())
+			})
+		})
+		Specify(`cleanup`, func() {
+			Expect(k8sClient.Delete(context.TODO(), haltGroup)).To(Succeed())
+			Expect(k8sClient.Delete(context.TODO(), continueGroup)).To(Succeed())
+			for _, drpc := range drpcs {
+				Expect(k8sClient.Delete(context.TODO(), drpc)).To(Succeed())
+			}
+		})
+	})
+})
@@ -0,0 +1,330 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	spokeClusterV1 "github.com/open-cluster-management/api/cluster/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers/util"
+)
+
+// autoFailoverRateWindow is the window over which the automatic failovers of
+// a drpolicy are limited to its maximum number of failovers per hour
+const autoFailoverRateWindow = time.Hour
+
+// autoFailover fails over the DRPlacementControls of a drpolicy that opts in to
+// automatic failover, from each of its clusters that has been unavailable for
+// longer than the grace period, once the cluster is fenced.  Failovers are
+// limited to the maximum number per hour of the policy, and stopped altogether
+// if the Ramen config disables them.  It returns the time after which the
+// policy is to be rechecked, or zero if no recheck is due.
+func (r *DRPolicyReconciler) autoFailover(ctx context.Context, drpolicy *ramen.DRPolicy,
+	log logr.Logger,
+) (time.Duration, error) {
+	if drpolicy.Spec.AutoFailover == nil {
+		return 0, nil
+	}
+
+	var requeueAfter time.Duration
+
+	requeueAfterMin := func(duration time.Duration) {
+		if requeueAfter == 0 || duration < requeueAfter {
+			requeueAfter = duration
+		}
+	}
+
+	failoverTimes := autoFailoverTimesPrune(drpolicy.Status.AutoFailoverTimes, time.Now())
+
+	for _, clusterName := range util.DrpolicyClusterNames(drpolicy) {
+		unavailableFor, err := managedClusterUnavailableFor(ctx, r.APIReader, clusterName)
+		if err != nil {
+			return 0, err
+		}
+
+		if unavailableFor == 0 {
+			continue
+		}
+
+		if remaining := drpolicy.Spec.AutoFailover.UnavailableGracePeriod.Duration - unavailableFor; remaining > 0 {
+			requeueAfterMin(remaining)
+
+			continue
+		}
+
+		var (
+			clusterRequeueAfter  time.Duration
+			clusterFailoverTimes []metav1.Time
+		)
+
+		clusterRequeueAfter, clusterFailoverTimes, err = r.autoFailoverCluster(ctx, drpolicy, clusterName,
+			unavailableFor, failoverTimes, log)
+		failoverTimes = clusterFailoverTimes
+
+		if err != nil {
+			// the failovers made before the error count against the rate limit
+			if err := r.autoFailoverTimesUpdate(ctx, drpolicy, failoverTimes); err != nil {
+				log.Error(err, "auto failover times update")
+			}
+
+			return 0, err
+		}
+
+		if clusterRequeueAfter > 0 {
+			requeueAfterMin(clusterRequeueAfter)
+		}
+	}
+
+	if err := r.autoFailoverTimesUpdate(ctx, drpolicy, failoverTimes); err != nil {
+		return 0, err
+	}
+
+	return requeueAfter, nil
+}
+
+// autoFailoverTimesUpdate records the automatic failover times in the status
+// of the drpolicy, if they changed
+func (r *DRPolicyReconciler) autoFailoverTimesUpdate(ctx context.Context, drpolicy *ramen.DRPolicy,
+	failoverTimes []metav1.Time,
+) error {
+	if reflect.DeepEqual(failoverTimes, drpolicy.Status.AutoFailoverTimes) {
+		return nil
+	}
+
+	drpolicy.Status.AutoFailoverTimes = failoverTimes
+	if err := r.Client.Status().Update(ctx, drpolicy); err != nil {
+		return fmt.Errorf("auto failover times update: %w", err)
+	}
+
+	return nil
+}
+
+// autoFailoverCluster fences the unavailable cluster, and once it is fenced,
+// fails the DRPlacementControls it hosts over to a peer cluster, within the
+// rate limit of the drpolicy.  It returns the time after which the cluster is
+// to be rechecked, if any, and the updated failover times.
+func (r *DRPolicyReconciler) autoFailoverCluster(ctx context.Context, drpolicy *ramen.DRPolicy,
+	clusterName string, unavailableFor time.Duration, failoverTimes []metav1.Time, log logr.Logger,
+) (time.Duration, []metav1.Time, error) {
+	drpcs, err := autoFailoverDRPCs(ctx, r.APIReader, drpolicy.Name, clusterName)
+	if err != nil || len(drpcs) == 0 {
+		return 0, failoverTimes, err
+	}
+
+	unavailable := fmt.Sprintf("Cluster %s has been unavailable for %v", clusterName,
+		unavailableFor.Round(time.Second))
+
+	if disabled, err := autoFailoverDisabled(); disabled {
+		msg := unavailable + ", but automatic failover is disabled in the Ramen config"
+		if err != nil {
+			msg = fmt.Sprintf("%s, but automatic failover is held, as the Ramen config cannot be read: %v",
+				unavailable, err)
+		}
+
+		util.ReportIfNotPresent(r.eventRecorder, drpolicy, corev1.EventTypeWarning,
+			util.EventReasonAutoFailoverDisabled, msg)
+
+		return drpolicyHealthCheckInterval, failoverTimes, nil
+	}
+
+	fenced, err := r.autoFailoverFence(ctx, drpolicy, clusterName, unavailable)
+	if err != nil || !fenced {
+		return drclusterFenceRequeueInterval, failoverTimes, err
+	}
+
+	targetCluster, err := failoverTargetCluster(ctx, r.APIReader, drpolicy, clusterName)
+	if err != nil {
+		return 0, failoverTimes, err
+	}
+
+	if targetCluster == "" {
+		util.ReportIfNotPresent(r.eventRecorder, drpolicy, corev1.EventTypeWarning,
+			util.EventReasonAutoFailoverBlocked, unavailable+", but no peer cluster is available to fail over to")
+
+		return drpolicyHealthCheckInterval, failoverTimes, nil
+	}
+
+	for idx := range drpcs {
+		if len(failoverTimes) >= drpolicy.Spec.AutoFailover.MaxFailoversPerHour {
+			util.ReportIfNotPresent(r.eventRecorder, drpolicy, corev1.EventTypeWarning,
+				util.EventReasonAutoFailoverRateLimited,
+				fmt.Sprintf("%s; %d DRPlacementControls are not failed over, as the limit of %d failovers "+
+					"per hour is reached", unavailable, len(drpcs)-idx, drpolicy.Spec.AutoFailover.MaxFailoversPerHour))
+
+			return failoverTimes[0].Add(autoFailoverRateWindow).Sub(time.Now()), failoverTimes, nil
+		}
+
+		drpc := &drpcs[idx]
+		drpcActionSet(drpc, ramen.ActionFailover, targetCluster)
+
+		if err := r.Client.Update(ctx, drpc); err != nil {
+			return 0, failoverTimes, fmt.Errorf("drpc %s failover: %w", drpcNamespacedName(drpc), err)
+		}
+
+		log.Info("auto failover", "drpc", drpcNamespacedName(drpc), "from", clusterName, "to", targetCluster)
+
+		msg := fmt.Sprintf("%s; DRPlacementControl %s failed over automatically to cluster %s",
+			unavailable, drpcNamespacedName(drpc), targetCluster)
+		util.ReportIfNotPresent(r.eventRecorder, drpolicy, corev1.EventTypeNormal, util.EventReasonAutoFailover, msg)
+		util.ReportIfNotPresent(r.eventRecorder, drpc, corev1.EventTypeNormal, util.EventReasonAutoFailover, msg)
+
+		failoverTimes = append(failoverTimes, metav1.Now())
+	}
+
+	return 0, failoverTimes, nil
+}
+
+// autoFailoverFence requests the fencing of the unavailable cluster, and
+// returns true once it is fenced.  A cluster whose DRCluster has no storage
+// client CIDRs is fenced only by the administrator.
+func (r *DRPolicyReconciler) autoFailoverFence(ctx context.Context, drpolicy *ramen.DRPolicy, clusterName,
+	unavailable string,
+) (bool, error) {
+	drcluster, err := drclusterGet(ctx, r.APIReader, clusterName)
+	if err != nil {
+		return false, err
+	}
+
+	if drcluster == nil ||
+		(len(drcluster.Spec.CIDRs) == 0 && drcluster.Spec.ClusterFence != ramen.ClusterFenceStateFenced) {
+		util.ReportIfNotPresent(r.eventRecorder, drpolicy, corev1.EventTypeWarning,
+			util.EventReasonAutoFailoverBlocked,
+			unavailable+", but it cannot be fenced automatically, as its DRCluster has no CIDRs; fence it to "+
+				"fail it over")
+
+		return false, nil
+	}
+
+	if drcluster.Spec.ClusterFence != ramen.ClusterFenceStateFenced {
+		drcluster.Spec.ClusterFence = ramen.ClusterFenceStateFenced
+		if err := r.Client.Update(ctx, drcluster); err != nil {
+			return false, fmt.Errorf("drcluster %s fence: %w", clusterName, err)
+		}
+
+		util.ReportIfNotPresent(r.eventRecorder, drpolicy, corev1.EventTypeNormal, util.EventReasonAutoFailoverFencing,
+			unavailable+", fencing it before failing over its DRPlacementControls")
+
+		return false, nil
+	}
+
+	return drclusterFenced(drcluster), nil
+}
+
+// failoverTargetCluster returns the most preferred peer cluster of the
+// drpolicy to fail over to from the cluster, that is available, or an empty
+// string if none is
+func failoverTargetCluster(ctx context.Context, reader client.Reader, drpolicy *ramen.DRPolicy,
+	clusterName string,
+) (string, error) {
+	topologies, err := clusterTopologies(ctx, drpolicy, reader)
+	if err != nil {
+		return "", err
+	}
+
+	for _, peerCluster := range clustersByTopologyPreference(drpolicy, topologies, clusterName) {
+		if _, err := managedClusterAvailable(ctx, reader, peerCluster); err != nil {
+			continue
+		}
+
+		drcluster, err := drclusterGet(ctx, reader, peerCluster)
+		if err != nil {
+			return "", err
+		}
+
+		if drcluster == nil || drclusterUnavailable(drcluster) == "" {
+			return peerCluster, nil
+		}
+	}
+
+	return "", nil
+}
+
+// autoFailoverDRPCs returns the DRPlacementControls of the drpolicy placed on
+// the cluster, that are not already failed over away from it
+func autoFailoverDRPCs(ctx context.Context, reader client.Reader, drpolicyName, clusterName string,
+) ([]ramen.DRPlacementControl, error) {
+	drpcs, err := drpolicyDRPCs(ctx, reader, drpolicyName)
+	if err != nil {
+		return nil, err
+	}
+
+	placed := []ramen.DRPlacementControl{}
+
+	for idx := range drpcs {
+		if drpcPlacedOn(&drpcs[idx], clusterName) {
+			placed = append(placed, drpcs[idx])
+		}
+	}
+
+	return placed, nil
+}
+
+// drpcPlacedOn returns true if the DRPlacementControl is placed on the
+// cluster, and is not being deleted or already failed over away from it
+func drpcPlacedOn(drpc *ramen.DRPlacementControl, clusterName string) bool {
+	if !drpc.GetDeletionTimestamp().IsZero() || drpc.Status.PreferredDecision.ClusterName != clusterName {
+		return false
+	}
+
+	return drpc.Spec.Action != ramen.ActionFailover || drpc.Spec.FailoverCluster == clusterName
+}
+
+// managedClusterUnavailableFor returns how long the ManagedCluster has been
+// unavailable, or zero if it is available, or not yet joined
+func managedClusterUnavailableFor(ctx context.Context, reader client.Reader, clusterName string,
+) (time.Duration, error) {
+	managedCluster := &spokeClusterV1.ManagedCluster{}
+	if err := reader.Get(ctx, types.NamespacedName{Name: clusterName}, managedCluster); err != nil {
+		return 0, client.IgnoreNotFound(fmt.Errorf("managed cluster %s get: %w", clusterName, err))
+	}
+
+	if !meta.IsStatusConditionTrue(managedCluster.Status.Conditions, spokeClusterV1.ManagedClusterConditionJoined) {
+		return 0, nil
+	}
+
+	condition := meta.FindStatusCondition(managedCluster.Status.Conditions,
+		spokeClusterV1.ManagedClusterConditionAvailable)
+	if condition == nil || condition.Status == metav1.ConditionTrue {
+		return 0, nil
+	}
+
+	return time.Since(condition.LastTransitionTime.Time), nil
+}
+
+// autoFailoverTimesPrune returns the failover times within the rate window
+func autoFailoverTimesPrune(failoverTimes []metav1.Time, now time.Time) []metav1.Time {
+	var pruned []metav1.Time
+
+	for _, failoverTime := range failoverTimes {
+		if now.Sub(failoverTime.Time) < autoFailoverRateWindow {
+			pruned = append(pruned, failoverTime)
+		}
+	}
+
+	return pruned
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/go-logr/logr"
+	ocmworkv1 "github.com/open-cluster-management/api/work/v1"
+	viewv1beta1 "github.com/open-cluster-management/multicloud-operators-foundation/pkg/apis/view/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers/util"
+)
+
+// DRPolicyReasonClusterRemovalBlocked is the cluster set updated condition
+// reason while a removed cluster hosts the primary of a DRPlacementControl
+const DRPolicyReasonClusterRemovalBlocked = `ClusterRemovalBlocked`
+
+// clusterSetUpdate applies changes to the clusters of the drpolicy, and
+// reports them in its status.  Cluster roles are deployed to added clusters
+// by ClusterRolesCreate.  A removed cluster remains in the status cluster set
+// while it hosts the primary of a DRPlacementControl referring to the
+// drpolicy, as the DRPlacementControl can no longer be relocated or failed
+// over from it.  Otherwise, the VRG ManifestWorks and ManagedClusterViews of
+// the DRPlacementControls, and the cluster roles, unless another drpolicy
+// includes the cluster, are deleted from it.
+func (r *DRPolicyReconciler) clusterSetUpdate(ctx context.Context, drpolicy *ramen.DRPolicy,
+	log logr.Logger,
+) error {
+	clusterNames := sets.NewString(util.DrpolicyClusterNames(drpolicy)...)
+	removed := sets.NewString(drpolicy.Status.ClusterSet...).Difference(clusterNames)
+
+	drpcs := []ramen.DRPlacementControl{}
+
+	if removed.Len() > 0 {
+		var err error
+
+		drpcs, err = drpolicyDRPCs(ctx, r.Client, drpolicy.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	blocked := []string{}
+
+	for _, clusterName := range removed.List() {
+		primaries, err := r.primariesOnCluster(ctx, drpcs, clusterName)
+		if err != nil {
+			return err
+		}
+
+		if len(primaries) > 0 {
+			clusterNames.Insert(clusterName)
+			blocked = append(blocked, fmt.Sprintf("cluster %s hosts the primary of DRPlacementControls %s",
+				clusterName, strings.Join(primaries, ", ")))
+
+			continue
+		}
+
+		if err := r.clusterRemove(ctx, drpolicy, drpcs, clusterName, log); err != nil {
+			return err
+		}
+	}
+
+	condition := metav1.Condition{
+		Type:               ramen.DRPolicyClusterSetUpdated,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: drpolicy.Generation,
+		Reason:             DRPolicyReasonValidated,
+		Message:            `cluster set updated`,
+	}
+
+	if len(blocked) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = DRPolicyReasonClusterRemovalBlocked
+		condition.Message = strings.Join(blocked, "; ") +
+			"; add the clusters back, and relocate or fail over the DRPlacementControls to remove them"
+
+		util.ReportIfNotPresent(r.eventRecorder, drpolicy, corev1.EventTypeWarning,
+			util.EventReasonDRPolicyClusterRemovalBlocked, condition.Message)
+	}
+
+	existing := meta.FindStatusCondition(drpolicy.Status.Conditions, condition.Type)
+	if existing != nil && existing.Status == condition.Status && existing.Reason == condition.Reason &&
+		existing.Message == condition.Message && existing.ObservedGeneration == condition.ObservedGeneration &&
+		reflect.DeepEqual(drpolicy.Status.ClusterSet, clusterNames.List()) {
+		return nil
+	}
+
+	log.Info("cluster set update", "clusters", clusterNames.List(), "status", condition.Status)
+
+	meta.SetStatusCondition(&drpolicy.Status.Conditions, condition)
+	drpolicy.Status.ClusterSet = clusterNames.List()
+
+	return r.Client.Status().Update(ctx, drpolicy)
+}
+
+// primariesOnCluster returns the names of the DRPlacementControls whose
+// preferred decision is the cluster, or whose VRG ManifestWork in the cluster
+// is primary
+func (r *DRPolicyReconciler) primariesOnCluster(ctx context.Context, drpcs []ramen.DRPlacementControl,
+	clusterName string,
+) ([]string, error) {
+	primaries := []string{}
+
+	for idx := range drpcs {
+		drpc := &drpcs[idx]
+
+		primary := drpc.Status.PreferredDecision.ClusterName == clusterName
+		if !primary {
+			var err error
+
+			primary, err = r.vrgManifestWorkPrimary(ctx, drpc, clusterName)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if primary {
+			primaries = append(primaries, drpcNamespacedName(drpc))
+		}
+	}
+
+	return primaries, nil
+}
+
+func (r *DRPolicyReconciler) vrgManifestWorkPrimary(ctx context.Context, drpc *ramen.DRPlacementControl,
+	clusterName string,
+) (bool, error) {
+	mw := &ocmworkv1.ManifestWork{}
+	mwName := util.ManifestWorkName(drpc.Name, drpc.Namespace, util.MWTypeVRG)
+
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: mwName, Namespace: clusterName}, mw); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("manifestwork %s/%s get: %w", clusterName, mwName, err)
+	}
+
+	if len(mw.Spec.Workload.Manifests) == 0 {
+		return false, nil
+	}
+
+	vrg := &ramen.VolumeReplicationGroup{}
+	if err := yaml.Unmarshal(mw.Spec.Workload.Manifests[0].RawExtension.Raw, vrg); err != nil {
+		return false, fmt.Errorf("manifestwork %s/%s vrg unmarshal: %w", clusterName, mwName, err)
+	}
+
+	return vrg.Spec.ReplicationState == ramen.Primary, nil
+}
+
+// clusterRemove deletes the secondary VRG ManifestWorks and the
+// ManagedClusterViews of the DRPlacementControls from the cluster, and the
+// cluster roles, unless another drpolicy includes the cluster
+func (r *DRPolicyReconciler) clusterRemove(ctx context.Context, drpolicy *ramen.DRPolicy,
+	drpcs []ramen.DRPlacementControl, clusterName string, log logr.Logger,
+) error {
+	log.Info("cluster remove", "cluster", clusterName)
+
+	for idx := range drpcs {
+		drpc := &drpcs[idx]
+		mwu := util.MWUtil{Client: r.Client, Ctx: ctx, Log: log, InstName: drpc.Name, InstNamespace: drpc.Namespace}
+
+		if err := mwu.DeleteManifestWorksForCluster(clusterName); err != nil {
+			return err
+		}
+
+		for _, resource := range []string{util.MWTypeVRG, util.MWTypeNS} {
+			mcv := &viewv1beta1.ManagedClusterView{ObjectMeta: metav1.ObjectMeta{
+				Name:      BuildManagedClusterViewName(drpc.Name, drpc.Namespace, resource),
+				Namespace: clusterName,
+			}}
+
+			if err := r.Client.Delete(ctx, mcv); err != nil && !errors.IsNotFound(err) {
+				return fmt.Errorf("managedclusterview %s/%s delete: %w", mcv.Namespace, mcv.Name, err)
+			}
+		}
+	}
+
+	mwu := util.MWUtil{Client: r.Client, Ctx: ctx, Log: log, InstName: "", InstNamespace: ""}
+
+	return mwu.ClusterRolesDeleteCluster(drpolicy, clusterName)
+}
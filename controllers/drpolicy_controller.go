@@ -21,11 +21,21 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	spokeClusterV1 "github.com/open-cluster-management/api/cluster/v1"
+	viewv1beta1 "github.com/open-cluster-management/multicloud-operators-foundation/pkg/apis/view/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	ramen "github.com/ramendr/ramen/api/v1alpha1"
 	"github.com/ramendr/ramen/controllers/util"
@@ -37,6 +47,8 @@ type DRPolicyReconciler struct {
 	APIReader         client.Reader
 	Scheme            *runtime.Scheme
 	ObjectStoreGetter ObjectStoreGetter
+	MCVGetter         ManagedClusterViewGetter
+	eventRecorder     *util.EventReporter
 }
 
 //nolint:lll
@@ -44,6 +56,13 @@ type DRPolicyReconciler struct {
 //+kubebuilder:rbac:groups=ramendr.openshift.io,resources=drpolicies/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=ramendr.openshift.io,resources=drpolicies/finalizers,verbs=update
 // +kubebuilder:rbac:groups=work.open-cluster-management.io,resources=manifestworks,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=cluster.open-cluster-management.io,resources=managedclusters,verbs=get;list;watch
+// +kubebuilder:rbac:groups=view.open-cluster-management.io,resources=managedclusterviews,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",namespace=system,resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",namespace=system,resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=get;create;patch;update
+// +kubebuilder:rbac:groups=ramendr.openshift.io,resources=drplacementcontrols,verbs=get;list;watch;update
+// +kubebuilder:rbac:groups=ramendr.openshift.io,resources=drclusters,verbs=get;list;watch;update
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -71,7 +90,13 @@ func (r *DRPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	case true:
 		log.Info("create/update")
 
-		if err := validate(ctx, drpolicy, r.APIReader, r.Client, r.ObjectStoreGetter, log); err != nil {
+		health, healthChecked, healthCheckAfter := r.healthCheck(ctx, drpolicy)
+
+		if err := r.healthConditionsUpdate(ctx, drpolicy, health, healthChecked, log); err != nil {
+			return ctrl.Result{}, fmt.Errorf(`health conditions update: %w`, err)
+		}
+
+		if err := validate(ctx, drpolicy, r.APIReader, r.Client, health, log); err != nil {
 			return ctrl.Result{}, fmt.Errorf(`validate: %w`, err)
 		}
 
@@ -82,9 +107,33 @@ func (r *DRPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		if err := manifestWorkUtil.ClusterRolesCreate(drpolicy); err != nil {
 			return ctrl.Result{}, fmt.Errorf("cluster roles create: %w", err)
 		}
+
+		if err := r.clusterSetUpdate(ctx, drpolicy, log); err != nil {
+			return ctrl.Result{}, fmt.Errorf("cluster set update: %w", err)
+		}
+
+		requeueAfter, err := r.autoFailover(ctx, drpolicy, log)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("auto failover: %w", err)
+		}
+
+		if requeueAfter > 0 && requeueAfter < healthCheckAfter {
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		}
+
+		return ctrl.Result{RequeueAfter: healthCheckAfter}, nil
 	default:
 		log.Info("delete")
 
+		allowed, err := r.deletionAllowed(ctx, drpolicy, log)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("deletion allowed: %w", err)
+		}
+
+		if !allowed {
+			return ctrl.Result{}, nil
+		}
+
 		if err := manifestWorkUtil.ClusterRolesDelete(drpolicy); err != nil {
 			return ctrl.Result{}, fmt.Errorf("cluster roles delete: %w", err)
 		}
@@ -97,8 +146,31 @@ func (r *DRPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	return ctrl.Result{}, nil
 }
 
+// DRPolicy validated and health condition reasons
+const (
+	DRPolicyReasonValidated                 = `Succeeded`
+	DRPolicyReasonClusterNotFound           = `ClusterNotFound`
+	DRPolicyReasonClusterNotJoined          = `ClusterNotJoined`
+	DRPolicyReasonClusterUnavailable        = `ClusterUnavailable`
+	DRPolicyReasonSchedulingIntervalInvalid = `SchedulingIntervalInvalid`
+	DRPolicyReasonS3ConnectionFailed        = `s3ConnectionFailed`
+	DRPolicyReasonS3RoundTripFailed         = `S3RoundTripFailed`
+	DRPolicyReasonInventoryUnavailable      = `ClusterInventoryUnavailable`
+	DRPolicyReasonReplicationClassNotFound  = `ReplicationClassNotFound`
+	DRPolicyReasonS3ProfileMismatch         = `S3ProfileMismatch`
+	DRPolicyReasonStorageClassNotFound      = `StorageClassNotFound`
+	DRPolicyReasonTopologyRuleViolated      = `TopologyRuleViolated`
+)
+
+// drpolicyValidationBucket is the bucket of each s3 profile that DRPolicy
+// validation writes to and reads from
+const drpolicyValidationBucket = `ramen-drpolicy-validation`
+
+// validate sets the validated condition of a drpolicy once per generation, as
+// its clusters, s3 profiles and replication classes are revalidated as part of
+// its health conditions
 func validate(ctx context.Context, drpolicy *ramen.DRPolicy, apiReader client.Reader,
-	client client.Client, objectStoreGetter ObjectStoreGetter, log logr.Logger,
+	client client.Client, health drpolicyHealth, log logr.Logger,
 ) error {
 	var (
 		conditionSetTrue  func(reason, message string) error
@@ -106,24 +178,37 @@ func validate(ctx context.Context, drpolicy *ramen.DRPolicy, apiReader client.Re
 	)
 
 	if condition := util.DrpolicyValidatedConditionGet(drpolicy); condition != nil {
-		if condition.Status == metav1.ConditionTrue {
+		if condition.Status == metav1.ConditionTrue && condition.ObservedGeneration == drpolicy.Generation {
 			log.Info(`valid -> valid`)
 
 			return nil
 		}
 
+		from := `invalid`
+		if condition.Status == metav1.ConditionTrue {
+			from = `valid`
+		}
+
 		conditionUpdate := func(status metav1.ConditionStatus, reason, message string) error {
 			util.ConditionUpdate(drpolicy, condition, status, reason, message)
 
 			return client.Status().Update(ctx, drpolicy)
 		}
 		conditionSetFalse = func(reason string, err error) error {
-			log.Info(`invalid -> invalid`)
+			log.Info(from + ` -> invalid`)
+			if condition.Reason == reason && condition.Message == err.Error() &&
+				condition.ObservedGeneration == drpolicy.Generation {
+				return err
+			}
+
+			if err1 := conditionUpdate(metav1.ConditionFalse, reason, err.Error()); err1 != nil {
+				err = err1
+			}
 
 			return err
 		}
 		conditionSetTrue = func(reason, message string) error {
-			log.Info(`invalid -> valid`)
+			log.Info(from + ` -> valid`)
 
 			return conditionUpdate(metav1.ConditionTrue, reason, message)
 		}
@@ -148,14 +233,321 @@ func validate(ctx context.Context, drpolicy *ramen.DRPolicy, apiReader client.Re
 		}
 	}
 
+	for _, check := range []func() (string, error){
+		func() (string, error) { return validateClusters(ctx, drpolicy, apiReader, false) },
+		func() (string, error) { return validateDRClusters(ctx, drpolicy, apiReader) },
+		func() (string, error) { return validateTopology(ctx, drpolicy, apiReader) },
+		func() (string, error) { return validateSchedulingInterval(drpolicy) },
+		func() (string, error) { return health.s3Reachable.reason, health.s3Reachable.err },
+		func() (string, error) {
+			return health.replicationClassesPresent.reason, health.replicationClassesPresent.err
+		},
+	} {
+		if reason, err := check(); err != nil {
+			return conditionSetFalse(reason, err)
+		}
+	}
+
+	return conditionSetTrue(DRPolicyReasonValidated, `drpolicy validated`)
+}
+
+// validateClusters checks that each cluster is a ManagedCluster that has joined
+// the hub, and optionally that it is available
+func validateClusters(ctx context.Context, drpolicy *ramen.DRPolicy, apiReader client.Reader,
+	available bool,
+) (string, error) {
+	for i := range drpolicy.Spec.DRClusterSet {
+		clusterName := drpolicy.Spec.DRClusterSet[i].Name
+		managedCluster := &spokeClusterV1.ManagedCluster{}
+
+		if err := apiReader.Get(ctx, types.NamespacedName{Name: clusterName}, managedCluster); err != nil {
+			if errors.IsNotFound(err) {
+				return DRPolicyReasonClusterNotFound, fmt.Errorf(`%s: managed cluster not found`, clusterName)
+			}
+
+			return DRPolicyReasonClusterNotFound, fmt.Errorf(`%s: %w`, clusterName, err)
+		}
+
+		if !meta.IsStatusConditionTrue(managedCluster.Status.Conditions,
+			spokeClusterV1.ManagedClusterConditionJoined) {
+			return DRPolicyReasonClusterNotJoined, fmt.Errorf(`%s: managed cluster not joined`, clusterName)
+		}
+
+		if available && !meta.IsStatusConditionTrue(managedCluster.Status.Conditions,
+			spokeClusterV1.ManagedClusterConditionAvailable) {
+			return DRPolicyReasonClusterUnavailable, fmt.Errorf(`%s: managed cluster not available`, clusterName)
+		}
+	}
+
+	return ``, nil
+}
+
+// validateDRClusters checks that the s3 profile of each cluster that has a
+// DRCluster is the one its DRCluster specifies
+func validateDRClusters(ctx context.Context, drpolicy *ramen.DRPolicy, apiReader client.Reader) (string, error) {
 	for i := range drpolicy.Spec.DRClusterSet {
-		cluster := drpolicy.Spec.DRClusterSet[i]
-		if _, err := objectStoreGetter.ObjectStore(ctx, apiReader, cluster.S3ProfileName, `drpolicy validation`); err != nil {
-			return conditionSetFalse(`s3ConnectionFailed`, fmt.Errorf(`%s: %w`, cluster.S3ProfileName, err))
+		cluster := &drpolicy.Spec.DRClusterSet[i]
+
+		drcluster, err := drclusterGet(ctx, apiReader, cluster.Name)
+		if err != nil {
+			return DRPolicyReasonClusterNotFound, err
+		}
+
+		if drcluster != nil && drcluster.Spec.S3ProfileName != cluster.S3ProfileName {
+			return DRPolicyReasonS3ProfileMismatch, fmt.Errorf(`%s: s3 profile %s differs from DRCluster s3 profile %s`,
+				cluster.Name, cluster.S3ProfileName, drcluster.Spec.S3ProfileName)
 		}
 	}
 
-	return conditionSetTrue(`Succeeded`, `drpolicy validated`)
+	return ``, nil
+}
+
+// clusterTopology is the region and zone of a cluster
+type clusterTopology struct {
+	region string
+	zone   string
+}
+
+// clusterTopologies returns the topology of each cluster of a drpolicy, as
+// specified in the policy, or else in the DRCluster of the cluster, if any
+func clusterTopologies(ctx context.Context, drpolicy *ramen.DRPolicy, apiReader client.Reader,
+) (map[string]clusterTopology, error) {
+	topologies := make(map[string]clusterTopology, len(drpolicy.Spec.DRClusterSet))
+
+	for i := range drpolicy.Spec.DRClusterSet {
+		cluster := &drpolicy.Spec.DRClusterSet[i]
+		topology := clusterTopology{region: cluster.Region, zone: cluster.Zone}
+
+		if topology.region == "" || topology.zone == "" {
+			drcluster, err := drclusterGet(ctx, apiReader, cluster.Name)
+			if err != nil {
+				return nil, err
+			}
+
+			if drcluster != nil && topology.region == "" {
+				topology.region = drcluster.Spec.Region
+			}
+
+			if drcluster != nil && topology.zone == "" {
+				topology.zone = drcluster.Spec.Zone
+			}
+		}
+
+		topologies[cluster.Name] = topology
+	}
+
+	return topologies, nil
+}
+
+// validateTopology checks that the clusters of a drpolicy with a topology rule
+// are each in a known failure domain of their own
+func validateTopology(ctx context.Context, drpolicy *ramen.DRPolicy, apiReader client.Reader) (string, error) {
+	rule := drpolicy.Spec.TopologyRule
+	if rule == "" {
+		return ``, nil
+	}
+
+	topologies, err := clusterTopologies(ctx, drpolicy, apiReader)
+	if err != nil {
+		return DRPolicyReasonClusterNotFound, err
+	}
+
+	domains := map[string]string{}
+
+	for _, cluster := range drpolicy.Spec.DRClusterSet {
+		topology := topologies[cluster.Name]
+
+		domain := rule.FailureDomain(topology.region, topology.zone)
+		if domain == "" {
+			return DRPolicyReasonTopologyRuleViolated, fmt.Errorf(`%s: failure domain unknown, violating %s`,
+				cluster.Name, rule)
+		}
+
+		if peer, found := domains[domain]; found {
+			return DRPolicyReasonTopologyRuleViolated, fmt.Errorf(`%s: shares failure domain %s with %s, violating %s`,
+				cluster.Name, domain, peer, rule)
+		}
+
+		domains[domain] = cluster.Name
+	}
+
+	return ``, nil
+}
+
+// clustersByTopologyPreference returns the names of the clusters of a
+// drpolicy, other than the given cluster, ordered by preference as targets to
+// move workloads away from it: first those in a different region, then those
+// in a different zone, and then the rest, each in policy order
+func clustersByTopologyPreference(drpolicy *ramen.DRPolicy, topologies map[string]clusterTopology,
+	fromCluster string,
+) []string {
+	const (
+		otherRegion = iota
+		otherZone
+		sameDomain
+		preferences
+	)
+
+	from := topologies[fromCluster]
+	ranked := make([][]string, preferences)
+
+	for _, cluster := range drpolicy.Spec.DRClusterSet {
+		if cluster.Name == fromCluster {
+			continue
+		}
+
+		topology := topologies[cluster.Name]
+		preference := sameDomain
+
+		switch {
+		case from.region == "" || topology.region == "":
+		case topology.region != from.region:
+			preference = otherRegion
+		case from.zone != "" && topology.zone != "" && topology.zone != from.zone:
+			preference = otherZone
+		}
+
+		ranked[preference] = append(ranked[preference], cluster.Name)
+	}
+
+	clusterNames := []string{}
+	for _, names := range ranked {
+		clusterNames = append(clusterNames, names...)
+	}
+
+	return clusterNames
+}
+
+func validateSchedulingInterval(drpolicy *ramen.DRPolicy) (string, error) {
+	if drpolicy.Spec.ReplicationMode == ramen.ReplicationModeSync {
+		if drpolicy.Spec.SchedulingInterval != "" {
+			return DRPolicyReasonSchedulingIntervalInvalid, fmt.Errorf(`scheduling interval %s not supported in %s `+
+				`replication mode`, drpolicy.Spec.SchedulingInterval, drpolicy.Spec.ReplicationMode)
+		}
+
+		return ``, nil
+	}
+
+	if _, err := util.SchedulingIntervalParse(drpolicy.Spec.SchedulingInterval); err != nil {
+		return DRPolicyReasonSchedulingIntervalInvalid, err
+	}
+
+	return ``, nil
+}
+
+// validateS3Profiles checks that an object can be written to, read back from,
+// and deleted from each s3 profile, as creating an object store does not
+// connect to it
+func validateS3Profiles(ctx context.Context, drpolicy *ramen.DRPolicy, apiReader client.Reader,
+	objectStoreGetter ObjectStoreGetter,
+) (string, error) {
+	for _, s3ProfileName := range util.S3UploadProfileList(*drpolicy) {
+		objectStore, err := objectStoreGetter.ObjectStore(ctx, apiReader, s3ProfileName, `drpolicy validation`)
+		if err != nil {
+			return DRPolicyReasonS3ConnectionFailed, fmt.Errorf(`%s: %w`, s3ProfileName, err)
+		}
+
+		if err := s3RoundTrip(objectStore, drpolicy.Name+`/`+s3ProfileName, drpolicy.Name); err != nil {
+			return DRPolicyReasonS3RoundTripFailed, fmt.Errorf(`%s: %w`, s3ProfileName, err)
+		}
+	}
+
+	return ``, nil
+}
+
+func s3RoundTrip(objectStore ObjectStorer, key, content string) error {
+	if err := objectStore.CreateBucket(drpolicyValidationBucket); err != nil {
+		return fmt.Errorf(`create bucket %s: %w`, drpolicyValidationBucket, err)
+	}
+
+	if err := objectStore.UploadObject(drpolicyValidationBucket, key, content); err != nil {
+		return fmt.Errorf(`put: %w`, err)
+	}
+
+	downloadedContent := ``
+	if err := objectStore.DownloadObject(drpolicyValidationBucket, key, &downloadedContent); err != nil {
+		return fmt.Errorf(`get: %w`, err)
+	}
+
+	if downloadedContent != content {
+		return fmt.Errorf(`get: object %s content %q differs from content put %q`, key, downloadedContent, content)
+	}
+
+	if err := objectStore.DeleteObject(drpolicyValidationBucket, key); err != nil {
+		return fmt.Errorf(`delete: %w`, err)
+	}
+
+	return nil
+}
+
+// validateReplicationClasses checks that each cluster offers a
+// VolumeReplicationClass that a VRG of the policy would select, and a
+// StorageClass of its provisioner, using the inventory published by the
+// dr-cluster operator of the cluster
+func validateReplicationClasses(ctx context.Context, drpolicy *ramen.DRPolicy, apiReader client.Reader,
+	mcvGetter ManagedClusterViewGetter,
+) (string, error) {
+	for i := range drpolicy.Spec.DRClusterSet {
+		clusterName := drpolicy.Spec.DRClusterSet[i].Name
+
+		inventory, err := clusterInventoryGet(ctx, apiReader, mcvGetter, clusterName)
+		if err != nil {
+			return DRPolicyReasonInventoryUnavailable, fmt.Errorf(`%s: %w`, clusterName, err)
+		}
+
+		if reason, err := validateClusterInventory(drpolicy, inventory); err != nil {
+			return reason, fmt.Errorf(`%s: %w`, clusterName, err)
+		}
+	}
+
+	return ``, nil
+}
+
+// validateClusterInventory checks that the inventory of a cluster offers a
+// VolumeReplicationClass that a VRG of the policy would select, and a
+// StorageClass of the same provisioner.  In Sync replication mode, the
+// scheduling interval is empty, and so selects classes without a schedule.
+func validateClusterInventory(drpolicy *ramen.DRPolicy, inventory *ClusterInventory) (string, error) {
+	provisioners := inventory.ReplicationProvisioners(drpolicy.Spec.ReplicationClassSelector.MatchLabels,
+		drpolicy.Spec.SchedulingInterval)
+	if len(provisioners) == 0 {
+		schedule := `scheduling interval ` + drpolicy.Spec.SchedulingInterval
+		if drpolicy.Spec.ReplicationMode == ramen.ReplicationModeSync {
+			schedule = `no scheduling interval`
+		}
+
+		return DRPolicyReasonReplicationClassNotFound, fmt.Errorf(
+			`no VolumeReplicationClass with %s and labels %v`,
+			schedule, drpolicy.Spec.ReplicationClassSelector.MatchLabels)
+	}
+
+	for _, provisioner := range provisioners {
+		if inventory.HasStorageClass(provisioner) {
+			return ``, nil
+		}
+	}
+
+	return DRPolicyReasonStorageClassNotFound, fmt.Errorf(
+		`no StorageClass of the provisioners %v of the selected VolumeReplicationClasses`, provisioners)
+}
+
+// clusterInventoryGet returns the inventory of the cluster reported in the
+// status of its DRCluster, if any, or read through a ManagedClusterView
+// otherwise
+func clusterInventoryGet(ctx context.Context, apiReader client.Reader, mcvGetter ManagedClusterViewGetter,
+	clusterName string,
+) (*ClusterInventory, error) {
+	drcluster, err := drclusterGet(ctx, apiReader, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	if drcluster == nil ||
+		!meta.IsStatusConditionTrue(drcluster.Status.Conditions, ramen.DRClusterInventoryReported) {
+		return mcvGetter.GetClusterInventoryFromManagedCluster(clusterName)
+	}
+
+	return drclusterInventory(&drcluster.Status), nil
 }
 
 const finalizerName = "drpolicies.ramendr.openshift.io/ramen"
@@ -186,9 +578,48 @@ func finalizerRemove(ctx context.Context, drpolicy *ramen.DRPolicy, client clien
 	return nil
 }
 
-// SetupWithManager sets up the controller with the Manager.
+// SetupWithManager sets up the controller with the Manager.  DRPolicies are
+// revalidated on changes to their ManagedClusters, DRClusters and cluster
+// inventories, and their deletion, or cluster removal, is retried once
+// DRPlacementControls stop referring to them, or to the cluster.
+// DRPolicies are also revalidated on changes to the Secrets and ConfigMaps,
+// holding s3 credentials and the Ramen config, of the operator namespace, which
+// are watched using a cache restricted to it.
 func (r *DRPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	operatorNamespaceCache, err := cache.New(mgr.GetConfig(), cache.Options{
+		Scheme:    mgr.GetScheme(),
+		Mapper:    mgr.GetRESTMapper(),
+		Namespace: OperatorNamespace(),
+	})
+	if err != nil {
+		return fmt.Errorf("operator namespace cache create: %w", err)
+	}
+
+	if err := mgr.Add(operatorNamespaceCache); err != nil {
+		return fmt.Errorf("operator namespace cache add: %w", err)
+	}
+
+	r.eventRecorder = util.NewEventReporter(mgr.GetEventRecorderFor("controller_DRPolicy"))
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&ramen.DRPolicy{}).
+		Watches(&source.Kind{Type: &spokeClusterV1.ManagedCluster{}},
+			handler.EnqueueRequestsFromMapFunc(r.managedClusterMapFunc),
+			builder.WithPredicates(managedClusterConditionsChangedPredicate())).
+		Watches(&source.Kind{Type: &viewv1beta1.ManagedClusterView{}},
+			handler.EnqueueRequestsFromMapFunc(r.inventoryViewMapFunc),
+			builder.WithPredicates(ManagedClusterViewPredicateFunc())).
+		Watches(&source.Kind{Type: &ramen.DRCluster{}},
+			handler.EnqueueRequestsFromMapFunc(r.managedClusterMapFunc),
+			builder.WithPredicates(drclusterChangedPredicate())).
+		Watches(&source.Kind{Type: &ramen.DRPlacementControl{}},
+			handler.EnqueueRequestsFromMapFunc(r.pendingDRPoliciesMapFunc),
+			builder.WithPredicates(drpcDRPolicyRefChangedPredicate())).
+		Watches(source.NewKindWithCache(&corev1.Secret{}, operatorNamespaceCache),
+			handler.EnqueueRequestsFromMapFunc(r.allDRPoliciesMapFunc),
+			builder.WithPredicates(dataChangedPredicate())).
+		Watches(source.NewKindWithCache(&corev1.ConfigMap{}, operatorNamespaceCache),
+			handler.EnqueueRequestsFromMapFunc(r.allDRPoliciesMapFunc),
+			builder.WithPredicates(dataChangedPredicate())).
 		Complete(r)
 }
@@ -2,20 +2,32 @@ package controllers_test
 
 import (
 	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
 
+	volrep "github.com/csi-addons/volume-replication-operator/api/v1alpha1"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
+	spokeClusterV1 "github.com/open-cluster-management/api/cluster/v1"
+	ocmworkv1 "github.com/open-cluster-management/api/work/v1"
 	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers"
 	"github.com/ramendr/ramen/controllers/util"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes/scheme"
 	validationErrors "k8s.io/kube-openapi/pkg/validation/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
 )
 
 var _ = Describe("DrpolicyController", func() {
@@ -34,7 +46,9 @@ var _ = Describe("DrpolicyController", func() {
 			0.25,
 		).Should(Succeed())
 	}
-	validatedConditionExpect := func(drpolicy *ramen.DRPolicy, status metav1.ConditionStatus) {
+	conditionExpect := func(drpolicy *ramen.DRPolicy, conditionType string, status metav1.ConditionStatus,
+		reason string,
+	) {
 		Eventually(
 			func(g Gomega) {
 				g.Expect(apiReader.Get(
@@ -48,12 +62,12 @@ var _ = Describe("DrpolicyController", func() {
 					},
 					IgnoreExtras,
 					Elements{
-						ramen.DRPolicyValidated: MatchAllFields(Fields{
+						conditionType: MatchAllFields(Fields{
 							`Type`:               Ignore(),
 							`Status`:             Equal(status),
 							`ObservedGeneration`: Ignore(),
 							`LastTransitionTime`: Ignore(),
-							`Reason`:             Ignore(),
+							`Reason`:             Equal(reason),
 							`Message`:            Ignore(),
 						}),
 					},
@@ -63,8 +77,34 @@ var _ = Describe("DrpolicyController", func() {
 			0.25,
 		).Should(Succeed())
 	}
+	validatedConditionExpect := func(drpolicy *ramen.DRPolicy, status metav1.ConditionStatus, reason string) {
+		conditionExpect(drpolicy, ramen.DRPolicyValidated, status, reason)
+	}
+	managedClusterAvailableSet := func(clusterName string, status metav1.ConditionStatus) {
+		managedCluster := &spokeClusterV1.ManagedCluster{}
+		Expect(apiReader.Get(context.TODO(), types.NamespacedName{Name: clusterName}, managedCluster)).To(Succeed())
+		meta.SetStatusCondition(&managedCluster.Status.Conditions, metav1.Condition{
+			Type:    spokeClusterV1.ManagedClusterConditionAvailable,
+			Status:  status,
+			Reason:  "ManagedClusterAvailable",
+			Message: "Managed cluster availability set",
+		})
+		Expect(k8sClient.Status().Update(context.TODO(), managedCluster)).To(Succeed())
+	}
+	managedClusterCreate := func(clusterName string, joined bool) {
+		managedCluster := &spokeClusterV1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: clusterName}}
+		err := k8sClient.Create(context.TODO(), managedCluster)
+		if errors.IsAlreadyExists(err) {
+			return
+		}
+		Expect(err).ToNot(HaveOccurred())
+		if joined {
+			managedClusterJoin(managedCluster)
+		}
+	}
 	drpolicyCreate := func(drpolicy *ramen.DRPolicy) {
 		for _, clusterName := range clusterNames(drpolicy).Difference(*clusterNamesCurrent).UnsortedList() {
+			managedClusterCreate(clusterName, true)
 			Expect(k8sClient.Create(
 				context.TODO(),
 				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: clusterName}},
@@ -103,18 +143,25 @@ var _ = Describe("DrpolicyController", func() {
 	drpolicies := [...]ramen.DRPolicy{
 		{
 			ObjectMeta: objectMetas[0],
-			Spec:       ramen.DRPolicySpec{DRClusterSet: clusters[0:2], SchedulingInterval: `00m`},
+			Spec:       ramen.DRPolicySpec{DRClusterSet: clusters[0:2], SchedulingInterval: `1m`},
 		},
 		{
 			ObjectMeta: objectMetas[1],
-			Spec:       ramen.DRPolicySpec{DRClusterSet: clusters[1:3], SchedulingInterval: `9999999d`},
+			Spec:       ramen.DRPolicySpec{DRClusterSet: clusters[1:3], SchedulingInterval: `9999d`},
 		},
 	}
 	clusterNamesNone := sets.String{}
 	var drpolicy *ramen.DRPolicy
+	var lastHealthCheckTime metav1.Time
 	Specify(`a drpolicy`, func() {
 		drpolicy = &drpolicies[0]
 	})
+	Specify(`a replication class for each drpolicy scheduling interval`, func() {
+		for i := range drpolicies {
+			replicationClassCreate(`drpolicy`+drpolicies[i].Spec.SchedulingInterval,
+				drpolicies[i].Spec.SchedulingInterval)
+		}
+	})
 	When("a 1st drpolicy is created", func() {
 		It("should create a cluster roles manifest work for each cluster specified in a 1st drpolicy", func() {
 			drpolicyCreate(drpolicy)
@@ -122,7 +169,7 @@ var _ = Describe("DrpolicyController", func() {
 	})
 	When(`a drpolicy is created containing an s3 profile that connects successfully`, func() {
 		It(`should set its validated status condition to true`, func() {
-			validatedConditionExpect(drpolicy, metav1.ConditionTrue)
+			validatedConditionExpect(drpolicy, metav1.ConditionTrue, controllers.DRPolicyReasonValidated)
 		})
 	})
 	When("TODO a 1st drpolicy is updated to add some clusters and remove some other clusters", func() {
@@ -185,23 +232,503 @@ var _ = Describe("DrpolicyController", func() {
 		})
 	})
 	Specify(`a drpolicy`, func() {
-		drpolicy.Spec.SchedulingInterval = `00m`
+		drpolicy.Spec.SchedulingInterval = `1m`
 	})
 	When(`a drpolicy is created containing an s3 profile that connects unsuccessfully`, func() {
 		It(`should set its validated status condition to false`, func() {
 			drpolicy.Spec.DRClusterSet[1].S3ProfileName = s3ProfileNameConnectFail
 			Expect(k8sClient.Create(context.TODO(), drpolicy)).To(Succeed())
-			validatedConditionExpect(drpolicy, metav1.ConditionFalse)
+			validatedConditionExpect(drpolicy, metav1.ConditionFalse, controllers.DRPolicyReasonS3ConnectionFailed)
 		})
 	})
 	When(`a drpolicy is updated containing an s3 profile that connects successfully`, func() {
 		It(`should update its validated status condition to true`, func() {
 			drpolicy.Spec.DRClusterSet[1].S3ProfileName = s3ProfileNameConnectSucc
 			Expect(k8sClient.Update(context.TODO(), drpolicy)).To(Succeed())
-			validatedConditionExpect(drpolicy, metav1.ConditionTrue)
+			validatedConditionExpect(drpolicy, metav1.ConditionTrue, controllers.DRPolicyReasonValidated)
+		})
+	})
+	Specify(`drpolicy delete`, func() {
+		drpolicyDeleteAndConfirm(drpolicy)
+	})
+	Specify(`a drpolicy`, func() {
+		drpolicy.ObjectMeta = objectMetas[0]
+	})
+	When(`a drpolicy is created containing a cluster that is not a managed cluster`, func() {
+		It(`should set its validated status condition to false with reason ClusterNotFound`, func() {
+			drpolicy.Spec.DRClusterSet[1].Name = `cluster3`
+			Expect(k8sClient.Create(context.TODO(), drpolicy)).To(Succeed())
+			validatedConditionExpect(drpolicy, metav1.ConditionFalse, controllers.DRPolicyReasonClusterNotFound)
+		})
+	})
+	When(`a drpolicy is updated containing a managed cluster that has not joined`, func() {
+		It(`should update its validated status condition reason to ClusterNotJoined`, func() {
+			managedClusterCreate(`cluster3`, false)
+			drpolicy.Spec.SchedulingInterval = `2m`
+			Expect(k8sClient.Update(context.TODO(), drpolicy)).To(Succeed())
+			validatedConditionExpect(drpolicy, metav1.ConditionFalse, controllers.DRPolicyReasonClusterNotJoined)
+		})
+	})
+	When(`a drpolicy is updated containing joined managed clusters and a zero scheduling interval`, func() {
+		It(`should update its validated status condition reason to SchedulingIntervalInvalid`, func() {
+			drpolicy.Spec.DRClusterSet[1].Name = `cluster1`
+			drpolicy.Spec.SchedulingInterval = `00m`
+			Expect(k8sClient.Update(context.TODO(), drpolicy)).To(Succeed())
+			validatedConditionExpect(drpolicy, metav1.ConditionFalse,
+				controllers.DRPolicyReasonSchedulingIntervalInvalid)
+		})
+	})
+	When(`a drpolicy is updated containing a scheduling interval no replication class offers`, func() {
+		It(`should update its validated status condition reason to ReplicationClassNotFound`, func() {
+			drpolicy.Spec.SchedulingInterval = `2m`
+			Expect(k8sClient.Update(context.TODO(), drpolicy)).To(Succeed())
+			validatedConditionExpect(drpolicy, metav1.ConditionFalse,
+				controllers.DRPolicyReasonReplicationClassNotFound)
+		})
+	})
+	When(`a drpolicy is updated containing a scheduling interval offered only by a replication class `+
+		`of a provisioner without a storage class`, func() {
+		It(`should update its validated status condition reason to StorageClassNotFound`, func() {
+			Expect(k8sClient.Create(context.TODO(), &volrep.VolumeReplicationClass{
+				ObjectMeta: metav1.ObjectMeta{Name: `drpolicy3m`},
+				Spec: volrep.VolumeReplicationClassSpec{
+					Provisioner: `drpolicy.test.provisioner.nostorageclass`,
+					Parameters:  map[string]string{`schedulingInterval`: `3m`},
+				},
+			})).To(Succeed())
+			drpolicy.Spec.SchedulingInterval = `3m`
+			Expect(k8sClient.Update(context.TODO(), drpolicy)).To(Succeed())
+			validatedConditionExpect(drpolicy, metav1.ConditionFalse,
+				controllers.DRPolicyReasonStorageClassNotFound)
+		})
+	})
+	When(`a drpolicy is updated to require distinct regions of clusters whose regions are unknown`, func() {
+		It(`should update its validated status condition reason to TopologyRuleViolated`, func() {
+			drpolicy.Spec.TopologyRule = ramen.ClusterTopologyDistinctRegions
+			Expect(k8sClient.Update(context.TODO(), drpolicy)).To(Succeed())
+			validatedConditionExpect(drpolicy, metav1.ConditionFalse,
+				controllers.DRPolicyReasonTopologyRuleViolated)
+		})
+	})
+	When(`a drpolicy requiring distinct regions is updated to place its clusters in distinct regions`, func() {
+		It(`should no longer set its validated status condition reason to TopologyRuleViolated`, func() {
+			drpolicy.Spec.DRClusterSet[0].Region = `east`
+			drpolicy.Spec.DRClusterSet[1].Region = `west`
+			Expect(k8sClient.Update(context.TODO(), drpolicy)).To(Succeed())
+			validatedConditionExpect(drpolicy, metav1.ConditionFalse,
+				controllers.DRPolicyReasonStorageClassNotFound)
+		})
+	})
+	When(`a drpolicy is updated containing a scheduling interval a replication class offers`, func() {
+		It(`should update its validated status condition to true`, func() {
+			drpolicy.Spec.SchedulingInterval = `1m`
+			Expect(k8sClient.Update(context.TODO(), drpolicy)).To(Succeed())
+			validatedConditionExpect(drpolicy, metav1.ConditionTrue, controllers.DRPolicyReasonValidated)
+		})
+		It(`should set its health status conditions to true and record the health check time`, func() {
+			conditionExpect(drpolicy, ramen.DRPolicyClustersAvailable, metav1.ConditionTrue,
+				controllers.DRPolicyReasonClustersAvailable)
+			conditionExpect(drpolicy, ramen.DRPolicyS3Reachable, metav1.ConditionTrue,
+				controllers.DRPolicyReasonS3Reachable)
+			conditionExpect(drpolicy, ramen.DRPolicyReplicationClassesPresent, metav1.ConditionTrue,
+				controllers.DRPolicyReasonReplicationClassesPresent)
+			Expect(apiReader.Get(context.TODO(), types.NamespacedName{Name: drpolicy.Name}, drpolicy)).To(Succeed())
+			Expect(drpolicy.Status.LastHealthCheckTime).ToNot(BeNil())
+			lastHealthCheckTime = *drpolicy.Status.LastHealthCheckTime
+		})
+	})
+	When(`a managed cluster of a validated drpolicy becomes unavailable`, func() {
+		It(`should set its clusters available status condition to false and keep it validated`, func() {
+			managedClusterAvailableSet(`cluster0`, metav1.ConditionFalse)
+			conditionExpect(drpolicy, ramen.DRPolicyClustersAvailable, metav1.ConditionFalse,
+				controllers.DRPolicyReasonClusterUnavailable)
+			validatedConditionExpect(drpolicy, metav1.ConditionTrue, controllers.DRPolicyReasonValidated)
+		})
+	})
+	When(`the managed cluster becomes available again`, func() {
+		It(`should set its clusters available status condition to true`, func() {
+			managedClusterAvailableSet(`cluster0`, metav1.ConditionTrue)
+			conditionExpect(drpolicy, ramen.DRPolicyClustersAvailable, metav1.ConditionTrue,
+				controllers.DRPolicyReasonClustersAvailable)
+		})
+		It(`should not recheck its s3 profiles and replication classes before the health check interval`, func() {
+			Expect(apiReader.Get(context.TODO(), types.NamespacedName{Name: drpolicy.Name}, drpolicy)).To(Succeed())
+			Expect(drpolicy.Status.LastHealthCheckTime).ToNot(BeNil())
+			Expect(drpolicy.Status.LastHealthCheckTime.Equal(&lastHealthCheckTime)).To(BeTrue())
 		})
 	})
 	Specify(`drpolicy delete`, func() {
 		drpolicyDeleteAndConfirm(drpolicy)
 	})
+	drpc := &ramen.DRPlacementControl{}
+	drpcCreate := func() {
+		*drpc = ramen.DRPlacementControl{
+			ObjectMeta: metav1.ObjectMeta{Name: `drpolicy-drpc`, Namespace: `default`},
+			Spec: ramen.DRPlacementControlSpec{
+				PlacementRef: corev1.ObjectReference{Name: `drpolicy-drpc-placement`, Kind: `PlacementRule`},
+				DRPolicyRef:  corev1.ObjectReference{Name: drpolicy.Name},
+				PVCSelector:  metav1.LabelSelector{MatchLabels: map[string]string{`app`: `drpolicy`}},
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), drpc)).To(Succeed())
+	}
+	drpcDelete := func() {
+		Expect(k8sClient.Delete(context.TODO(), drpc)).To(Succeed())
+	}
+	Specify(`a drpolicy referenced by a drpc`, func() {
+		drpolicy.ObjectMeta = objectMetas[0]
+		Expect(k8sClient.Create(context.TODO(), drpolicy)).To(Succeed())
+		validatedConditionExpect(drpolicy, metav1.ConditionTrue, controllers.DRPolicyReasonValidated)
+		drpcCreate()
+	})
+	When(`a drpolicy referenced by a drpc is deleted`, func() {
+		It(`should set its deletion blocked status condition to true and keep it`, func() {
+			Expect(k8sClient.Delete(context.TODO(), drpolicy)).To(Succeed())
+			conditionExpect(drpolicy, ramen.DRPolicyDeletionBlocked, metav1.ConditionTrue,
+				controllers.DRPolicyReasonReferenced)
+			Expect(drpolicy.Status.Conditions).To(ContainElement(
+				MatchFields(IgnoreExtras, Fields{`Message`: ContainSubstring(`default/drpolicy-drpc`)})))
+			Consistently(func() error {
+				return apiReader.Get(context.TODO(), types.NamespacedName{Name: drpolicy.Name}, &ramen.DRPolicy{})
+			}, 1, 0.25).Should(Succeed())
+		})
+	})
+	When(`the drpc referencing a drpolicy being deleted is deleted`, func() {
+		It(`should delete the drpolicy`, func() {
+			drpcDelete()
+			Eventually(func() error {
+				return apiReader.Get(context.TODO(), types.NamespacedName{Name: drpolicy.Name}, drpolicy)
+			}, 10, 0.25).ShouldNot(Succeed())
+		})
+	})
+	Specify(`a drpolicy referenced by a drpc`, func() {
+		drpolicy.ObjectMeta = objectMetas[0]
+		Expect(k8sClient.Create(context.TODO(), drpolicy)).To(Succeed())
+		validatedConditionExpect(drpolicy, metav1.ConditionTrue, controllers.DRPolicyReasonValidated)
+		drpcCreate()
+	})
+	When(`a drpolicy referenced by a drpc and annotated to force its deletion is deleted`, func() {
+		It(`should delete the drpolicy`, func() {
+			drpolicy.SetAnnotations(map[string]string{controllers.DRPolicyForceDeleteAnnotation: `true`})
+			Expect(k8sClient.Update(context.TODO(), drpolicy)).To(Succeed())
+			drpolicyDeleteAndConfirm(drpolicy)
+		})
+	})
+	Specify(`drpc delete`, func() {
+		drpcDelete()
+	})
+	clusterSetExpect := func(status metav1.ConditionStatus, reason string, clusterNames ...string) {
+		conditionExpect(drpolicy, ramen.DRPolicyClusterSetUpdated, status, reason)
+		Expect(drpolicy.Status.ClusterSet).To(Equal(clusterNames))
+	}
+	clusterRolesExistExpect := func(clusterName string, exist bool) {
+		Eventually(func() bool {
+			return apiReader.Get(context.TODO(), types.NamespacedName{
+				Name:      util.ClusterRolesManifestWorkName,
+				Namespace: clusterName,
+			}, &ocmworkv1.ManifestWork{}) == nil
+		}, 10, 0.25).Should(Equal(exist))
+	}
+	clustersUpdated := [...]ramen.ManagedCluster{
+		{Name: `cluster4`, S3ProfileName: s3ProfileNameConnectSucc},
+		{Name: `cluster5`, S3ProfileName: s3ProfileNameConnectSucc},
+		{Name: `cluster6`, S3ProfileName: s3ProfileNameConnectSucc},
+	}
+	Specify(`a drpolicy with 3 clusters`, func() {
+		for _, cluster := range clustersUpdated {
+			managedClusterCreate(cluster.Name, true)
+			Expect(k8sClient.Create(
+				context.TODO(),
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: cluster.Name}},
+			)).To(Succeed())
+		}
+		drpolicy.ObjectMeta = objectMetas[0]
+		drpolicy.Spec.DRClusterSet = append([]ramen.ManagedCluster{}, clustersUpdated[:]...)
+		Expect(k8sClient.Create(context.TODO(), drpolicy)).To(Succeed())
+		validatedConditionExpect(drpolicy, metav1.ConditionTrue, controllers.DRPolicyReasonValidated)
+		clusterSetExpect(metav1.ConditionTrue, controllers.DRPolicyReasonValidated, `cluster4`, `cluster5`, `cluster6`)
+	})
+	Specify(`a drpc whose primary is on the 3rd cluster of a drpolicy`, func() {
+		drpcCreate()
+		Eventually(func() error {
+			if err := apiReader.Get(context.TODO(), types.NamespacedName{Name: drpc.Name, Namespace: drpc.Namespace},
+				drpc); err != nil {
+				return err
+			}
+			drpc.Status.PreferredDecision.ClusterName = `cluster6`
+			drpc.Status.LastUpdateTime = metav1.Now()
+
+			return k8sClient.Status().Update(context.TODO(), drpc)
+		}, 10, 0.25).Should(Succeed())
+	})
+	drpolicyClustersUpdate := func(drpolicyClusters ...ramen.ManagedCluster) {
+		Eventually(func() error {
+			if err := apiReader.Get(context.TODO(), types.NamespacedName{Name: drpolicy.Name}, drpolicy); err != nil {
+				return err
+			}
+			drpolicy.Spec.DRClusterSet = drpolicyClusters
+
+			return k8sClient.Update(context.TODO(), drpolicy)
+		}, 10, 0.25).Should(Succeed())
+	}
+	When(`the cluster hosting the primary of a drpc is removed from its drpolicy`, func() {
+		It(`should keep it in its cluster set and set its cluster set updated status condition to false`, func() {
+			drpolicyClustersUpdate(clustersUpdated[0], clustersUpdated[1])
+			clusterSetExpect(metav1.ConditionFalse, controllers.DRPolicyReasonClusterRemovalBlocked,
+				`cluster4`, `cluster5`, `cluster6`)
+			clusterRolesExistExpect(`cluster6`, true)
+		})
+	})
+	When(`a cluster not hosting the primary of a drpc is removed from its drpolicy`, func() {
+		It(`should drop it from its cluster set and delete its cluster roles`, func() {
+			drpolicyClustersUpdate(clustersUpdated[0], clustersUpdated[2])
+			clusterSetExpect(metav1.ConditionTrue, controllers.DRPolicyReasonValidated, `cluster4`, `cluster6`)
+			clusterRolesExistExpect(`cluster5`, false)
+		})
+	})
+	When(`a cluster is added to a drpolicy`, func() {
+		It(`should add it to its cluster set and deploy cluster roles to it`, func() {
+			drpolicyClustersUpdate(clustersUpdated[:]...)
+			clusterSetExpect(metav1.ConditionTrue, controllers.DRPolicyReasonValidated, `cluster4`, `cluster5`, `cluster6`)
+			clusterRolesExistExpect(`cluster5`, true)
+		})
+	})
+	Specify(`drpc and drpolicy delete`, func() {
+		drpcDelete()
+		drpolicyDeleteAndConfirm(drpolicy)
+	})
+	ramenConfigFileName := filepath.Join(os.TempDir(), `drpolicy-test-ramen-config.yaml`)
+	ramenConfigWrite := func(autoFailoverDisabled bool) {
+		Expect(ioutil.WriteFile(ramenConfigFileName, []byte(fmt.Sprintf(
+			"apiVersion: %s\nkind: RamenConfig\nautoFailoverDisabled: %t\n",
+			ramen.GroupVersion.String(), autoFailoverDisabled)), 0o600)).To(Succeed())
+	}
+	autoFailoverDRPCs := [...]*ramen.DRPlacementControl{{}, {}}
+	autoFailoverDRPCsFailedOver := func() int {
+		failedOver := 0
+		for _, drpc := range autoFailoverDRPCs {
+			Expect(apiReader.Get(context.TODO(), types.NamespacedName{Name: drpc.Name, Namespace: drpc.Namespace},
+				drpc)).To(Succeed())
+			if drpc.Spec.Action == ramen.ActionFailover && drpc.Spec.FailoverCluster == `cluster5` &&
+				!drpc.Spec.CancelAction {
+				failedOver++
+			}
+		}
+
+		return failedOver
+	}
+	autoFailoverDRCluster := func() *ramen.DRCluster {
+		drcluster := &ramen.DRCluster{}
+		Expect(apiReader.Get(context.TODO(), types.NamespacedName{Name: `cluster4`}, drcluster)).To(Succeed())
+
+		return drcluster
+	}
+	autoFailoverMaxSet := func(maxFailoversPerHour int) {
+		Eventually(func() error {
+			if err := apiReader.Get(context.TODO(), types.NamespacedName{Name: drpolicy.Name}, drpolicy); err != nil {
+				return err
+			}
+			drpolicy.Spec.AutoFailover.MaxFailoversPerHour = maxFailoversPerHour
+
+			return k8sClient.Update(context.TODO(), drpolicy)
+		}, 10, 0.25).Should(Succeed())
+	}
+	autoFailoverDisabledEventExpect := func(message string) {
+		Eventually(func(g Gomega) {
+			events := &corev1.EventList{}
+			g.Expect(apiReader.List(context.TODO(), events)).To(Succeed())
+			g.Expect(events.Items).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				`InvolvedObject`: MatchFields(IgnoreExtras, Fields{`Name`: Equal(drpolicy.Name)}),
+				`Reason`:         Equal(util.EventReasonAutoFailoverDisabled),
+				`Message`:        ContainSubstring(message),
+			})))
+		}, 10, 0.25).Should(Succeed())
+	}
+	Specify(`a Ramen config enabling automatic failover`, func() {
+		ramenConfigWrite(false)
+		controllers.LoadControllerConfig(ramenConfigFileName, scheme.Scheme, ctrl.Log.WithName(`drpolicy-test`))
+	})
+	Specify(`a drpolicy opting in to automatic failover, with 2 drpcs placed on a cluster with CIDRs, and a `+
+		`cancellation left set from an earlier action`, func() {
+		drpolicy.ObjectMeta = objectMetas[0]
+		drpolicy.Spec.DRClusterSet = append([]ramen.ManagedCluster{}, clustersUpdated[0:2]...)
+		drpolicy.Spec.SchedulingInterval = `1m`
+		drpolicy.Spec.TopologyRule = ``
+		drpolicy.Spec.AutoFailover = &ramen.AutoFailover{
+			UnavailableGracePeriod: metav1.Duration{Duration: 5 * time.Second},
+			MaxFailoversPerHour:    1,
+		}
+		Expect(k8sClient.Create(context.TODO(), drpolicy)).To(Succeed())
+		validatedConditionExpect(drpolicy, metav1.ConditionTrue, controllers.DRPolicyReasonValidated)
+		Expect(k8sClient.Create(context.TODO(), &ramen.DRCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: `cluster4`},
+			Spec: ramen.DRClusterSpec{
+				S3ProfileName: s3ProfileNameConnectSucc,
+				CIDRs:         []string{`10.0.0.0/24`},
+			},
+		})).To(Succeed())
+		for i, drpc := range autoFailoverDRPCs {
+			*drpc = ramen.DRPlacementControl{
+				ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf(`drpolicy-autofailover-drpc%d`, i), Namespace: `default`},
+				Spec: ramen.DRPlacementControlSpec{
+					PlacementRef:     corev1.ObjectReference{Name: `drpolicy-autofailover-placement`, Kind: `PlacementRule`},
+					DRPolicyRef:      corev1.ObjectReference{Name: drpolicy.Name},
+					PVCSelector:      metav1.LabelSelector{MatchLabels: map[string]string{`app`: `drpolicy`}},
+					PreferredCluster: `cluster4`,
+					CancelAction:     true,
+				},
+			}
+			Expect(k8sClient.Create(context.TODO(), drpc)).To(Succeed())
+			Eventually(func() error {
+				if err := apiReader.Get(context.TODO(), types.NamespacedName{Name: drpc.Name, Namespace: drpc.Namespace},
+					drpc); err != nil {
+					return err
+				}
+				drpc.Status.PreferredDecision.ClusterName = `cluster4`
+				drpc.Status.LastUpdateTime = metav1.Now()
+
+				return k8sClient.Status().Update(context.TODO(), drpc)
+			}, 10, 0.25).Should(Succeed())
+		}
+	})
+	When(`the cluster hosting drpcs of a drpolicy opting in to automatic failover becomes unavailable`, func() {
+		It(`should neither fence it nor fail its drpcs over within the grace period`, func() {
+			managedClusterAvailableSet(`cluster4`, metav1.ConditionFalse)
+			Consistently(func(g Gomega) {
+				g.Expect(autoFailoverDRCluster().Spec.ClusterFence).ToNot(Equal(ramen.ClusterFenceStateFenced))
+				g.Expect(autoFailoverDRPCsFailedOver()).To(Equal(0))
+			}, 3, 0.25).Should(Succeed())
+		})
+		It(`should request its fencing once the grace period elapses`, func() {
+			Eventually(func() ramen.ClusterFenceState {
+				return autoFailoverDRCluster().Spec.ClusterFence
+			}, 10, 0.25).Should(Equal(ramen.ClusterFenceStateFenced))
+		})
+		It(`should not fail its drpcs over until it is fenced`, func() {
+			Consistently(func(g Gomega) {
+				g.Expect(autoFailoverDRCluster().Status.FenceState).ToNot(Equal(ramen.ClusterFenceStateFenced))
+				g.Expect(autoFailoverDRPCsFailedOver()).To(Equal(0))
+			}, 2, 0.25).Should(Succeed())
+		})
+	})
+	When(`the unavailable cluster is fenced`, func() {
+		It(`should fail its drpcs over only up to the maximum number of failovers per hour`, func() {
+			Eventually(func() error {
+				drcluster := autoFailoverDRCluster()
+				drcluster.SetAnnotations(map[string]string{
+					controllers.DRClusterStorageDriverAnnotation:          `drpolicy.test.driver`,
+					controllers.DRClusterStorageSecretNameAnnotation:      `drpolicy-test-secret`,
+					controllers.DRClusterStorageSecretNamespaceAnnotation: `drpolicy-test`,
+				})
+
+				return k8sClient.Update(context.TODO(), drcluster)
+			}, 10, 0.25).Should(Succeed())
+			// the annotations are read on the next fence recheck of the DRCluster
+			Eventually(func() int {
+				return autoFailoverDRPCsFailedOver()
+			}, 40, 0.25).Should(Equal(1))
+			Consistently(func() int {
+				return autoFailoverDRPCsFailedOver()
+			}, 2, 0.25).Should(Equal(1))
+			Expect(apiReader.Get(context.TODO(), types.NamespacedName{Name: drpolicy.Name}, drpolicy)).To(Succeed())
+			Expect(drpolicy.Status.AutoFailoverTimes).To(HaveLen(1))
+		})
+	})
+	When(`the Ramen config disables automatic failover`, func() {
+		It(`should not fail over the remaining drpc, even once the rate limit allows it`, func() {
+			ramenConfigWrite(true)
+			autoFailoverMaxSet(2)
+			autoFailoverDisabledEventExpect(`disabled in the Ramen config`)
+			Consistently(func() int {
+				return autoFailoverDRPCsFailedOver()
+			}, 2, 0.25).Should(Equal(1))
+		})
+	})
+	When(`the Ramen config cannot be read`, func() {
+		It(`should not fail over the remaining drpc`, func() {
+			Expect(os.Remove(ramenConfigFileName)).To(Succeed())
+			autoFailoverMaxSet(3)
+			autoFailoverDisabledEventExpect(`Ramen config cannot be read`)
+			Consistently(func() int {
+				return autoFailoverDRPCsFailedOver()
+			}, 2, 0.25).Should(Equal(1))
+		})
+	})
+	When(`the Ramen config enables automatic failover again`, func() {
+		It(`should fail over the remaining drpc`, func() {
+			ramenConfigWrite(false)
+			autoFailoverMaxSet(4)
+			Eventually(func() int {
+				return autoFailoverDRPCsFailedOver()
+			}, 10, 0.25).Should(Equal(2))
+			Expect(apiReader.Get(context.TODO(), types.NamespacedName{Name: drpolicy.Name}, drpolicy)).To(Succeed())
+			Expect(drpolicy.Status.AutoFailoverTimes).To(HaveLen(2))
+		})
+	})
+	When(`the fenced cluster recovers, and a DRClusterAction relocates the drpcs back to it`, func() {
+		clusterAction := &ramen.DRClusterAction{}
+		It(`should unfence the cluster, as no VRG of the drpcs is primary on it`, func() {
+			managedClusterAvailableSet(`cluster4`, metav1.ConditionTrue)
+			for _, drpc := range autoFailoverDRPCs {
+				drpc := drpc
+				Eventually(func() error {
+					if err := apiReader.Get(context.TODO(), types.NamespacedName{Name: drpc.Name, Namespace: drpc.Namespace},
+						drpc); err != nil {
+						return err
+					}
+					drpc.Status.Phase = ramen.FailedOver
+					drpc.Status.LastUpdateTime = metav1.Now()
+					meta.SetStatusCondition(&drpc.Status.Conditions, metav1.Condition{
+						Type:               ramen.ConditionPeerReady,
+						Status:             metav1.ConditionTrue,
+						ObservedGeneration: drpc.Generation,
+						Reason:             string(ramen.FailedOver),
+						Message:            `ready for a relocation`,
+					})
+
+					return k8sClient.Status().Update(context.TODO(), drpc)
+				}, 10, 0.25).Should(Succeed())
+			}
+			*clusterAction = ramen.DRClusterAction{
+				ObjectMeta: metav1.ObjectMeta{Name: `drpolicy-failback`},
+				Spec: ramen.DRClusterActionSpec{
+					ClusterName:  `cluster4`,
+					Action:       ramen.ActionRelocate,
+					DRPolicyName: drpolicy.Name,
+				},
+			}
+			Expect(k8sClient.Create(context.TODO(), clusterAction)).To(Succeed())
+			Eventually(func() ramen.ClusterFenceState {
+				return autoFailoverDRCluster().Spec.ClusterFence
+			}, 10, 0.25).Should(Equal(ramen.ClusterFenceStateUnfenced))
+			// the unfencing is confirmed on the next fence recheck of the DRCluster
+			Eventually(func() ramen.ClusterFenceState {
+				return autoFailoverDRCluster().Status.FenceState
+			}, 40, 0.25).Should(Equal(ramen.ClusterFenceStateUnfenced))
+		})
+		It(`should relocate the drpcs back to it once unfenced`, func() {
+			// the resync of the drpcs is rechecked periodically
+			Eventually(func(g Gomega) {
+				for _, drpc := range autoFailoverDRPCs {
+					g.Expect(apiReader.Get(context.TODO(), types.NamespacedName{Name: drpc.Name, Namespace: drpc.Namespace},
+						drpc)).To(Succeed())
+					g.Expect(drpc.Spec.Action).To(Equal(ramen.ActionRelocate))
+					g.Expect(drpc.Spec.PreferredCluster).To(Equal(`cluster4`))
+				}
+			}, 70, 0.25).Should(Succeed())
+			Expect(k8sClient.Delete(context.TODO(), clusterAction)).To(Succeed())
+		})
+	})
+	Specify(`drpcs, drcluster and drpolicy delete, and the cluster available again`, func() {
+		for _, drpc := range autoFailoverDRPCs {
+			Expect(k8sClient.Delete(context.TODO(), drpc)).To(Succeed())
+		}
+		drpolicyDeleteAndConfirm(drpolicy)
+		Expect(k8sClient.Delete(context.TODO(), autoFailoverDRCluster())).To(Succeed())
+		Eventually(func() error {
+			return apiReader.Get(context.TODO(), types.NamespacedName{Name: `cluster4`}, &ramen.DRCluster{})
+		}, 10, 0.25).ShouldNot(Succeed())
+		managedClusterAvailableSet(`cluster4`, metav1.ConditionTrue)
+		Expect(os.Remove(ramenConfigFileName)).To(Succeed())
+	})
 })
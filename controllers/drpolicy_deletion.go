@@ -0,0 +1,192 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers/util"
+)
+
+const (
+	// DRPolicyForceDeleteAnnotation when set to "true" on a DRPolicy, lets it be
+	// deleted even though DRPlacementControls still refer to it
+	DRPolicyForceDeleteAnnotation = "drpolicies.ramendr.openshift.io/force-delete"
+
+	// DRPolicyReasonReferenced is the deletion blocked condition reason
+	DRPolicyReasonReferenced = `ReferencedByDRPlacementControls`
+)
+
+func drpolicyForceDeleted(drpolicy *ramen.DRPolicy) bool {
+	return drpolicy.GetAnnotations()[DRPolicyForceDeleteAnnotation] == "true"
+}
+
+// drpolicyDRPCs returns the DRPlacementControls that refer to the drpolicy
+func drpolicyDRPCs(ctx context.Context, reader client.Reader, drpolicyName string,
+) ([]ramen.DRPlacementControl, error) {
+	drpcs := &ramen.DRPlacementControlList{}
+	if err := reader.List(ctx, drpcs); err != nil {
+		return nil, fmt.Errorf("drpcs list: %w", err)
+	}
+
+	referencing := []ramen.DRPlacementControl{}
+
+	for idx := range drpcs.Items {
+		if drpcs.Items[idx].Spec.DRPolicyRef.Name == drpolicyName {
+			referencing = append(referencing, drpcs.Items[idx])
+		}
+	}
+
+	return referencing, nil
+}
+
+// drpcsReferencing returns the namespaced names of the DRPlacementControls
+// that refer to the drpolicy
+func drpcsReferencing(ctx context.Context, reader client.Reader, drpolicyName string) ([]string, error) {
+	drpcs, err := drpolicyDRPCs(ctx, reader, drpolicyName)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(drpcs))
+
+	for idx := range drpcs {
+		names = append(names, drpcNamespacedName(&drpcs[idx]))
+	}
+
+	return names, nil
+}
+
+func drpcNamespacedName(drpc *ramen.DRPlacementControl) string {
+	return types.NamespacedName{Name: drpc.Name, Namespace: drpc.Namespace}.String()
+}
+
+// deletionAllowed returns true if no DRPlacementControl refers to the drpolicy,
+// or if its deletion is forced.  Otherwise, it reports the referring
+// DRPlacementControls in the deletion blocked condition and an event, and the
+// deletion is retried once they are deleted or refer to another drpolicy.
+func (r *DRPolicyReconciler) deletionAllowed(ctx context.Context, drpolicy *ramen.DRPolicy,
+	log logr.Logger,
+) (bool, error) {
+	drpcNames, err := drpcsReferencing(ctx, r.Client, drpolicy.Name)
+	if err != nil {
+		return false, err
+	}
+
+	if len(drpcNames) == 0 {
+		return true, nil
+	}
+
+	msg := fmt.Sprintf("DRPolicy is referenced by DRPlacementControls: %s", strings.Join(drpcNames, ", "))
+
+	if drpolicyForceDeleted(drpolicy) {
+		log.Info("deletion forced", "drpcs", drpcNames)
+		util.ReportIfNotPresent(r.eventRecorder, drpolicy, corev1.EventTypeWarning,
+			util.EventReasonDRPolicyForceDeleted, msg)
+
+		return true, nil
+	}
+
+	log.Info("deletion blocked", "drpcs", drpcNames)
+	util.ReportIfNotPresent(r.eventRecorder, drpolicy, corev1.EventTypeWarning,
+		util.EventReasonDRPolicyDeletionBlocked, msg)
+
+	condition := meta.FindStatusCondition(drpolicy.Status.Conditions, ramen.DRPolicyDeletionBlocked)
+	if condition != nil && condition.Status == metav1.ConditionTrue && condition.Message == msg &&
+		condition.ObservedGeneration == drpolicy.Generation {
+		return false, nil
+	}
+
+	meta.SetStatusCondition(&drpolicy.Status.Conditions, metav1.Condition{
+		Type:               ramen.DRPolicyDeletionBlocked,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: drpolicy.Generation,
+		Reason:             DRPolicyReasonReferenced,
+		Message:            msg,
+	})
+
+	return false, r.Client.Status().Update(ctx, drpolicy)
+}
+
+// pendingDRPoliciesMapFunc maps a DRPlacementControl to the drpolicies being
+// deleted, or whose cluster removal is pending, as the drpolicy it referred to
+// prior to an update is unknown
+func (r *DRPolicyReconciler) pendingDRPoliciesMapFunc(obj client.Object) []reconcile.Request {
+	requests := []reconcile.Request{}
+
+	drpolicies := &ramen.DRPolicyList{}
+	if err := r.Client.List(context.TODO(), drpolicies); err != nil {
+		ctrl.Log.WithName("drpolicy").Error(err, `list`)
+
+		return requests
+	}
+
+	for i := range drpolicies.Items {
+		drpolicy := &drpolicies.Items[i]
+		if drpolicy.DeletionTimestamp.IsZero() &&
+			len(util.DrpolicyAppliedClusterNames(drpolicy)) == len(drpolicy.Spec.DRClusterSet) {
+			continue
+		}
+
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: drpolicy.Name},
+		})
+	}
+
+	return requests
+}
+
+// drpcDRPolicyRefChangedPredicate filters DRPlacementControl events that do not
+// drop a reference to a drpolicy, or a cluster of it
+func drpcDRPolicyRefChangedPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldDRPC, ok := e.ObjectOld.(*ramen.DRPlacementControl)
+			if !ok {
+				return false
+			}
+
+			newDRPC, ok := e.ObjectNew.(*ramen.DRPlacementControl)
+			if !ok {
+				return false
+			}
+
+			return oldDRPC.Spec.DRPolicyRef.Name != newDRPC.Spec.DRPolicyRef.Name ||
+				oldDRPC.Status.PreferredDecision.ClusterName != newDRPC.Status.PreferredDecision.ClusterName
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
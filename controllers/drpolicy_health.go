@@ -0,0 +1,300 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	spokeClusterV1 "github.com/open-cluster-management/api/cluster/v1"
+	viewv1beta1 "github.com/open-cluster-management/multicloud-operators-foundation/pkg/apis/view/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers/util"
+)
+
+// drpolicyHealthCheckInterval is the interval at which the s3 profiles and
+// replication classes of a DRPolicy are rechecked, unless its spec changes
+const drpolicyHealthCheckInterval = 5 * time.Minute
+
+// DRPolicy health condition reasons, when true
+const (
+	DRPolicyReasonClustersAvailable         = `ClustersAvailable`
+	DRPolicyReasonS3Reachable               = `S3Reachable`
+	DRPolicyReasonReplicationClassesPresent = `ReplicationClassesPresent`
+)
+
+// drpolicyCheckResult is the outcome of a DRPolicy check, with the reason of
+// its failure, if any
+type drpolicyCheckResult struct {
+	reason string
+	err    error
+}
+
+type drpolicyHealth struct {
+	clustersAvailable         drpolicyCheckResult
+	s3Reachable               drpolicyCheckResult
+	replicationClassesPresent drpolicyCheckResult
+}
+
+// healthCheck checks the availability of the clusters of the drpolicy, which
+// only reads ManagedClusters, on each reconcile.  Its s3 profiles and
+// replication classes, which require an s3 round trip and a cluster inventory
+// view read per cluster, are only rechecked once the health check interval
+// elapses since their last check, the spec changes, or a check failed, so
+// that a recovery is noticed on the next change of the resources they depend
+// on.  Otherwise their last results are carried over from the health
+// conditions.  It returns the health,
+// whether they were rechecked, and the time till they are next due.
+func (r *DRPolicyReconciler) healthCheck(ctx context.Context, drpolicy *ramen.DRPolicy,
+) (drpolicyHealth, bool, time.Duration) {
+	health := drpolicyHealth{
+		s3Reachable:               drpolicyCheckResultFromCondition(drpolicy, ramen.DRPolicyS3Reachable),
+		replicationClassesPresent: drpolicyCheckResultFromCondition(drpolicy, ramen.DRPolicyReplicationClassesPresent),
+	}
+
+	health.clustersAvailable.reason, health.clustersAvailable.err =
+		validateClusters(ctx, drpolicy, r.APIReader, true)
+
+	if checkAfter := drpolicyHealthCheckDue(drpolicy); checkAfter > 0 {
+		return health, false, checkAfter
+	}
+
+	health.s3Reachable.reason, health.s3Reachable.err =
+		validateS3Profiles(ctx, drpolicy, r.APIReader, r.ObjectStoreGetter)
+	health.replicationClassesPresent.reason, health.replicationClassesPresent.err =
+		validateReplicationClasses(ctx, drpolicy, r.APIReader, r.MCVGetter)
+
+	now := metav1.Now()
+	drpolicy.Status.LastHealthCheckTime = &now
+
+	return health, true, drpolicyHealthCheckInterval
+}
+
+// drpolicyHealthCheckDue returns the time till the s3 profiles and replication
+// classes of the drpolicy are due to be rechecked, or zero if they are due now
+// as they were not checked for the current generation or a check failed
+func drpolicyHealthCheckDue(drpolicy *ramen.DRPolicy) time.Duration {
+	if drpolicy.Status.LastHealthCheckTime == nil {
+		return 0
+	}
+
+	for _, conditionType := range []string{ramen.DRPolicyS3Reachable, ramen.DRPolicyReplicationClassesPresent} {
+		condition := meta.FindStatusCondition(drpolicy.Status.Conditions, conditionType)
+		if condition == nil || condition.ObservedGeneration != drpolicy.Generation ||
+			condition.Status != metav1.ConditionTrue {
+			return 0
+		}
+	}
+
+	checkAfter := drpolicyHealthCheckInterval - time.Since(drpolicy.Status.LastHealthCheckTime.Time)
+	if checkAfter < 0 {
+		return 0
+	}
+
+	return checkAfter
+}
+
+// drpolicyCheckResultFromCondition returns the result of the last check
+// reported by the health condition of the drpolicy
+func drpolicyCheckResultFromCondition(drpolicy *ramen.DRPolicy, conditionType string) drpolicyCheckResult {
+	condition := meta.FindStatusCondition(drpolicy.Status.Conditions, conditionType)
+	if condition == nil || condition.Status != metav1.ConditionFalse {
+		return drpolicyCheckResult{}
+	}
+
+	return drpolicyCheckResult{reason: condition.Reason, err: errors.New(condition.Message)}
+}
+
+// healthConditionsUpdate sets the health conditions of the drpolicy, and emits
+// an event for each condition whose status changes.  The status is updated if
+// a condition changes, or the health was checked.
+func (r *DRPolicyReconciler) healthConditionsUpdate(ctx context.Context, drpolicy *ramen.DRPolicy,
+	health drpolicyHealth, checked bool, log logr.Logger,
+) error {
+	updated := checked
+
+	for _, check := range []struct {
+		conditionType string
+		reason        string
+		message       string
+		result        drpolicyCheckResult
+	}{
+		{ramen.DRPolicyClustersAvailable, DRPolicyReasonClustersAvailable, `clusters available`,
+			health.clustersAvailable},
+		{ramen.DRPolicyS3Reachable, DRPolicyReasonS3Reachable, `s3 profiles reachable`, health.s3Reachable},
+		{ramen.DRPolicyReplicationClassesPresent, DRPolicyReasonReplicationClassesPresent,
+			`replication classes present`, health.replicationClassesPresent},
+	} {
+		condition := metav1.Condition{
+			Type:               check.conditionType,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: drpolicy.Generation,
+			Reason:             check.reason,
+			Message:            check.message,
+		}
+
+		if check.result.err != nil {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = check.result.reason
+			condition.Message = check.result.err.Error()
+		}
+
+		existing := meta.FindStatusCondition(drpolicy.Status.Conditions, check.conditionType)
+		if existing != nil && existing.Status == condition.Status && existing.Reason == condition.Reason &&
+			existing.Message == condition.Message && existing.ObservedGeneration == condition.ObservedGeneration {
+			continue
+		}
+
+		log.Info(`health condition update`, `type`, condition.Type, `status`, condition.Status,
+			`reason`, condition.Reason)
+
+		switch {
+		case condition.Status == metav1.ConditionFalse && (existing == nil || existing.Status != condition.Status):
+			util.ReportIfNotPresent(r.eventRecorder, drpolicy, corev1.EventTypeWarning,
+				util.EventReasonDRPolicyDegraded, fmt.Sprintf(`%s: %s`, condition.Type, condition.Message))
+		case condition.Status == metav1.ConditionTrue && existing != nil && existing.Status == metav1.ConditionFalse:
+			util.ReportIfNotPresent(r.eventRecorder, drpolicy, corev1.EventTypeNormal,
+				util.EventReasonDRPolicyRecovered, fmt.Sprintf(`%s: %s`, condition.Type, condition.Message))
+		}
+
+		meta.SetStatusCondition(&drpolicy.Status.Conditions, condition)
+
+		updated = true
+	}
+
+	if !updated {
+		return nil
+	}
+
+	return r.Client.Status().Update(ctx, drpolicy)
+}
+
+// drpoliciesOfCluster returns reconcile requests for the drpolicies that
+// include the cluster
+func (r *DRPolicyReconciler) drpoliciesOfCluster(clusterName string) []reconcile.Request {
+	requests := []reconcile.Request{}
+
+	drpolicies := &ramen.DRPolicyList{}
+	if err := r.Client.List(context.TODO(), drpolicies); err != nil {
+		ctrl.Log.WithName("drpolicy").Error(err, `list`)
+
+		return requests
+	}
+
+	for i := range drpolicies.Items {
+		drpolicy := &drpolicies.Items[i]
+		for _, name := range util.DrpolicyClusterNames(drpolicy) {
+			if name == clusterName {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: drpolicy.Name}})
+
+				break
+			}
+		}
+	}
+
+	return requests
+}
+
+// managedClusterMapFunc maps a ManagedCluster, or a DRCluster, to the
+// drpolicies that include its cluster
+func (r *DRPolicyReconciler) managedClusterMapFunc(obj client.Object) []reconcile.Request {
+	return r.drpoliciesOfCluster(obj.GetName())
+}
+
+// inventoryViewMapFunc maps a cluster inventory view, in the namespace of the
+// cluster, to the drpolicies that include the cluster
+func (r *DRPolicyReconciler) inventoryViewMapFunc(obj client.Object) []reconcile.Request {
+	if _, ok := obj.(*viewv1beta1.ManagedClusterView); !ok || obj.GetName() != clusterInventoryViewName() {
+		return []reconcile.Request{}
+	}
+
+	return r.drpoliciesOfCluster(obj.GetNamespace())
+}
+
+func (r *DRPolicyReconciler) allDRPoliciesMapFunc(obj client.Object) []reconcile.Request {
+	requests := []reconcile.Request{}
+
+	drpolicies := &ramen.DRPolicyList{}
+	if err := r.Client.List(context.TODO(), drpolicies); err != nil {
+		ctrl.Log.WithName("drpolicy").Error(err, `list`)
+
+		return requests
+	}
+
+	for i := range drpolicies.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: drpolicies.Items[i].Name},
+		})
+	}
+
+	return requests
+}
+
+// managedClusterConditionsChangedPredicate filters ManagedCluster updates that
+// do not change its conditions, such as resource usage updates
+func managedClusterConditionsChangedPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldManagedCluster, ok := e.ObjectOld.(*spokeClusterV1.ManagedCluster)
+			if !ok {
+				return false
+			}
+
+			newManagedCluster, ok := e.ObjectNew.(*spokeClusterV1.ManagedCluster)
+			if !ok {
+				return false
+			}
+
+			return !reflect.DeepEqual(oldManagedCluster.Status.Conditions, newManagedCluster.Status.Conditions)
+		},
+	}
+}
+
+// dataChangedPredicate filters Secret and ConfigMap updates that do not change
+// their data, such as leader election record updates
+func dataChangedPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			switch oldObject := e.ObjectOld.(type) {
+			case *corev1.Secret:
+				newObject, ok := e.ObjectNew.(*corev1.Secret)
+
+				return ok && !reflect.DeepEqual(oldObject.Data, newObject.Data)
+			case *corev1.ConfigMap:
+				newObject, ok := e.ObjectNew.(*corev1.ConfigMap)
+
+				return ok && !reflect.DeepEqual(oldObject.Data, newObject.Data)
+			}
+
+			return false
+		},
+	}
+}
@@ -137,6 +137,14 @@ func getRamenConfigS3StoreProfile(profileName string) (
 	return s3StoreProfile, nil
 }
 
+// ValidateS3Profile returns an error if the S3 profile is not configured, or is
+// misconfigured, in the Ramen config
+func ValidateS3Profile(profileName string) error {
+	_, err := getRamenConfigS3StoreProfile(profileName)
+
+	return err
+}
+
 func getMaxConcurrentReconciles() int {
 	const defaultMaxConcurrentReconciles = 1
 
@@ -151,3 +159,20 @@ func getMaxConcurrentReconciles() int {
 
 	return ramenConfig.MaxConcurrentReconciles
 }
+
+// autoFailoverDisabled returns true if the Ramen config disables the automatic
+// failover of DRPlacementControls.  It fails closed, returning true along with
+// the error, if the Ramen config file cannot be read, as the kill switch may
+// then be missed.  Without a Ramen config file, nothing disables it.
+func autoFailoverDisabled() (bool, error) {
+	if cachedRamenConfigFileName == "" {
+		return false, nil
+	}
+
+	ramenConfig, err := ReadRamenConfig()
+	if err != nil {
+		return true, err
+	}
+
+	return ramenConfig.AutoFailoverDisabled, nil
+}
@@ -108,6 +108,7 @@ type ObjectStorer interface {
 	ListKeys(bucket string, keyPrefix string) (keys []string, err error)
 	DownloadObject(bucket string, key string, downloadContent interface{}) error
 	DeleteObject(bucket, keyPrefix string) error
+	DeleteObjects(bucket string, keys ...string) error
 }
 
 // S3ObjectStoreGetter returns a concrete type that implements
@@ -124,8 +125,10 @@ type s3ObjectStoreGetter struct{}
 // ObjectStore returns an S3 object store that satisfies the ObjectStorer
 // interface,  with a downloader and an uploader client connections, by either
 // creating a new connection or returning a previously established connection
-// for the given s3 profile.  Returns an error if s3 profile does not exists,
-// secret is not configured, or if client session creation fails.
+// for the given s3 profile.  A previously established connection is replaced
+// if the credentials in the secret changed.  Returns an error if s3 profile
+// does not exists, secret is not configured, or if client session creation
+// fails.
 func (s3ObjectStoreGetter) ObjectStore(ctx context.Context,
 	r client.Reader, s3ProfileName string,
 	callerTag string) (ObjectStorer, error) {
@@ -135,18 +138,19 @@ func (s3ObjectStoreGetter) ObjectStore(ctx context.Context,
 			s3ProfileName, callerTag, err)
 	}
 
-	// Use cached connection, if one exists
-	s3Endpoint := s3StoreProfile.S3CompatibleEndpoint
-	if s3ObjectStore, ok := s3ConnectionMap[s3Endpoint]; ok {
-		return s3ObjectStore, nil
-	}
-
 	accessID, secretAccessKey, err := getS3Secret(ctx, r, s3StoreProfile.S3SecretRef)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get secret %v for caller %s, %w",
 			s3StoreProfile.S3SecretRef, callerTag, err)
 	}
 
+	// Use cached connection, if one exists with the same credentials
+	s3Endpoint := s3StoreProfile.S3CompatibleEndpoint
+	if s3ObjectStore, ok := s3ConnectionMap[s3Endpoint]; ok &&
+		s3ObjectStore.accessID == string(accessID) && s3ObjectStore.secretAccessKey == string(secretAccessKey) {
+		return s3ObjectStore, nil
+	}
+
 	s3Region := s3StoreProfile.S3Region
 
 	// Create an S3 client session
@@ -173,13 +177,15 @@ func (s3ObjectStoreGetter) ObjectStore(ctx context.Context,
 	s3Downloader := s3manager.NewDownloaderWithClient(s3Client)
 	s3BatchDeleter := s3manager.NewBatchDeleteWithClient(s3Client)
 	s3Conn := &s3ObjectStore{
-		session:      s3Session,
-		client:       s3Client,
-		uploader:     s3Uploader,
-		downloader:   s3Downloader,
-		batchDeleter: s3BatchDeleter,
-		s3Endpoint:   s3Endpoint,
-		callerTag:    callerTag,
+		session:         s3Session,
+		client:          s3Client,
+		uploader:        s3Uploader,
+		downloader:      s3Downloader,
+		batchDeleter:    s3BatchDeleter,
+		s3Endpoint:      s3Endpoint,
+		callerTag:       callerTag,
+		accessID:        string(accessID),
+		secretAccessKey: string(secretAccessKey),
 	}
 	s3ConnectionMap[s3Endpoint] = s3Conn
 
@@ -211,6 +217,9 @@ type s3ObjectStore struct {
 	batchDeleter *s3manager.BatchDelete
 	s3Endpoint   string
 	callerTag    string
+	// credentials of the connection, to replace it once they change
+	accessID        string
+	secretAccessKey string
 }
 
 // S3 object store map with s3Endpoint as the key to serve as cache
@@ -562,6 +571,17 @@ func (s *s3ObjectStore) DeleteObject(bucket string, keyPrefix string) (
 			s.s3Endpoint, bucket, keyPrefix, err)
 	}
 
+	if err = s.DeleteObjects(bucket, keys...); err != nil {
+		return fmt.Errorf("unable to DeleteObjects with keyPrefix %s, %w", keyPrefix, err)
+	}
+
+	return
+}
+
+// DeleteObjects() deletes from the given bucket the objects with the given
+// keys.  Unlike DeleteObject(), keys are not treated as prefixes.
+func (s *s3ObjectStore) DeleteObjects(bucket string, keys ...string) (
+	err error) {
 	numObjects := len(keys)
 	delObjects := make([]s3manager.BatchDeleteObject, numObjects)
 
@@ -578,8 +598,8 @@ func (s *s3ObjectStore) DeleteObject(bucket string, keyPrefix string) (
 		Objects: delObjects,
 	}); err != nil {
 		return fmt.Errorf("unable to DeleteObjects "+
-			"from endpoint %s bucket %s keyPrefix %s, %w",
-			s.s3Endpoint, bucket, keyPrefix, err)
+			"from endpoint %s bucket %s keys %v, %w",
+			s.s3Endpoint, bucket, keys, err)
 	}
 
 	return
@@ -18,8 +18,12 @@ package controllers_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"reflect"
+	"strings"
+	"sync"
 
 	"github.com/ramendr/ramen/controllers"
 	corev1 "k8s.io/api/core/v1"
@@ -59,7 +63,24 @@ func (fakeObjectStorer) UploadTypedObject(bucket string, keySuffix string, uploa
 	return nil
 }
 
+// fakeObjects holds the objects uploaded using UploadObject, keyed by bucket
+// and key, for them to be downloaded and deleted
+var fakeObjects = struct {
+	sync.Mutex
+	objects map[string][]byte
+}{objects: map[string][]byte{}}
+
 func (fakeObjectStorer) UploadObject(bucket string, key string, uploadContent interface{}) error {
+	data, err := json.Marshal(uploadContent)
+	if err != nil {
+		return err
+	}
+
+	fakeObjects.Lock()
+	defer fakeObjects.Unlock()
+
+	fakeObjects.objects[bucket+`/`+key] = data
+
 	return nil
 }
 
@@ -80,6 +101,28 @@ func (fakeObjectStorer) ListKeys(bucket string, keyPrefix string) ([]string, err
 }
 
 func (fakeObjectStorer) DownloadObject(bucket string, key string, downloadContent interface{}) error {
+	fakeObjects.Lock()
+	defer fakeObjects.Unlock()
+
+	data, ok := fakeObjects.objects[bucket+`/`+key]
+	if !ok {
+		return fmt.Errorf(`object %s/%s not found`, bucket, key)
+	}
+
+	return json.Unmarshal(data, downloadContent)
+}
+
+func (fakeObjectStorer) DeleteObject(bucket, keyPrefix string) error {
+	fakeObjects.Lock()
+	defer fakeObjects.Unlock()
+
+	for key := range fakeObjects.objects {
+		if strings.HasPrefix(key, bucket+`/`+keyPrefix) {
+			delete(fakeObjects.objects, key)
+		}
+	}
+
 	return nil
 }
-func (fakeObjectStorer) DeleteObject(bucket, keyPrefix string) error { return nil }
+
+func (fakeObjectStorer) DeleteObjects(bucket string, keys ...string) error { return nil }
@@ -47,6 +47,18 @@ const (
 	// which is active in a cluster, has all its PV related cluster data
 	// protected from a disaster by uploading it to the required S3 store(s).
 	VRGConditionTypeClusterDataProtected = "ClusterDataProtected"
+
+	// PV data needs to be resynced with the primary.  This condition is only
+	// applicable to a VRG as secondary, and is true when the storage reports
+	// that the volume has diverged from the primary (split-brain) or is
+	// degraded without resyncing, and remains true until a requested resync
+	// completes.
+	VRGConditionTypeResyncRequired = "ResyncRequired"
+
+	// VRG is paused.  When paused, VRG does not create, update or delete
+	// VolumeReplication resources, nor upload or delete PV cluster data, but
+	// continues to report their status.
+	VRGConditionTypePaused = "Paused"
 )
 
 // VRG condition reasons
@@ -63,6 +75,13 @@ const (
 	VRGConditionReasonUploading           = "Uploading"
 	VRGConditionReasonUploaded            = "Uploaded"
 	VRGConditionReasonUploadError         = "UploadError"
+	VRGConditionReasonSplitBrain          = "SplitBrain"
+	VRGConditionReasonDegraded            = "Degraded"
+	VRGConditionReasonResyncing           = "Resyncing"
+	VRGConditionReasonResynced            = "Resynced"
+	VRGConditionReasonHealthy             = "Healthy"
+	VRGConditionReasonPaused              = "Paused"
+	VRGConditionReasonResumed             = "Resumed"
 )
 
 // Just when VRG has been picked up for reconciliation when nothing has been
@@ -277,6 +296,52 @@ func setVRGClusterDataUnprotectedCondition(conditions *[]metav1.Condition, obser
 	})
 }
 
+// sets conditions when PV data needs to be resynced, or is being resynced
+func setVRGResyncRequiredCondition(conditions *[]metav1.Condition, observedGeneration int64,
+	reason, message string) {
+	setStatusCondition(conditions, metav1.Condition{
+		Type:               VRGConditionTypeResyncRequired,
+		Reason:             reason,
+		ObservedGeneration: observedGeneration,
+		Status:             metav1.ConditionTrue,
+		Message:            message,
+	})
+}
+
+// sets conditions when PV data does not need a resync
+func setVRGResyncNotRequiredCondition(conditions *[]metav1.Condition, observedGeneration int64,
+	reason, message string) {
+	setStatusCondition(conditions, metav1.Condition{
+		Type:               VRGConditionTypeResyncRequired,
+		Reason:             reason,
+		ObservedGeneration: observedGeneration,
+		Status:             metav1.ConditionFalse,
+		Message:            message,
+	})
+}
+
+// sets conditions when VRG is paused
+func setVRGPausedCondition(conditions *[]metav1.Condition, observedGeneration int64, message string) {
+	setStatusCondition(conditions, metav1.Condition{
+		Type:               VRGConditionTypePaused,
+		Reason:             VRGConditionReasonPaused,
+		ObservedGeneration: observedGeneration,
+		Status:             metav1.ConditionTrue,
+		Message:            message,
+	})
+}
+
+// sets conditions when VRG is resumed post a pause
+func setVRGResumedCondition(conditions *[]metav1.Condition, observedGeneration int64, message string) {
+	setStatusCondition(conditions, metav1.Condition{
+		Type:               VRGConditionTypePaused,
+		Reason:             VRGConditionReasonResumed,
+		ObservedGeneration: observedGeneration,
+		Status:             metav1.ConditionFalse,
+		Message:            message,
+	})
+}
+
 func setStatusCondition(existingConditions *[]metav1.Condition, newCondition metav1.Condition) {
 	if existingConditions == nil {
 		existingConditions = &[]metav1.Condition{}
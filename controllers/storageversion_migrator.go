@@ -0,0 +1,157 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// HubCRDNames are the names of the CRDs whose resources are reconciled, and
+// hence migrated, by the hub operator
+var HubCRDNames = []string{
+	"drpolicies.ramendr.openshift.io",
+	"drplacementcontrols.ramendr.openshift.io",
+	"drclusters.ramendr.openshift.io",
+	"dractionrequests.ramendr.openshift.io",
+	"drclusteractions.ramendr.openshift.io",
+	"drplacementcontrolgroups.ramendr.openshift.io",
+}
+
+// DRClusterCRDNames are the names of the CRDs whose resources are reconciled,
+// and hence migrated, by the dr-cluster operator
+var DRClusterCRDNames = []string{
+	"volumereplicationgroups.ramendr.openshift.io",
+}
+
+// StorageVersionMigrator migrates the resources of the given CRDs to the CRD
+// storage version, by rewriting each of them, and then drops older versions
+// from the stored versions in the CRD status.  This allows older versions to be
+// removed from the CRDs in a future release, without orphaning resources still
+// stored in them.  It requires the conversion webhook to be available.
+type StorageVersionMigrator struct {
+	Client    client.Client
+	APIReader client.Reader
+	Log       logr.Logger
+
+	// Names of the CRDs whose resources are migrated
+	CRDNames []string
+
+	// Interval to retry a failed migration
+	RetryInterval time.Duration
+}
+
+//+kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
+//+kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions/status,verbs=get;update;patch
+
+// Start migrates the resources of the CRDs, retrying till all are migrated or
+// the context is done.  It implements manager.Runnable.
+func (m *StorageVersionMigrator) Start(ctx context.Context) error {
+	err := wait.PollImmediateUntil(m.RetryInterval, func() (bool, error) {
+		for _, crdName := range m.CRDNames {
+			if err := m.migrate(ctx, crdName); err != nil {
+				m.Log.Error(err, "Storage version migration failed, will retry", "crd", crdName)
+
+				return false, nil
+			}
+		}
+
+		return true, nil
+	}, ctx.Done())
+	if err != nil && err != wait.ErrWaitTimeout {
+		return fmt.Errorf("storage version migration: %w", err)
+	}
+
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, such that only
+// the leader migrates resources
+func (m *StorageVersionMigrator) NeedLeaderElection() bool {
+	return true
+}
+
+func (m *StorageVersionMigrator) migrate(ctx context.Context, crdName string) error {
+	log := m.Log.WithValues("crd", crdName)
+
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := m.APIReader.Get(ctx, types.NamespacedName{Name: crdName}, crd); err != nil {
+		return fmt.Errorf("failed to get CRD %s: %w", crdName, err)
+	}
+
+	storageVersion := ""
+
+	for _, version := range crd.Spec.Versions {
+		if version.Storage {
+			storageVersion = version.Name
+
+			break
+		}
+	}
+
+	if storageVersion == "" {
+		return fmt.Errorf("CRD %s has no storage version", crdName)
+	}
+
+	if len(crd.Status.StoredVersions) == 1 && crd.Status.StoredVersions[0] == storageVersion {
+		log.Info("Resources stored in storage version", "version", storageVersion)
+
+		return nil
+	}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   crd.Spec.Group,
+		Version: storageVersion,
+		Kind:    crd.Spec.Names.ListKind,
+	})
+
+	if err := m.Client.List(ctx, list); err != nil {
+		return fmt.Errorf("failed to list resources of CRD %s: %w", crdName, err)
+	}
+
+	for index := range list.Items {
+		resource := &list.Items[index]
+
+		// An update without changes rewrites the resource in the storage version.
+		// A conflict implies that the resource was rewritten by another update.
+		if err := m.Client.Update(ctx, resource); err != nil && !errors.IsNotFound(err) && !errors.IsConflict(err) {
+			return fmt.Errorf("failed to migrate %s %s/%s: %w", crd.Spec.Names.Kind,
+				resource.GetNamespace(), resource.GetName(), err)
+		}
+	}
+
+	log.Info("Migrated resources to storage version", "version", storageVersion, "count", len(list.Items),
+		"storedVersions", crd.Status.StoredVersions)
+
+	crd.Status.StoredVersions = []string{storageVersion}
+	if err := m.Client.Status().Update(ctx, crd); err != nil {
+		return fmt.Errorf("failed to update stored versions of CRD %s: %w", crdName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,22 @@
+package controllers_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+var _ = Describe("StorageVersionMigrator", func() {
+	It("migrates every Ramen CRD with more than one version", func() {
+		crdNames := append(append([]string{}, controllers.HubCRDNames...), controllers.DRClusterCRDNames...)
+		for _, object := range testEnv.CRDs {
+			crd, ok := object.(*apiextensionsv1.CustomResourceDefinition)
+			if !ok || crd.Spec.Group != ramen.GroupVersion.Group || len(crd.Spec.Versions) < 2 {
+				continue
+			}
+			Expect(crdNames).To(ContainElement(crd.Name))
+		}
+	})
+})
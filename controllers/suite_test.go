@@ -17,11 +17,14 @@ limitations under the License.
 package controllers_test
 
 import (
+	"context"
 	"path/filepath"
 	"testing"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -30,6 +33,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/envtest/printer"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
 
 	volrep "github.com/csi-addons/volume-replication-operator/api/v1alpha1"
 	ocmclv1 "github.com/open-cluster-management/api/cluster/v1"
@@ -37,6 +41,7 @@ import (
 	viewv1beta1 "github.com/open-cluster-management/multicloud-operators-foundation/pkg/apis/view/v1beta1"
 	subv1 "github.com/open-cluster-management/multicloud-operators-subscription/pkg/apis"
 	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+	ramendrv1beta1 "github.com/ramendr/ramen/api/v1beta1"
 	ramencontrollers "github.com/ramendr/ramen/controllers"
 	// +kubebuilder:scaffold:imports
 )
@@ -87,6 +92,9 @@ var _ = BeforeSuite(func() {
 	err = ramendrv1alpha1.AddToScheme(scheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = ramendrv1beta1.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
+
 	err = viewv1beta1.AddToScheme(scheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -100,16 +108,61 @@ var _ = BeforeSuite(func() {
 	Expect(k8sClient).NotTo(BeNil())
 
 	// test controller behavior
+	webhookInstallOptions := &testEnv.WebhookInstallOptions
 	k8sManager, err := ctrl.NewManager(cfg, ctrl.Options{
-		Scheme: scheme.Scheme,
+		Scheme:  scheme.Scheme,
+		Host:    webhookInstallOptions.LocalServingHost,
+		Port:    webhookInstallOptions.LocalServingPort,
+		CertDir: webhookInstallOptions.LocalServingCertDir,
 	})
 	Expect(err).ToNot(HaveOccurred())
 
+	// envtest configures the CRDs to convert resources to and from their
+	// storage version using the conversion webhook served by the manager
+	k8sManager.GetWebhookServer().Register("/convert", &conversion.Webhook{})
+
 	Expect((&ramencontrollers.DRPolicyReconciler{
 		Client:            k8sManager.GetClient(),
 		APIReader:         k8sManager.GetAPIReader(),
 		Scheme:            k8sManager.GetScheme(),
 		ObjectStoreGetter: fakeObjectStoreGetter{},
+		MCVGetter:         FakeMCVGetter{},
+	}).SetupWithManager(k8sManager)).To(Succeed())
+
+	Expect((&ramencontrollers.DRClusterReconciler{
+		Client:    k8sManager.GetClient(),
+		APIReader: k8sManager.GetAPIReader(),
+		Log:       ctrl.Log.WithName("controllers").WithName("DRCluster"),
+		MCVGetter: FakeMCVGetter{},
+	}).SetupWithManager(k8sManager)).To(Succeed())
+
+	Expect((&ramencontrollers.DRActionRequestReconciler{
+		Client:    k8sManager.GetClient(),
+		APIReader: k8sManager.GetAPIReader(),
+		Log:       ctrl.Log.WithName("controllers").WithName("DRActionRequest"),
+	}).SetupWithManager(k8sManager)).To(Succeed())
+
+	Expect((&ramencontrollers.DRClusterActionReconciler{
+		Client:    k8sManager.GetClient(),
+		APIReader: k8sManager.GetAPIReader(),
+		Log:       ctrl.Log.WithName("controllers").WithName("DRClusterAction"),
+		MCVGetter: FakeMCVGetter{},
+	}).SetupWithManager(k8sManager)).To(Succeed())
+
+	Expect((&ramencontrollers.DRPlacementControlGroupReconciler{
+		Client:    k8sManager.GetClient(),
+		APIReader: k8sManager.GetAPIReader(),
+		Log:       ctrl.Log.WithName("controllers").WithName("DRPlacementControlGroup"),
+	}).SetupWithManager(k8sManager)).To(Succeed())
+
+	Expect(k8sClient.Create(context.TODO(), &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: ramencontrollers.OperatorNamespace()},
+	})).To(Succeed())
+
+	Expect((&ramencontrollers.ClusterInventoryReconciler{
+		Client:    k8sManager.GetClient(),
+		APIReader: k8sManager.GetAPIReader(),
+		Log:       ctrl.Log.WithName("controllers").WithName("ClusterInventory"),
 	}).SetupWithManager(k8sManager)).To(Succeed())
 
 	err = (&ramencontrollers.VolumeReplicationGroupReconciler{
@@ -18,6 +18,11 @@ package util
 
 import (
 	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -34,6 +39,44 @@ func DrpolicyClusterNames(drpolicy *rmn.DRPolicy) []string {
 	return clusterNames
 }
 
+// DrpolicyAppliedClusterNames returns the names of the clusters in the spec of
+// the DRPolicy, and of the clusters whose removal from it is pending
+func DrpolicyAppliedClusterNames(drpolicy *rmn.DRPolicy) []string {
+	clusterNames := DrpolicyClusterNames(drpolicy)
+
+	for _, clusterName := range drpolicy.Status.ClusterSet {
+		if !DrpolicyContainsCluster(drpolicy, clusterName) {
+			clusterNames = append(clusterNames, clusterName)
+		}
+	}
+
+	return clusterNames
+}
+
+// DrpolicyContainsCluster returns true if the cluster is in the spec of the
+// DRPolicy
+func DrpolicyContainsCluster(drpolicy *rmn.DRPolicy, clusterName string) bool {
+	for i := range drpolicy.Spec.DRClusterSet {
+		if drpolicy.Spec.DRClusterSet[i].Name == clusterName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DrpolicyClusterRemovalPending returns true if the cluster was removed from the
+// spec of the DRPolicy, but its removal is not complete
+func DrpolicyClusterRemovalPending(drpolicy *rmn.DRPolicy, clusterName string) bool {
+	for _, name := range drpolicy.Status.ClusterSet {
+		if name == clusterName {
+			return !DrpolicyContainsCluster(drpolicy, clusterName)
+		}
+	}
+
+	return false
+}
+
 func DrpolicyValidatedConditionGet(drpolicy *rmn.DRPolicy) *metav1.Condition {
 	return meta.FindStatusCondition(drpolicy.Status.Conditions, rmn.DRPolicyValidated)
 }
@@ -50,6 +93,33 @@ func DrpolicyValidated(drpolicy *rmn.DRPolicy) error {
 	return errors.New(`validated condition absent`)
 }
 
+// DrpolicyHealthConditionTypes are the types of the conditions reporting the
+// health of a DRPolicy
+var DrpolicyHealthConditionTypes = []string{
+	rmn.DRPolicyClustersAvailable,
+	rmn.DRPolicyS3Reachable,
+	rmn.DRPolicyReplicationClassesPresent,
+}
+
+// DrpolicyDegradedMessage returns a message listing the health conditions of
+// the DRPolicy that are false, or an empty message if the DRPolicy is healthy
+func DrpolicyDegradedMessage(drpolicy *rmn.DRPolicy) string {
+	messages := []string{}
+
+	for _, conditionType := range DrpolicyHealthConditionTypes {
+		condition := meta.FindStatusCondition(drpolicy.Status.Conditions, conditionType)
+		if condition != nil && condition.Status == metav1.ConditionFalse {
+			messages = append(messages, fmt.Sprintf("%s: %s", conditionType, condition.Message))
+		}
+	}
+
+	if len(messages) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("DRPolicy %s is degraded, %s", drpolicy.Name, strings.Join(messages, "; "))
+}
+
 // Return a list of unique S3 profiles to upload the relevant cluster state
 func S3UploadProfileList(drPolicy rmn.DRPolicy) (s3ProfileList []string) {
 	for _, drCluster := range drPolicy.Spec.DRClusterSet {
@@ -68,3 +138,41 @@ func S3UploadProfileList(drPolicy rmn.DRPolicy) (s3ProfileList []string) {
 
 	return
 }
+
+// SchedulingIntervalParse returns the duration of a DRPolicy scheduling interval
+// of the form <num><m,h,d>, where num is greater than zero
+func SchedulingIntervalParse(interval string) (time.Duration, error) {
+	const minLength = 2
+
+	if len(interval) < minLength {
+		return 0, fmt.Errorf("scheduling interval %q is not of the form <num><m,h,d>", interval)
+	}
+
+	var unit time.Duration
+
+	switch interval[len(interval)-1] {
+	case 'm':
+		unit = time.Minute
+	case 'h':
+		unit = time.Hour
+	case 'd':
+		unit = 24 * time.Hour
+	default:
+		return 0, fmt.Errorf("scheduling interval %q unit is not one of m, h or d", interval)
+	}
+
+	num, err := strconv.ParseInt(interval[:len(interval)-1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("scheduling interval %q is not of the form <num><m,h,d>, %w", interval, err)
+	}
+
+	if num <= 0 {
+		return 0, fmt.Errorf("scheduling interval %q is not greater than zero", interval)
+	}
+
+	if num > math.MaxInt64/int64(unit) {
+		return 0, fmt.Errorf("scheduling interval %q is too large", interval)
+	}
+
+	return time.Duration(num) * unit, nil
+}
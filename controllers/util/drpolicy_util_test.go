@@ -0,0 +1,38 @@
+package util_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	rmnutil "github.com/ramendr/ramen/controllers/util"
+)
+
+var _ = Describe("SchedulingIntervalParse", func() {
+	DescribeTable("valid scheduling intervals",
+		func(interval string, expected time.Duration) {
+			duration, err := rmnutil.SchedulingIntervalParse(interval)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(duration).To(Equal(expected))
+		},
+		Entry("minutes", "5m", 5*time.Minute),
+		Entry("hours", "1h", time.Hour),
+		Entry("days", "2d", 48*time.Hour),
+		Entry("leading zeros", "01m", time.Minute),
+	)
+
+	DescribeTable("invalid scheduling intervals",
+		func(interval string) {
+			_, err := rmnutil.SchedulingIntervalParse(interval)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("empty", ""),
+		Entry("no number", "m"),
+		Entry("no unit", "10"),
+		Entry("seconds", "30s"),
+		Entry("zero", "00m"),
+		Entry("negative", "-1h"),
+		Entry("too large", "9999999d"),
+	)
+})
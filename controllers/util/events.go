@@ -64,6 +64,21 @@ const (
 	// EventReasonSecondarySuccess is an event generated when VRG is successfully
 	// processed as Primary.
 	EventReasonDeleteSuccess = "VRGDeleteSuccess"
+
+	// EventReasonResyncRequired is generated when VRG detects that a volume
+	// needs to be resynced with its primary
+	EventReasonResyncRequired = "VRGResyncRequired"
+
+	// EventReasonResyncSuccess is generated when a requested resync of a
+	// volume completes
+	EventReasonResyncSuccess = "VRGResyncSuccess"
+
+	// EventReasonPVCUnprotected is generated when VRG unprotects a PVC that
+	// is no longer selected by it
+	EventReasonPVCUnprotected = "PVCUnprotected"
+
+	// EventReasonPaused is generated when VRG is paused
+	EventReasonPaused = "VRGPaused"
 	// TODO: Add any additional events (or remove one of existing ones above) if necessary.
 
 	// Events for DRPC Reconciler
@@ -99,6 +114,154 @@ const (
 	// EventReasonSwitchFailed is generated when DRPC fails to switch the cluster
 	// where the app is placed
 	EventReasonSwitchFailed = "DRPCClusterSwitchFailed"
+
+	// EventReasonActionRefused is generated when DRPC refuses to act on a
+	// failover or relocate request, as a VRG it manages is paused, as the
+	// DRCluster of the target cluster reports it as unavailable, or, in Sync
+	// replication mode, as the cluster to fail over from is not fenced
+	EventReasonActionRefused = "DRPCActionRefused"
+
+	// EventReasonFencing is generated when DRPC requests the fencing of the
+	// cluster it fails over from
+	EventReasonFencing = "DRPCFencing"
+
+	// EventReasonUnfencing is generated when DRPC requests the unfencing of the
+	// cluster it relocates to
+	EventReasonUnfencing = "DRPCUnfencing"
+
+	// EventReasonFencingSkipped is generated when DRPC fails over without
+	// fencing the cluster it fails over from, as its annotation requests
+	EventReasonFencingSkipped = "DRPCFencingSkipped"
+
+	// EventReasonFailoverClusterSelected is generated when DRPC selects the
+	// failover cluster of a failover request that does not specify one
+	EventReasonFailoverClusterSelected = "DRPCFailoverClusterSelected"
+
+	// EventReasonSplitBrainDetected is generated when DRPC detects that more
+	// than one cluster reports its VRG as Primary
+	EventReasonSplitBrainDetected = "DRPCSplitBrainDetected"
+
+	// EventReasonSplitBrainResolved is generated when DRPC demotes the VRGs of
+	// the clusters other than the split-brain winner
+	EventReasonSplitBrainResolved = "DRPCSplitBrainResolved"
+
+	// EventReasonActionCancelled is generated when DRPC rolls a cancelled
+	// failover or relocation back to the cluster it started from
+	EventReasonActionCancelled = "DRPCActionCancelled"
+
+	// EventReasonActionCancelRefused is generated when DRPC refuses to cancel
+	// a failover or relocation past the point of no return
+	EventReasonActionCancelRefused = "DRPCActionCancelRefused"
+
+	// EventReasonActionApprovalRequired is generated when DRPC holds a
+	// failover or relocation until it is approved
+	EventReasonActionApprovalRequired = "DRPCActionApprovalRequired"
+
+	// EventReasonActionApproved is generated when a failover or relocation
+	// of DRPC is approved
+	EventReasonActionApproved = "DRPCActionApproved"
+
+	// EventReasonActionRejected is generated when a failover or relocation of
+	// DRPC is rejected
+	EventReasonActionRejected = "DRPCActionRejected"
+
+	// EventReasonActionApprovalExpired is generated when a failover or
+	// relocation of DRPC is not approved in time
+	EventReasonActionApprovalExpired = "DRPCActionApprovalExpired"
+
+	// EventReasonActionApprovalInvalid is generated when DRPC ignores the
+	// decision of a user that may not approve or reject its action
+	EventReasonActionApprovalInvalid = "DRPCActionApprovalInvalid"
+
+	// Events for DRPolicy Reconciler
+
+	// EventReasonDRPolicyDegraded is generated when a health condition of a
+	// DRPolicy becomes false
+	EventReasonDRPolicyDegraded = "DRPolicyDegraded"
+
+	// EventReasonDRPolicyRecovered is generated when a health condition of a
+	// DRPolicy becomes true after being false
+	EventReasonDRPolicyRecovered = "DRPolicyRecovered"
+
+	// EventReasonDRPolicyDeletionBlocked is generated when the deletion of a
+	// DRPolicy is held, as DRPlacementControls still refer to it
+	EventReasonDRPolicyDeletionBlocked = "DRPolicyDeletionBlocked"
+
+	// EventReasonDRPolicyForceDeleted is generated when a DRPolicy that
+	// DRPlacementControls still refer to is deleted, as it is annotated to force
+	// its deletion
+	EventReasonDRPolicyForceDeleted = "DRPolicyForceDeleted"
+
+	// EventReasonDRPolicyClusterRemovalBlocked is generated when the removal of
+	// a cluster from a DRPolicy is held, as the cluster hosts the primary of a
+	// DRPlacementControl
+	EventReasonDRPolicyClusterRemovalBlocked = "DRPolicyClusterRemovalBlocked"
+
+	// EventReasonAutoFailover is generated when a DRPolicy fails over one of its
+	// DRPCs automatically from an unavailable cluster
+	EventReasonAutoFailover = "DRPolicyAutoFailover"
+
+	// EventReasonAutoFailoverFencing is generated when a DRPolicy fences an
+	// unavailable cluster, before failing over its DRPCs automatically
+	EventReasonAutoFailoverFencing = "DRPolicyAutoFailoverFencing"
+
+	// EventReasonAutoFailoverBlocked is generated when a DRPolicy cannot fail
+	// over the DRPCs of an unavailable cluster automatically, as the cluster
+	// cannot be fenced, or no peer cluster is available
+	EventReasonAutoFailoverBlocked = "DRPolicyAutoFailoverBlocked"
+
+	// EventReasonAutoFailoverRateLimited is generated when a DRPolicy holds
+	// automatic failovers, as its maximum number of failovers per hour is
+	// reached
+	EventReasonAutoFailoverRateLimited = "DRPolicyAutoFailoverRateLimited"
+
+	// EventReasonAutoFailoverDisabled is generated when a DRPolicy does not fail
+	// over the DRPCs of an unavailable cluster, as the Ramen config disables
+	// automatic failover, or cannot be read
+	EventReasonAutoFailoverDisabled = "DRPolicyAutoFailoverDisabled"
+
+	// Events for DRActionRequest Reconciler
+
+	// EventReasonActionRequested is generated when a DRActionRequest requests
+	// its action from a DRPC
+	EventReasonActionRequested = "DRActionRequested"
+
+	// EventReasonActionRequestSucceeded is generated when the action of a
+	// DRActionRequest completes
+	EventReasonActionRequestSucceeded = "DRActionRequestSucceeded"
+
+	// EventReasonActionRequestFailed is generated when the action of a
+	// DRActionRequest cannot be started, or does not complete
+	EventReasonActionRequestFailed = "DRActionRequestFailed"
+
+	// Events for DRClusterAction Reconciler
+
+	// EventReasonClusterActionStarted is generated when a DRClusterAction
+	// requests its action from one of its DRPCs
+	EventReasonClusterActionStarted = "DRClusterActionStarted"
+
+	// EventReasonClusterActionSucceeded is generated when the action of a
+	// DRClusterAction completes for all its DRPCs
+	EventReasonClusterActionSucceeded = "DRClusterActionSucceeded"
+
+	// EventReasonClusterActionFailed is generated when the action of a
+	// DRClusterAction completes, and failed for any of its DRPCs
+	EventReasonClusterActionFailed = "DRClusterActionFailed"
+
+	// Events for DRPlacementControlGroup Reconciler
+
+	// EventReasonGroupActionStarted is generated when a DRPlacementControlGroup
+	// requests its action from one of its member DRPCs
+	EventReasonGroupActionStarted = "DRPCGroupActionStarted"
+
+	// EventReasonGroupActionSucceeded is generated when the action of a
+	// DRPlacementControlGroup completes for all its members
+	EventReasonGroupActionSucceeded = "DRPCGroupActionSucceeded"
+
+	// EventReasonGroupActionFailed is generated when the action of a
+	// DRPlacementControlGroup completes, and failed for any of its members, or
+	// is halted or stopped
+	EventReasonGroupActionFailed = "DRPCGroupActionFailed"
 )
 
 // EventReporter is custom events reporter type which allows user to limit the events
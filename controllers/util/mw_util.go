@@ -124,13 +124,14 @@ func (mwu *MWUtil) CreateOrUpdateVRGManifestWork(
 	drPolicy *rmn.DRPolicy, pvcSelector metav1.LabelSelector) error {
 	s3ProfileList := S3UploadProfileList(*drPolicy)
 	schedulingInterval := drPolicy.Spec.SchedulingInterval
+	replicationMode := drPolicy.Spec.ReplicationMode
 	replClassSelector := drPolicy.Spec.ReplicationClassSelector
 
 	mwu.Log.Info(fmt.Sprintf("Create or Update manifestwork %s:%s:%s:%s",
 		name, namespace, homeCluster, s3ProfileList))
 
 	manifestWork, err := mwu.generateVRGManifestWork(name, namespace, homeCluster,
-		s3ProfileList, pvcSelector, schedulingInterval, replClassSelector)
+		s3ProfileList, pvcSelector, schedulingInterval, replicationMode, replClassSelector)
 	if err != nil {
 		return err
 	}
@@ -140,10 +141,10 @@ func (mwu *MWUtil) CreateOrUpdateVRGManifestWork(
 
 func (mwu *MWUtil) generateVRGManifestWork(
 	name, namespace, homeCluster string, s3ProfileList []string,
-	pvcSelector metav1.LabelSelector, schedulingInterval string,
+	pvcSelector metav1.LabelSelector, schedulingInterval string, replicationMode rmn.ReplicationMode,
 	replClassSelector metav1.LabelSelector) (*ocmworkv1.ManifestWork, error) {
 	vrgClientManifest, err := mwu.generateVRGManifest(name, namespace, s3ProfileList,
-		pvcSelector, schedulingInterval, replClassSelector)
+		pvcSelector, schedulingInterval, replicationMode, replClassSelector)
 	if err != nil {
 		mwu.Log.Error(err, "failed to generate VolumeReplicationGroup manifest")
 
@@ -161,7 +162,7 @@ func (mwu *MWUtil) generateVRGManifestWork(
 
 func (mwu *MWUtil) generateVRGManifest(
 	name, namespace string, s3ProfileList []string,
-	pvcSelector metav1.LabelSelector, schedulingInterval string,
+	pvcSelector metav1.LabelSelector, schedulingInterval string, replicationMode rmn.ReplicationMode,
 	replClassSelector metav1.LabelSelector) (*ocmworkv1.Manifest, error) {
 	return mwu.GenerateManifest(&rmn.VolumeReplicationGroup{
 		TypeMeta:   metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: "ramendr.openshift.io/v1alpha1"},
@@ -169,6 +170,7 @@ func (mwu *MWUtil) generateVRGManifest(
 		Spec: rmn.VolumeReplicationGroupSpec{
 			PVCSelector:              pvcSelector,
 			SchedulingInterval:       schedulingInterval,
+			ReplicationMode:          replicationMode,
 			ReplicationState:         rmn.Primary,
 			S3ProfileList:            s3ProfileList,
 			ReplicationClassSelector: replClassSelector,
@@ -233,6 +235,16 @@ func (mwu *MWUtil) ClusterRolesCreate(drpolicy *rmn.DRPolicy) error {
 }
 
 func (mwu *MWUtil) ClusterRolesDelete(drpolicy *rmn.DRPolicy) error {
+	return mwu.clusterRolesDelete(drpolicy, DrpolicyAppliedClusterNames(drpolicy))
+}
+
+// ClusterRolesDeleteCluster deletes the cluster roles from a cluster removed
+// from the drpolicy, unless another drpolicy includes the cluster
+func (mwu *MWUtil) ClusterRolesDeleteCluster(drpolicy *rmn.DRPolicy, clusterName string) error {
+	return mwu.clusterRolesDelete(drpolicy, []string{clusterName})
+}
+
+func (mwu *MWUtil) clusterRolesDelete(drpolicy *rmn.DRPolicy, clusterNamesDelete []string) error {
 	drpolicies := rmn.DRPolicyList{}
 	clusterNames := sets.String{}
 
@@ -246,11 +258,11 @@ func (mwu *MWUtil) ClusterRolesDelete(drpolicy *rmn.DRPolicy) error {
 	for i := range drpolicies.Items {
 		drpolicy1 := &drpolicies.Items[i]
 		if drpolicy1.ObjectMeta.Name != drpolicy.ObjectMeta.Name {
-			clusterNames = clusterNames.Insert(DrpolicyClusterNames(drpolicy1)...)
+			clusterNames = clusterNames.Insert(DrpolicyAppliedClusterNames(drpolicy1)...)
 		}
 	}
 
-	for _, clusterName := range DrpolicyClusterNames(drpolicy) {
+	for _, clusterName := range clusterNamesDelete {
 		if !clusterNames.Has(clusterName) {
 			if err := mwu.deleteManifestWork(ClusterRolesManifestWorkName, clusterName); err != nil {
 				return err
@@ -307,6 +319,11 @@ func (mwu *MWUtil) generateVRGClusterRoleManifest() (*ocmworkv1.Manifest, error)
 				Resources: []string{"volumereplicationgroups"},
 				Verbs:     []string{"create", "get", "list", "update", "delete"},
 			},
+			{
+				APIGroups: []string{NetworkFenceGroup},
+				Resources: []string{"networkfences"},
+				Verbs:     []string{"create", "get", "list", "update", "delete"},
+			},
 		},
 	})
 }
@@ -0,0 +1,117 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+
+	ocmworkv1 "github.com/open-cluster-management/api/work/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NetworkFence mirrors the cluster scoped csi-addons NetworkFence resource,
+// which blocks the storage access of a set of client CIDRs.  It is defined here
+// as the csi-addons API is not a dependency of Ramen.
+type NetworkFence struct {
+	metav1.TypeMeta `json:",inline"`
+
+	ObjectMeta metav1.ObjectMeta  `json:"metadata,omitempty"`
+	Spec       NetworkFenceSpec   `json:"spec,omitempty"`
+	Status     NetworkFenceStatus `json:"status,omitempty"`
+}
+
+// NetworkFenceSecret is the secret holding the storage credentials of the driver
+type NetworkFenceSecret struct {
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type NetworkFenceSpec struct {
+	Driver     string             `json:"driver"`
+	FenceState NetworkFenceState  `json:"fenceState"`
+	Cidrs      []string           `json:"cidrs"`
+	Secret     NetworkFenceSecret `json:"secret,omitempty"`
+	Parameters map[string]string  `json:"parameters,omitempty"`
+}
+
+type NetworkFenceStatus struct {
+	Result     NetworkFenceResult `json:"result,omitempty"`
+	Message    string             `json:"message,omitempty"`
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+type (
+	NetworkFenceState  string
+	NetworkFenceResult string
+)
+
+const (
+	NetworkFenceGroup   = "csiaddons.openshift.io"
+	NetworkFenceVersion = "v1alpha1"
+	NetworkFenceKind    = "NetworkFence"
+
+	NetworkFenceStateFenced   NetworkFenceState = "Fenced"
+	NetworkFenceStateUnfenced NetworkFenceState = "Unfenced"
+
+	NetworkFenceResultSucceeded NetworkFenceResult = "Succeeded"
+	NetworkFenceResultFailed    NetworkFenceResult = "Failed"
+
+	// MWTypeNF is the ManifestWork type of a NetworkFence
+	MWTypeNF string = "nf"
+)
+
+// NetworkFenceName returns the name of the NetworkFence that fences a cluster
+func NetworkFenceName(fencedCluster string) string {
+	return "network-fence-" + fencedCluster
+}
+
+// NetworkFenceManifestWorkName returns the name of the ManifestWork that
+// creates the NetworkFence of a fenced cluster on its fence peer cluster
+func NetworkFenceManifestWorkName(fencedCluster string) string {
+	return fmt.Sprintf("%s-%s-mw", NetworkFenceName(fencedCluster), MWTypeNF)
+}
+
+// CreateOrUpdateNetworkFenceManifestWork creates or updates the ManifestWork
+// of the NetworkFence of the fenced cluster on its peer cluster
+func (mwu *MWUtil) CreateOrUpdateNetworkFenceManifestWork(
+	fencedCluster, peerCluster string, spec NetworkFenceSpec) error {
+	manifest, err := mwu.GenerateManifest(&NetworkFence{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       NetworkFenceKind,
+			APIVersion: NetworkFenceGroup + "/" + NetworkFenceVersion,
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: NetworkFenceName(fencedCluster)},
+		Spec:       spec,
+	})
+	if err != nil {
+		return err
+	}
+
+	manifestWork := mwu.newManifestWork(
+		NetworkFenceManifestWorkName(fencedCluster),
+		peerCluster,
+		map[string]string{"app": "NetworkFence"},
+		[]ocmworkv1.Manifest{*manifest})
+
+	return mwu.createOrUpdateManifestWork(manifestWork, peerCluster)
+}
+
+// DeleteNetworkFenceManifestWork deletes the ManifestWork, and so the
+// NetworkFence, of the fenced cluster from its peer cluster
+func (mwu *MWUtil) DeleteNetworkFenceManifestWork(fencedCluster, peerCluster string) error {
+	return mwu.deleteManifestWork(NetworkFenceManifestWorkName(fencedCluster), peerCluster)
+}
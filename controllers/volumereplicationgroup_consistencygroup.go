@@ -0,0 +1,701 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	volrep "github.com/csi-addons/volume-replication-operator/api/v1alpha1"
+	volrepController "github.com/csi-addons/volume-replication-operator/controllers"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/controllers/util"
+)
+
+// VolumeGroupReplication is the group level counterpart of the VolumeReplication
+// resource.  Its API is not part of the volume replication operator version
+// vendored by Ramen, hence it is handled as an unstructured resource.  Its spec
+// carries the VolumeReplicationClass, the desired replication state and a PVC
+// label selector as its source, and its status reports the same Completed,
+// Degraded and Resyncing conditions that a VolumeReplication resource reports.
+var volumeGroupReplicationGVK = schema.GroupVersionKind{
+	Group:   volrep.GroupVersion.Group,
+	Version: volrep.GroupVersion.Version,
+	Kind:    "VolumeGroupReplication",
+}
+
+func newVolumeGroupReplication() *unstructured.Unstructured {
+	vgr := &unstructured.Unstructured{}
+	vgr.SetGroupVersionKind(volumeGroupReplicationGVK)
+
+	return vgr
+}
+
+func newVolumeGroupReplicationList() *unstructured.UnstructuredList {
+	vgrList := &unstructured.UnstructuredList{}
+	vgrList.SetGroupVersionKind(volumeGroupReplicationGVK.GroupVersion().WithKind(
+		volumeGroupReplicationGVK.Kind + "List"))
+
+	return vgrList
+}
+
+// vgrName returns the name of the VolumeGroupReplication resource of a
+// consistency group.  VRG name is part of it, as consistency group names are
+// only unique within a VRG.
+func (v *VRGInstance) vgrName(groupName string) string {
+	return fmt.Sprintf("%s-%s", v.instance.Name, groupName)
+}
+
+func (v *VRGInstance) findConsistencyGroup(groupName string) *ramendrv1alpha1.ConsistencyGroup {
+	for index := range v.instance.Spec.ConsistencyGroups {
+		group := &v.instance.Spec.ConsistencyGroups[index]
+		if group.Name == groupName {
+			return group
+		}
+	}
+
+	return nil
+}
+
+// validateConsistencyGroups ensures consistency group names are unique and that
+// their selectors are valid
+func (v *VRGInstance) validateConsistencyGroups() error {
+	names := map[string]struct{}{}
+
+	for index := range v.instance.Spec.ConsistencyGroups {
+		group := &v.instance.Spec.ConsistencyGroups[index]
+
+		if _, found := names[group.Name]; found {
+			return fmt.Errorf("duplicate consistency group name %s", group.Name)
+		}
+
+		names[group.Name] = struct{}{}
+
+		if _, err := metav1.LabelSelectorAsSelector(&group.PVCSelector); err != nil {
+			return fmt.Errorf("invalid PVC selector for consistency group %s, %w", group.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// updatePVCConsistencyGroups maps each PVC in the pvcList to the consistency
+// group it belongs to, if any.  A PVC matching more than one consistency group
+// is an error, as it cannot be replicated consistently with both groups.
+func (v *VRGInstance) updatePVCConsistencyGroups() error {
+	v.pvcConsistencyGroups = map[string]string{}
+
+	for index := range v.instance.Spec.ConsistencyGroups {
+		group := &v.instance.Spec.ConsistencyGroups[index]
+
+		selector, err := metav1.LabelSelectorAsSelector(&group.PVCSelector)
+		if err != nil {
+			return fmt.Errorf("invalid PVC selector for consistency group %s, %w", group.Name, err)
+		}
+
+		for pvcIndex := range v.pvcList.Items {
+			pvc := &v.pvcList.Items[pvcIndex]
+			if !selector.Matches(labels.Set(pvc.GetLabels())) {
+				continue
+			}
+
+			if groupName, found := v.pvcConsistencyGroups[pvc.Name]; found {
+				return fmt.Errorf("PersistentVolumeClaim %s belongs to consistency groups %s and %s",
+					pvc.Name, groupName, group.Name)
+			}
+
+			v.pvcConsistencyGroups[pvc.Name] = group.Name
+		}
+	}
+
+	return nil
+}
+
+// consistencyGroupOf returns the consistency group name of the given pvc and
+// records it in the protected PVC status, or returns an empty string if the pvc
+// is replicated individually
+func (v *VRGInstance) consistencyGroupOf(pvc *corev1.PersistentVolumeClaim) string {
+	groupName := v.pvcConsistencyGroups[pvc.Name]
+	if groupName == "" {
+		return ""
+	}
+
+	if protectedPVC := v.findProtectedPVC(pvc.Name); protectedPVC != nil {
+		protectedPVC.ConsistencyGroup = groupName
+	} else {
+		v.instance.Status.ProtectedPVCs = append(v.instance.Status.ProtectedPVCs,
+			ramendrv1alpha1.ProtectedPVC{Name: pvc.Name, ConsistencyGroup: groupName})
+	}
+
+	return groupName
+}
+
+// clearConsistencyGroupOf drops the consistency group recorded in the protected
+// PVC status of a pvc that is now replicated individually
+func (v *VRGInstance) clearConsistencyGroupOf(pvc *corev1.PersistentVolumeClaim) {
+	if protectedPVC := v.findProtectedPVC(pvc.Name); protectedPVC != nil {
+		protectedPVC.ConsistencyGroup = ""
+	}
+}
+
+// replicatedByStaleVGR returns true if the pvc is still replicated by the
+// VolumeGroupReplication resource of a consistency group it no longer belongs
+// to, in which case it may not be replicated by another resource yet
+func (v *VRGInstance) replicatedByStaleVGR(pvc *corev1.PersistentVolumeClaim, staleVGRs map[string]bool) bool {
+	protectedPVC := v.findProtectedPVC(pvc.Name)
+	if protectedPVC == nil || protectedPVC.ConsistencyGroup == "" ||
+		protectedPVC.ConsistencyGroup == v.pvcConsistencyGroups[pvc.Name] {
+		return false
+	}
+
+	return staleVGRs[v.vgrName(protectedPVC.ConsistencyGroup)]
+}
+
+// reconcileStaleVGRs deletes the VolumeGroupReplication resources created by the
+// VRG for consistency groups no longer in its spec, once each reaches the VRG
+// state, as their PVCs are otherwise replicated by two resources once they are
+// replicated individually or by another group.  It returns true if a requeue is
+// required, and the names of the resources not yet deleted.
+func (v *VRGInstance) reconcileStaleVGRs() (bool, map[string]bool) {
+	staleVGRs := map[string]bool{}
+
+	vgrList := newVolumeGroupReplicationList()
+	if err := v.reconciler.List(v.ctx, vgrList, client.InNamespace(v.instance.Namespace)); err != nil {
+		if meta.IsNoMatchError(err) {
+			return false, staleVGRs
+		}
+
+		v.log.Info("Requeuing due to failure in listing VolumeGroupReplication resources", "errorValue", err)
+
+		return true, staleVGRs
+	}
+
+	groupNames := map[string]string{}
+	for index := range v.instance.Spec.ConsistencyGroups {
+		groupNames[v.vgrName(v.instance.Spec.ConsistencyGroups[index].Name)] = ""
+	}
+
+	requeue := false
+
+	for index := range vgrList.Items {
+		vgr := &vgrList.Items[index]
+		if _, found := groupNames[vgr.GetName()]; found || !metav1.IsControlledBy(vgr, v.instance) {
+			continue
+		}
+
+		log := v.log.WithValues("volumeGroupReplication", vgr.GetName())
+
+		requeueResult, deleted := v.deleteStaleVGR(vgr, log)
+		if requeueResult {
+			requeue = true
+		}
+
+		if !deleted {
+			staleVGRs[vgr.GetName()] = true
+		}
+	}
+
+	return requeue, staleVGRs
+}
+
+// deleteStaleVGR ensures that a VolumeGroupReplication resource of a consistency
+// group no longer in the VRG spec reaches the VRG state before deleting it, as
+// unprotectVR does for a VolumeReplication resource.  Synchronously replicated
+// volumes are released with no demotion.  It returns a requeue on failures, and
+// whether the resource is deleted.
+func (v *VRGInstance) deleteStaleVGR(vgr *unstructured.Unstructured, log logr.Logger) (bool, bool) {
+	const (
+		requeue bool = true
+		deleted bool = true
+	)
+
+	pvcs := []*corev1.PersistentVolumeClaim{}
+
+	for idx := range v.pvcList.Items {
+		pvc := &v.pvcList.Items[idx]
+		if protectedPVC := v.findProtectedPVC(pvc.Name); protectedPVC != nil &&
+			protectedPVC.ConsistencyGroup != "" && v.vgrName(protectedPVC.ConsistencyGroup) == vgr.GetName() {
+			pvcs = append(pvcs, pvc)
+		}
+	}
+
+	if v.instance.Spec.ReplicationState == ramendrv1alpha1.Primary || !v.replicationModeSync() {
+		state := volrep.Secondary
+		if v.instance.Spec.ReplicationState == ramendrv1alpha1.Primary {
+			state = volrep.Primary
+		}
+
+		available, err := v.updateVGR(vgr, pvcs, state, log)
+		if err != nil {
+			return requeue, !deleted
+		}
+
+		// Wait for the VolumeGroupReplication resource to reach the desired state,
+		// its status update triggers a reconcile
+		if !available {
+			log.Info("VolumeGroupReplication resource of removed consistency group not yet available for deletion")
+
+			return !requeue, !deleted
+		}
+	}
+
+	if err := v.reconciler.Delete(v.ctx, vgr); err != nil && !errors.IsNotFound(err) {
+		log.Info("Requeuing due to failure in deleting VolumeGroupReplication resource of removed consistency group",
+			"errorValue", err)
+
+		return requeue, !deleted
+	}
+
+	log.Info("Deleted VolumeGroupReplication resource of removed consistency group")
+
+	return !requeue, deleted
+}
+
+// releaseVR deletes the VolumeReplication resource created by the VRG for a pvc
+// that joined a consistency group, so that the VolumeGroupReplication resource
+// of the group takes over its volume.  As primary, the resource is demoted and
+// deleted once demoted, unless synchronously replicated.  It returns a requeue
+// on failures, and whether the resource is deleted.
+func (v *VRGInstance) releaseVR(pvc *corev1.PersistentVolumeClaim, log logr.Logger) (bool, bool) {
+	const (
+		requeue bool = true
+		deleted bool = true
+	)
+
+	vrNamespacedName := types.NamespacedName{Name: pvc.Name, Namespace: pvc.Namespace}
+
+	volRep := &volrep.VolumeReplication{}
+	if err := v.reconciler.Get(v.ctx, vrNamespacedName, volRep); err != nil {
+		if errors.IsNotFound(err) {
+			return !requeue, deleted
+		}
+
+		log.Info("Requeuing due to failure in getting VolumeReplication resource", "errorValue", err)
+
+		return requeue, !deleted
+	}
+
+	if !metav1.IsControlledBy(volRep, v.instance) {
+		log.Info("VolumeReplication resource not owned by VolumeReplicationGroup, skipping its deletion")
+
+		return !requeue, deleted
+	}
+
+	if v.instance.Spec.ReplicationState == ramendrv1alpha1.Primary && !v.replicationModeSync() {
+		msg := "Demoting VolumeReplication resource of PVC joining consistency group"
+
+		if volRep.Spec.ReplicationState != volrep.Secondary {
+			volRep.Spec.ReplicationState = volrep.Secondary
+			if err := v.reconciler.Update(v.ctx, volRep); err != nil {
+				log.Info("Requeuing due to failure in demoting VolumeReplication resource", "errorValue", err)
+
+				return requeue, !deleted
+			}
+
+			v.updatePVCDataReadyCondition(pvc.Name, VRGConditionReasonProgressing, msg)
+
+			return !requeue, !deleted
+		}
+
+		// Its status update triggers a reconcile
+		if demoted, _ := isVRConditionMet(volRep, volrepController.ConditionCompleted,
+			metav1.ConditionTrue); !demoted {
+			v.updatePVCDataReadyCondition(pvc.Name, VRGConditionReasonProgressing, msg)
+
+			return !requeue, !deleted
+		}
+	}
+
+	if err := v.deleteVR(vrNamespacedName, log); err != nil {
+		return requeue, !deleted
+	}
+
+	log.Info("Deleted VolumeReplication resource of PVC joining consistency group")
+
+	return !requeue, deleted
+}
+
+// consistencyGroupSelector returns the label selector of the group level
+// replication resource, that selects PVCs matching both the VRG and the
+// consistency group PVC selectors
+func (v *VRGInstance) consistencyGroupSelector(group *ramendrv1alpha1.ConsistencyGroup) *metav1.LabelSelector {
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{}}
+
+	for _, source := range []metav1.LabelSelector{v.instance.Spec.PVCSelector, group.PVCSelector} {
+		for key, value := range source.MatchLabels {
+			selector.MatchLabels[key] = value
+		}
+
+		selector.MatchExpressions = append(selector.MatchExpressions, source.MatchExpressions...)
+	}
+
+	return selector
+}
+
+// reconcileVGRs creates or updates the VolumeGroupReplication resource for each
+// consistency group in the groupPVCs map to the requested state.  Member PVCs
+// are expected to be prepared for VR protection.  It returns true if a requeue
+// is required, and the groups whose resource is available.
+func (v *VRGInstance) reconcileVGRs(groupPVCs map[string][]*corev1.PersistentVolumeClaim,
+	state volrep.ReplicationState) (bool, map[string]bool) {
+	requeue := false
+	availableGroups := map[string]bool{}
+
+	for groupName, pvcs := range groupPVCs {
+		log := v.log.WithValues("consistencyGroup", groupName)
+
+		groupState := state
+		if state == volrep.Secondary {
+			pvcNames := make([]string, 0, len(pvcs))
+			for _, pvc := range pvcs {
+				pvcNames = append(pvcNames, pvc.Name)
+			}
+
+			groupState = v.secondaryReplicationState(pvcNames...)
+		}
+
+		available, err := v.createOrUpdateVGR(groupName, pvcs, groupState, log)
+		if err != nil {
+			log.Info("Requeuing due to failure in getting or creating VolumeGroupReplication resource",
+				"errorValue", err)
+
+			requeue = true
+
+			continue
+		}
+
+		availableGroups[groupName] = available
+
+		log.Info("Successfully processed VolumeGroupReplication for consistency group")
+	}
+
+	return requeue, availableGroups
+}
+
+// uploadGroupPVsToS3Stores protects the PV object of each pvc of the available
+// consistency groups by uploading it to the S3 stores.  The PVs of a group are
+// uploaded only once its VolumeGroupReplication resource reports the PVCs
+// replicating, and a group not yet available is reconciled on its status update.
+// It returns true if a requeue is required.
+func (v *VRGInstance) uploadGroupPVsToS3Stores(groupPVCs map[string][]*corev1.PersistentVolumeClaim,
+	availableGroups map[string]bool) bool {
+	requeue := false
+
+	for groupName, pvcs := range groupPVCs {
+		if !availableGroups[groupName] {
+			continue
+		}
+
+		for _, pvc := range pvcs {
+			log := v.log.WithValues("consistencyGroup", groupName, "pvc", pvc.Name)
+
+			if err := v.uploadPVToS3Stores(pvc, log); err != nil {
+				log.Info("Requeuing due to failure to upload PV object to S3 store(s)",
+					"errorValue", err)
+
+				requeue = true
+			}
+		}
+	}
+
+	return requeue
+}
+
+// createOrUpdateVGR is the group level counterpart of createOrUpdateVR.  Conditions
+// of each member PVC are updated based on the state and status of the group level
+// replication resource.
+func (v *VRGInstance) createOrUpdateVGR(groupName string, pvcs []*corev1.PersistentVolumeClaim,
+	state volrep.ReplicationState, log logr.Logger) (bool, error) {
+	const available = true
+
+	vgrNamespacedName := types.NamespacedName{Name: v.vgrName(groupName), Namespace: v.instance.Namespace}
+	vgr := newVolumeGroupReplication()
+
+	err := v.reconciler.Get(v.ctx, vgrNamespacedName, vgr)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			log.Error(err, "Failed to get VolumeGroupReplication resource", "resource", vgrNamespacedName)
+
+			msg := "Failed to get VolumeGroupReplication resource"
+			v.updateGroupPVCsDataReadyCondition(pvcs, VRGConditionReasonErrorUnknown, msg)
+
+			return !available, fmt.Errorf("failed to get VolumeGroupReplication resource"+
+				" (%s) belonging to VolumeReplicationGroup (%s/%s), %w",
+				vgrNamespacedName, v.instance.Namespace, v.instance.Name, err)
+		}
+
+		if err = v.createVGR(vgrNamespacedName, groupName, pvcs, state); err != nil {
+			log.Error(err, "Failed to create VolumeGroupReplication resource", "resource", vgrNamespacedName)
+			rmnutil.ReportIfNotPresent(v.reconciler.eventRecorder, v.instance, corev1.EventTypeWarning,
+				rmnutil.EventReasonVRCreateFailed, err.Error())
+
+			msg := "Failed to create VolumeGroupReplication resource"
+			v.updateGroupPVCsDataReadyCondition(pvcs, VRGConditionReasonError, msg)
+
+			return !available, fmt.Errorf("failed to create VolumeGroupReplication resource"+
+				" (%s) belonging to VolumeReplicationGroup (%s/%s), %w",
+				vgrNamespacedName, v.instance.Namespace, v.instance.Name, err)
+		}
+
+		msg := "Created VolumeGroupReplication resource for consistency group"
+		v.updateGroupPVCsDataReadyCondition(pvcs, VRGConditionReasonProgressing, msg)
+
+		return !available, nil
+	}
+
+	return v.updateVGR(vgr, pvcs, state, log)
+}
+
+func (v *VRGInstance) updateVGR(vgr *unstructured.Unstructured, pvcs []*corev1.PersistentVolumeClaim,
+	state volrep.ReplicationState, log logr.Logger) (bool, error) {
+	const available = true
+
+	currentState, _, err := unstructured.NestedString(vgr.Object, "spec", "replicationState")
+	if err != nil {
+		return !available, fmt.Errorf("failed to get replication state of VolumeGroupReplication"+
+			" resource (%s/%s), %w", vgr.GetNamespace(), vgr.GetName(), err)
+	}
+
+	if currentState == string(state) {
+		log.Info("VolumeGroupReplication and VolumeReplicationGroup state match. Proceeding to status check")
+
+		return v.checkVGRStatus(vgr, pvcs)
+	}
+
+	if err := unstructured.SetNestedField(vgr.Object, string(state), "spec", "replicationState"); err != nil {
+		return !available, fmt.Errorf("failed to set replication state of VolumeGroupReplication"+
+			" resource (%s/%s), %w", vgr.GetNamespace(), vgr.GetName(), err)
+	}
+
+	if err := v.reconciler.Update(v.ctx, vgr); err != nil {
+		log.Error(err, "Failed to update VolumeGroupReplication resource",
+			"name", vgr.GetName(), "namespace", vgr.GetNamespace(), "state", state)
+		rmnutil.ReportIfNotPresent(v.reconciler.eventRecorder, v.instance, corev1.EventTypeWarning,
+			rmnutil.EventReasonVRUpdateFailed, err.Error())
+
+		msg := "Failed to update VolumeGroupReplication resource"
+		v.updateGroupPVCsDataReadyCondition(pvcs, VRGConditionReasonError, msg)
+
+		return !available, fmt.Errorf("failed to update VolumeGroupReplication resource"+
+			" (%s/%s) as %s, belonging to VolumeReplicationGroup (%s/%s), %w",
+			vgr.GetNamespace(), vgr.GetName(), state,
+			v.instance.Namespace, v.instance.Name, err)
+	}
+
+	msg := "Updated VolumeGroupReplication resource for consistency group"
+	v.updateGroupPVCsDataReadyCondition(pvcs, VRGConditionReasonProgressing, msg)
+
+	return !available, nil
+}
+
+// createVGR creates a VolumeGroupReplication resource for a consistency group.  All
+// PVCs of the group must map to the same VolumeReplicationClass.
+func (v *VRGInstance) createVGR(vgrNamespacedName types.NamespacedName, groupName string,
+	pvcs []*corev1.PersistentVolumeClaim, state volrep.ReplicationState) error {
+	group := v.findConsistencyGroup(groupName)
+	if group == nil {
+		return fmt.Errorf("consistency group %s not found", groupName)
+	}
+
+	volumeReplicationClass := ""
+
+	for _, pvc := range pvcs {
+		className, err := v.selectVolumeReplicationClass(types.NamespacedName{Name: pvc.Name, Namespace: pvc.Namespace})
+		if err != nil {
+			return fmt.Errorf("failed to find the appropriate VolumeReplicationClass (%s) %w",
+				v.instance.Name, err)
+		}
+
+		if volumeReplicationClass != "" && volumeReplicationClass != className {
+			return fmt.Errorf("PVCs of consistency group %s map to different VolumeReplicationClasses (%s, %s)",
+				groupName, volumeReplicationClass, className)
+		}
+
+		volumeReplicationClass = className
+	}
+
+	selector, err := runtime.DefaultUnstructuredConverter.ToUnstructured(v.consistencyGroupSelector(group))
+	if err != nil {
+		return fmt.Errorf("failed to convert PVC selector of consistency group %s, %w", groupName, err)
+	}
+
+	vgr := newVolumeGroupReplication()
+	vgr.SetName(vgrNamespacedName.Name)
+	vgr.SetNamespace(vgrNamespacedName.Namespace)
+	vgr.Object["spec"] = map[string]interface{}{
+		"volumeReplicationClass": volumeReplicationClass,
+		"replicationState":       string(state),
+		"source": map[string]interface{}{
+			"selector": selector,
+		},
+	}
+
+	// Let VRG receive notification for any changes to VolumeGroupReplication CR
+	// created by VRG.
+	if err := ctrl.SetControllerReference(v.instance, vgr, v.reconciler.Scheme); err != nil {
+		return fmt.Errorf("failed to set owner reference to VolumeGroupReplication resource (%s), %w",
+			vgrNamespacedName, err)
+	}
+
+	v.log.Info("Creating VolumeGroupReplication resource", "resource", vgrNamespacedName)
+
+	if err := v.reconciler.Create(v.ctx, vgr); err != nil {
+		return fmt.Errorf("failed to create VolumeGroupReplication resource (%s), %w", vgrNamespacedName, err)
+	}
+
+	return nil
+}
+
+// checkVGRStatus maps the status of the VolumeGroupReplication resource to the
+// conditions of each member PVC.  As the group level resource reports the same
+// conditions as a VolumeReplication resource, the status is checked on behalf of
+// each member using the VolumeReplication status checks.
+func (v *VRGInstance) checkVGRStatus(vgr *unstructured.Unstructured,
+	pvcs []*corev1.PersistentVolumeClaim) (bool, error) {
+	const available = true
+
+	status := volrep.VolumeReplicationStatus{}
+
+	if statusMap, found, _ := unstructured.NestedMap(vgr.Object, "status"); found {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(statusMap, &status); err != nil {
+			msg := "Failed to decode VolumeGroupReplication resource status"
+			v.updateGroupPVCsDataReadyCondition(pvcs, VRGConditionReasonErrorUnknown, msg)
+
+			return !available, fmt.Errorf("failed to decode status of VolumeGroupReplication resource"+
+				" (%s/%s), %w", vgr.GetNamespace(), vgr.GetName(), err)
+		}
+	}
+
+	replicationState, _, _ := unstructured.NestedString(vgr.Object, "spec", "replicationState")
+	groupAvailable := true
+
+	for _, pvc := range pvcs {
+		volRep := &volrep.VolumeReplication{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       pvc.Name,
+				Namespace:  pvc.Namespace,
+				Generation: vgr.GetGeneration(),
+			},
+			Spec:   volrep.VolumeReplicationSpec{ReplicationState: volrep.ReplicationState(replicationState)},
+			Status: status,
+		}
+
+		memberAvailable, err := v.checkVRStatus(volRep)
+		if err != nil {
+			return !available, err
+		}
+
+		groupAvailable = groupAvailable && memberAvailable
+	}
+
+	return groupAvailable, nil
+}
+
+// deleteVGR deletes a VolumeGroupReplication instance if found
+func (v *VRGInstance) deleteVGR(groupName string, log logr.Logger) error {
+	vgr := newVolumeGroupReplication()
+	vgr.SetName(v.vgrName(groupName))
+	vgr.SetNamespace(v.instance.Namespace)
+
+	err := v.reconciler.Delete(v.ctx, vgr)
+	if err == nil || errors.IsNotFound(err) {
+		return nil
+	}
+
+	log.Error(err, "Failed to delete VolumeGroupReplication resource")
+
+	return fmt.Errorf("failed to delete VolumeGroupReplication resource (%s/%s), %w",
+		vgr.GetNamespace(), vgr.GetName(), err)
+}
+
+func (v *VRGInstance) updateGroupPVCsDataReadyCondition(pvcs []*corev1.PersistentVolumeClaim,
+	reason, message string) {
+	for _, pvc := range pvcs {
+		v.updatePVCDataReadyCondition(pvc.Name, reason, message)
+	}
+}
+
+// reconcileVGRsForDeletion ensures each consistency group reaches the VRG state
+// before deleting its VolumeGroupReplication resource and preparing the member
+// PVCs for deletion.  It returns true if a requeue is required, which includes
+// a group whose VolumeGroupReplication resource is not yet available, so that
+// the VRG is not finalized while the resource remains.
+func (v *VRGInstance) reconcileVGRsForDeletion(groupPVCs map[string][]*corev1.PersistentVolumeClaim) bool {
+	requeue := false
+
+	state := volrep.Primary
+	if v.instance.Spec.ReplicationState == ramendrv1alpha1.Secondary {
+		// Synchronously replicated volumes are released with no demotion
+		if v.replicationModeSync() {
+			return v.reconcileVGRsAsSecondarySync(groupPVCs)
+		}
+
+		state = volrep.Secondary
+	}
+
+	for groupName, pvcs := range groupPVCs {
+		log := v.log.WithValues("consistencyGroup", groupName)
+
+		available, err := v.createOrUpdateVGR(groupName, pvcs, state, log)
+		if err != nil {
+			log.Info("Requeuing due to failure in getting or creating VolumeGroupReplication resource",
+				"errorValue", err)
+
+			requeue = true
+
+			continue
+		}
+
+		if !available {
+			log.Info("Requeuing as VolumeGroupReplication resource is not yet available for deletion")
+
+			requeue = true
+
+			continue
+		}
+
+		if err := v.deleteVGR(groupName, log); err != nil {
+			requeue = true
+
+			continue
+		}
+
+		for _, pvc := range pvcs {
+			if err := v.preparePVCForVRDeletion(pvc, log); err != nil {
+				log.Info("Requeuing due to failure in preparing PersistentVolumeClaim for VolumeGroupReplication"+
+					" deletion", "pvc", pvc.Name, "errorValue", err)
+
+				requeue = true
+			}
+		}
+	}
+
+	return requeue
+}
@@ -58,6 +58,7 @@ type PVUploader interface {
 
 type PVDeleter interface {
 	DeletePVs(v interface{}, s3ProfileName string) error
+	DeletePV(v interface{}, s3ProfileName string, pvName string) error
 }
 
 // VolumeReplicationGroupReconciler reconciles a VolumeReplicationGroup object
@@ -90,16 +91,31 @@ func (r *VolumeReplicationGroupReconciler) SetupWithManager(mgr ctrl.Manager) er
 			log.WithValues("pvc", types.NamespacedName{Name: pvc.Name, Namespace: pvc.Namespace}))
 	}))
 
+	previewMapFun := handler.EnqueueRequestsFromMapFunc(previewVRGsMapFunc(mgr))
+
 	r.eventRecorder = rmnutil.NewEventReporter(mgr.GetEventRecorderFor("controller_VolumeReplicationGroup"))
 
 	r.Log.Info("Adding VolumeReplicationGroup controller")
 
-	return ctrl.NewControllerManagedBy(mgr).
+	ctrlBuilder := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(ctrlcontroller.Options{MaxConcurrentReconciles: getMaxConcurrentReconciles()}).
 		For(&ramendrv1alpha1.VolumeReplicationGroup{}).
 		Watches(&source.Kind{Type: &corev1.PersistentVolumeClaim{}}, pvcMapFun, builder.WithPredicates(pvcPredicate)).
-		Owns(&volrep.VolumeReplication{}).
-		Complete(r)
+		Watches(&source.Kind{Type: &volrep.VolumeReplicationClass{}}, previewMapFun).
+		Watches(&source.Kind{Type: &storagev1.StorageClass{}}, previewMapFun).
+		Owns(&volrep.VolumeReplication{})
+
+	// Consistency groups require the VolumeGroupReplication API, which may not be
+	// installed on the cluster. Watch it only if present.
+	if _, err := mgr.GetRESTMapper().RESTMapping(volumeGroupReplicationGVK.GroupKind(),
+		volumeGroupReplicationGVK.Version); err == nil {
+		ctrlBuilder = ctrlBuilder.Owns(newVolumeGroupReplication())
+	} else {
+		r.Log.Info("VolumeGroupReplication API not found, consistency group status changes are not watched",
+			"errorValue", err)
+	}
+
+	return ctrlBuilder.Complete(r)
 }
 
 func init() {
@@ -244,6 +260,16 @@ func filterPVC(mgr manager.Manager, pvc *corev1.PersistentVolumeClaim, log logr.
 				"vrg", vrg.Name, "labeled", selector)
 
 			req = append(req, reconcile.Request{NamespacedName: types.NamespacedName{Name: vrg.Name, Namespace: vrg.Namespace}})
+
+			continue
+		}
+
+		// A pvc that is protected by the VRG but no longer matches its labels
+		// needs to be unprotected by the VRG
+		if vrgProtectsPVC(&vrg, pvc.Name) {
+			log.Info("Found VolumeReplicationGroup protecting pvc with non-matching labels", "vrg", vrg.Name)
+
+			req = append(req, reconcile.Request{NamespacedName: types.NamespacedName{Name: vrg.Name, Namespace: vrg.Namespace}})
 		}
 	}
 
@@ -256,6 +282,7 @@ func filterPVC(mgr manager.Manager, pvc *corev1.PersistentVolumeClaim, log logr.
 // +kubebuilder:rbac:groups=ramendr.openshift.io,resources=volumereplicationgroups/finalizers,verbs=update
 // +kubebuilder:rbac:groups=replication.storage.openshift.io,resources=volumereplications,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=replication.storage.openshift.io,resources=volumereplicationclasses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=replication.storage.openshift.io,resources=volumegroupreplications,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=core,resources=persistentvolumes,verbs=get;list;watch;update;patch;create
@@ -320,6 +347,8 @@ type VRGInstance struct {
 	pvcList             *corev1.PersistentVolumeClaimList
 	replClassList       *volrep.VolumeReplicationClassList
 	vrcUpdated          bool
+	// PVC name to consistency group name, for PVCs that are part of one
+	pvcConsistencyGroups map[string]string
 }
 
 const (
@@ -334,6 +363,9 @@ const (
 	pvVRAnnotationRetentionKey    = "volumereplicationgroups.ramendr.openshift.io/vr-retained"
 	pvVRAnnotationRetentionValue  = "retained"
 	PVRestoreAnnotation           = "volumereplicationgroups.ramendr.openshift.io/ramen-restore"
+
+	// VRGPausedAnnotation when set to "true" on a VRG, pauses its reconciliation
+	VRGPausedAnnotation = "volumereplicationgroups.ramendr.openshift.io/paused"
 )
 
 func (v *VRGInstance) processVRG() (ctrl.Result, error) {
@@ -373,17 +405,27 @@ func (v *VRGInstance) processVRG() (ctrl.Result, error) {
 		return ctrl.Result{Requeue: true}, nil
 	}
 
+	if v.isPaused() {
+		return v.processAsPaused()
+	}
+
+	v.updateResumedCondition()
+
 	return v.processVRGActions()
 }
 
 func (v *VRGInstance) processVRGActions() (ctrl.Result, error) {
 	v.log = v.log.WithName("vrginstance").WithValues("State", v.instance.Spec.ReplicationState)
 
+	v.updateProtectionPreview()
+
 	switch {
 	case !v.instance.GetDeletionTimestamp().IsZero():
 		v.log = v.log.WithValues("Finalize", true)
 
 		return v.processForDeletion()
+	case v.previewOnly():
+		return v.processAsPreview()
 	case v.instance.Spec.ReplicationState == ramendrv1alpha1.Primary:
 		return v.processAsPrimary()
 	default: // Secondary, not primary and not deleted
@@ -403,6 +445,12 @@ func (v *VRGInstance) validateVRGState() error {
 		return err
 	}
 
+	if err := v.validateConsistencyGroups(); err != nil {
+		v.log.Error(err, "Invalid consistency groups detected")
+
+		return err
+	}
+
 	return nil
 }
 
@@ -645,6 +693,12 @@ func (v *VRGInstance) updatePVCList() error {
 
 	v.log.Info("Found PersistentVolumeClaims", "count", len(v.pvcList.Items))
 
+	if err := v.updatePVCConsistencyGroups(); err != nil {
+		v.log.Error(err, "Failed to map PersistentVolumeClaims to consistency groups")
+
+		return fmt.Errorf("failed to map PersistentVolumeClaims to consistency groups, %w", err)
+	}
+
 	return nil
 }
 
@@ -711,6 +765,8 @@ func (v *VRGInstance) processForDeletion() (ctrl.Result, error) {
 // TODO: Currently removes VR requests unconditionally, needs to ensure it is managed by VRG
 func (v *VRGInstance) reconcileVRsForDeletion() bool {
 	requeue := false
+	groupPVCs := map[string][]*corev1.PersistentVolumeClaim{}
+	groupsNotReady := map[string]bool{}
 
 	for idx := range v.pvcList.Items {
 		pvc := &v.pvcList.Items[idx]
@@ -740,6 +796,18 @@ func (v *VRGInstance) reconcileVRsForDeletion() bool {
 			continue
 		}
 
+		// VolumeReplication of a PVC in a consistency group is handled at the group level
+		if groupName := v.consistencyGroupOf(pvc); groupName != "" {
+			groupPVCs[groupName] = append(groupPVCs[groupName], pvc)
+
+			if v.instance.Spec.ReplicationState == ramendrv1alpha1.Secondary &&
+				!v.isPVCReadyForSecondary(pvc, log) {
+				groupsNotReady[groupName] = true
+			}
+
+			continue
+		}
+
 		if v.reconcileVRForDeletion(pvc, log) {
 			requeue = true
 
@@ -749,6 +817,19 @@ func (v *VRGInstance) reconcileVRsForDeletion() bool {
 		log.Info("Successfully processed VolumeReplication for PersistentVolumeClaim")
 	}
 
+	// A group not yet ready for Secondary keeps its VolumeGroupReplication
+	// resource, so the VRG is requeued rather than finalized
+	for groupName := range groupsNotReady {
+		v.log.Info("Requeuing as consistency group is not yet ready for Secondary", "consistencyGroup", groupName)
+		delete(groupPVCs, groupName)
+
+		requeue = true
+	}
+
+	if v.reconcileVGRsForDeletion(groupPVCs) {
+		requeue = true
+	}
+
 	return requeue
 }
 
@@ -879,7 +960,9 @@ func (v *VRGInstance) processAsPrimary() (ctrl.Result, error) {
 // reconcileVRsAsPrimary creates/updates VolumeReplication CR for each pvc
 // from pvcList. If it fails (even for one pvc), then requeue is set to true.
 func (v *VRGInstance) reconcileVRsAsPrimary() bool {
-	requeue := false
+	requeue, staleVGRs := v.reconcileStaleVGRs()
+	groupPVCs := map[string][]*corev1.PersistentVolumeClaim{}
+	groupsNotReady := map[string]bool{}
 
 	for idx := range v.pvcList.Items {
 		pvc := &v.pvcList.Items[idx]
@@ -897,6 +980,36 @@ func (v *VRGInstance) reconcileVRsAsPrimary() bool {
 			continue
 		}
 
+		// A PVC still replicated by the resource of its former consistency group
+		// waits for that resource to be deleted
+		if v.replicatedByStaleVGR(pvc, staleVGRs) {
+			if groupName := v.pvcConsistencyGroups[pvc.Name]; groupName != "" {
+				groupsNotReady[groupName] = true
+			}
+
+			continue
+		}
+
+		// VolumeReplication of a PVC in a consistency group is handled at the group
+		// level, once the PVC's own VolumeReplication resource is released, and its
+		// PV is uploaded once the group is available
+		if groupName := v.consistencyGroupOf(pvc); groupName != "" {
+			groupPVCs[groupName] = append(groupPVCs[groupName], pvc)
+
+			requeueResult, released := v.releaseVR(pvc, log)
+			if requeueResult {
+				requeue = true
+			}
+
+			if !released {
+				groupsNotReady[groupName] = true
+			}
+
+			continue
+		}
+
+		v.clearConsistencyGroupOf(pvc)
+
 		if _, err := v.processVRAsPrimary(pvcNamespacedName, log); err != nil {
 			log.Info("Requeuing due to failure in getting or creating VolumeReplication resource for PersistentVolumeClaim",
 				"errorValue", err)
@@ -924,6 +1037,26 @@ func (v *VRGInstance) reconcileVRsAsPrimary() bool {
 		log.Info("Successfully processed VolumeReplication for PersistentVolumeClaim")
 	}
 
+	for groupName := range groupsNotReady {
+		v.log.Info("Requeuing as consistency group is not yet ready for replication", "consistencyGroup", groupName)
+		delete(groupPVCs, groupName)
+
+		requeue = true
+	}
+
+	requeueResult, availableGroups := v.reconcileVGRs(groupPVCs, volrep.Primary)
+	if requeueResult {
+		requeue = true
+	}
+
+	if v.uploadGroupPVsToS3Stores(groupPVCs, availableGroups) {
+		requeue = true
+	}
+
+	if v.reconcileUnprotectedPVCs() {
+		requeue = true
+	}
+
 	return requeue
 }
 
@@ -972,7 +1105,9 @@ func (v *VRGInstance) processAsSecondary() (ctrl.Result, error) {
 
 // reconcileVRsAsSecondary reconciles VolumeReplication resources for the VRG as secondary
 func (v *VRGInstance) reconcileVRsAsSecondary() bool {
-	requeue := false
+	requeue, staleVGRs := v.reconcileStaleVGRs()
+	groupPVCs := map[string][]*corev1.PersistentVolumeClaim{}
+	groupsNotReady := map[string]bool{}
 
 	for idx := range v.pvcList.Items {
 		pvc := &v.pvcList.Items[idx]
@@ -990,6 +1125,39 @@ func (v *VRGInstance) reconcileVRsAsSecondary() bool {
 			continue
 		}
 
+		if v.replicatedByStaleVGR(pvc, staleVGRs) {
+			if groupName := v.pvcConsistencyGroups[pvc.Name]; groupName != "" {
+				groupsNotReady[groupName] = true
+			}
+
+			continue
+		}
+
+		// A consistency group is demoted only when all of its PVCs are ready for
+		// Secondary, and their own VolumeReplication resources are released
+		if groupName := v.consistencyGroupOf(pvc); groupName != "" {
+			groupPVCs[groupName] = append(groupPVCs[groupName], pvc)
+
+			if !v.isPVCReadyForSecondary(pvc, log) {
+				groupsNotReady[groupName] = true
+
+				continue
+			}
+
+			requeueResult, released := v.releaseVR(pvc, log)
+			if requeueResult {
+				requeue = true
+			}
+
+			if !released {
+				groupsNotReady[groupName] = true
+			}
+
+			continue
+		}
+
+		v.clearConsistencyGroupOf(pvc)
+
 		requeueResult, skip = v.reconcileVRAsSecondary(pvc, log)
 		if requeueResult {
 			requeue = true
@@ -1004,6 +1172,25 @@ func (v *VRGInstance) reconcileVRsAsSecondary() bool {
 		log.Info("Successfully processed VolumeReplication for PersistentVolumeClaim")
 	}
 
+	for groupName := range groupsNotReady {
+		v.log.Info("Requeuing as consistency group is not yet ready for Secondary", "consistencyGroup", groupName)
+		delete(groupPVCs, groupName)
+
+		requeue = true
+	}
+
+	if v.replicationModeSync() {
+		if v.reconcileVGRsAsSecondarySync(groupPVCs) {
+			requeue = true
+		}
+	} else if requeueResult, _ := v.reconcileVGRs(groupPVCs, volrep.Secondary); requeueResult {
+		requeue = true
+	}
+
+	if v.reconcileUnprotectedPVCs() {
+		requeue = true
+	}
+
 	return requeue
 }
 
@@ -1013,6 +1200,10 @@ func (v *VRGInstance) reconcileVRAsSecondary(pvc *corev1.PersistentVolumeClaim,
 		skip    bool = true
 	)
 
+	if v.replicationModeSync() {
+		return v.reconcileVRAsSecondarySync(pvc, log)
+	}
+
 	if !v.isPVCReadyForSecondary(pvc, log) {
 		// 1) Dont requeue as a reconcile would be
 		//    triggered when the events that indicate
@@ -1428,6 +1619,35 @@ func (ObjectStorePVDeleter) DeletePVs(v interface{}, s3ProfileName string) (err
 	return nil
 }
 
+// DeletePV deletes the cluster data of a single PV, uploaded by UploadPV, from
+// this VRG's S3 bucket
+func (ObjectStorePVDeleter) DeletePV(v interface{}, s3ProfileName string, pvName string) (err error) {
+	vrgName := v.(*VRGInstance).instance.Name
+	s3Bucket := constructBucketName(v.(*VRGInstance).instance.Namespace, vrgName)
+
+	objectStore, err := v.(*VRGInstance).reconciler.ObjStoreGetter.ObjectStore(
+		v.(*VRGInstance).ctx,
+		v.(*VRGInstance).reconciler.APIReader,
+		s3ProfileName,
+		vrgName,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get client for s3Profile %s, err %w",
+			s3ProfileName, err)
+	}
+
+	key := reflect.TypeOf(corev1.PersistentVolume{}).String() + "/" + pvName
+	if err := objectStore.DeleteObjects(s3Bucket, key); err != nil && !isAwsErrCodeNoSuchBucket(err) {
+		return fmt.Errorf("error deleting PV %s from S3 bucket %s of S3 profile %s, %w",
+			pvName, s3Bucket, s3ProfileName, err)
+	}
+
+	v.(*VRGInstance).log.Info("Deleted PV related cluster data from",
+		"PV", pvName, "S3 bucket", s3Bucket, "S3 profile", s3ProfileName)
+
+	return nil
+}
+
 // processVRAsPrimary processes VR to change its state to primary, with the assumption that the
 // related PVC is prepared for VR protection
 func (v *VRGInstance) processVRAsPrimary(vrNamespacedName types.NamespacedName, log logr.Logger) (bool, error) {
@@ -1437,7 +1657,7 @@ func (v *VRGInstance) processVRAsPrimary(vrNamespacedName types.NamespacedName,
 // processVRAsSecondary processes VR to change its state to secondary, with the assumption that the
 // related PVC is prepared for VR as secondary
 func (v *VRGInstance) processVRAsSecondary(vrNamespacedName types.NamespacedName, log logr.Logger) (bool, error) {
-	return v.createOrUpdateVR(vrNamespacedName, volrep.Secondary, log)
+	return v.createOrUpdateVR(vrNamespacedName, v.secondaryReplicationState(vrNamespacedName.Name), log)
 }
 
 // createOrUpdateVR updates an existing VR resource if found, or creates it if required
@@ -1609,13 +1829,9 @@ func (v *VRGInstance) selectVolumeReplicationClass(namespacedName types.Namespac
 		}
 
 		schedulingInterval, found := replicationClass.Spec.Parameters["schedulingInterval"]
-		if !found {
-			// schedule not present in parameters of this replicationClass.
-			continue
-		}
 
 		// ReplicationClass that matches both VRG schedule and pvc provisioner
-		if schedulingInterval == v.instance.Spec.SchedulingInterval {
+		if v.replicationClassScheduleMatches(schedulingInterval, found) {
 			className = replicationClass.Name
 
 			break
@@ -1757,6 +1973,7 @@ func (v *VRGInstance) updateVRGConditions() {
 	v.updateVRGDataReadyCondition()
 	v.updateVRGDataProtectedCondition()
 	v.updateVRGClusterDataProtectedCondition()
+	v.updateVRGResyncRequiredCondition()
 }
 
 //
@@ -2004,7 +2221,10 @@ func (v *VRGInstance) checkVRStatus(volRep *volrep.VolumeReplication) (bool, err
 	case v.instance.Spec.ReplicationState == ramendrv1alpha1.Primary:
 		return v.validateVRStatus(volRep, ramendrv1alpha1.Primary), nil
 	case v.instance.Spec.ReplicationState == ramendrv1alpha1.Secondary:
-		return v.validateVRStatus(volRep, ramendrv1alpha1.Secondary), nil
+		available := v.validateVRStatus(volRep, ramendrv1alpha1.Secondary)
+		v.updatePVCResyncCondition(volRep)
+
+		return available, nil
 	default:
 		msg := "VolumeReplicationGroup state invalid"
 		v.updatePVCDataReadyCondition(volRep.Name, VRGConditionReasonError, msg)
@@ -2056,7 +2276,13 @@ func (v *VRGInstance) validateVRStatus(volRep *volrep.VolumeReplication, state r
 		msg = "PVC in the VolumeReplicationGroup is ready for use"
 		v.updatePVCDataReadyCondition(volRep.Name, VRGConditionReasonReady, msg)
 
-		v.updatePVCDataProtectedCondition(volRep.Name, VRGConditionReasonReady, msg)
+		// A synchronously replicated volume is protected as soon as it is promoted
+		if v.replicationModeSync() {
+			v.updatePVCDataProtectedCondition(volRep.Name, VRGConditionReasonDataProtected,
+				"PVC in the VolumeReplicationGroup is synchronously replicated")
+		} else {
+			v.updatePVCDataProtectedCondition(volRep.Name, VRGConditionReasonReady, msg)
+		}
 
 		v.log.Info(fmt.Sprintf("VolumeReplication resource %s/%s is ready for use", volRep.Name,
 			volRep.Namespace))
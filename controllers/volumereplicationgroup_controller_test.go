@@ -16,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -71,6 +72,30 @@ var _ = Describe("Test VolumeReplicationGroup", func() {
 		})
 	})
 
+	// Protect PVCs, and then relabel one of them such that it is no longer
+	// selected by the VRG, and check that it is unprotected
+	var vrgUnprotectTestCase vrgTest
+	Context("in primary state unprotecting a PVC", func() {
+		It("sets up PVCs, PVs and VRG", func() {
+			vrgUnprotectTestCase = newVRGTestCase(3)
+		})
+		It("waits for VRG to status to match", func() {
+			v := vrgUnprotectTestCase
+			v.waitForVRCountToMatch(len(v.pvcNames))
+			v.promoteVolReps()
+			v.verifyVRGStatusExpectation(true)
+		})
+		It("unprotects the PVC once it no longer matches the VRG labels", func() {
+			v := vrgUnprotectTestCase
+			v.unlabelPVC(v.pvcNames[0])
+			v.waitForVRCountToMatch(len(v.pvcNames) - 1)
+			v.waitForPVCUnprotected(v.pvcNames[0])
+		})
+		It("cleans up after testing", func() {
+			vrgUnprotectTestCase.cleanup()
+		})
+	})
+
 	// Creates VRG. PVCs and PV are created with Status.Phase
 	// set to pending and VolRep should not be created until
 	// all the PVCs and PVs are bound. So, these tests then
@@ -341,6 +366,211 @@ var _ = Describe("Test VolumeReplicationGroup", func() {
 			v.cleanup()
 		})
 	})
+	// Creates VRG with a consistency group that includes all its PVCs, and
+	// checks that a single VolumeGroupReplication resource is created instead
+	// of a VolumeReplication resource per PVC.
+	var vrgConsistencyGroupTests []vrgTest
+	Context("in primary state with a consistency group", func() {
+		consistencyGroupTemplate := &template{
+			ClaimBindInfo:          corev1.ClaimBound,
+			VolumeBindInfo:         corev1.VolumeBound,
+			schedulingInterval:     "1h",
+			storageClassName:       "manual",
+			replicationClassName:   "test-replicationclass",
+			vrcProvisioner:         "manual.storage.com",
+			scProvisioner:          "manual.storage.com",
+			replicationClassLabels: map[string]string{"protection": "ramen"},
+			consistencyGroup:       true,
+		}
+		It("sets up PVCs, PVs and VRG", func() {
+			v := newVRGTestCaseBindInfo(3, consistencyGroupTemplate, true, false)
+			vrgConsistencyGroupTests = append(vrgConsistencyGroupTests, v)
+		})
+		It("waits for VRG to create a VolumeGroupReplication and no VRs", func() {
+			v := vrgConsistencyGroupTests[0]
+			v.waitForVGRCountToMatch(1)
+			v.waitForVRCountToMatch(0)
+		})
+		It("waits for VRG to status to match, and uploads PVs once the group is available", func() {
+			v := vrgConsistencyGroupTests[0]
+			v.verifyVRGStatusExpectation(false)
+			v.verifyPVsUploaded(false)
+			v.promoteVGRs()
+			v.verifyVRGStatusExpectation(true)
+			v.verifyPVsUploaded(true)
+		})
+		It("cleans up after testing", func() {
+			v := vrgConsistencyGroupTests[0]
+			v.cleanup()
+			v.waitForVGRCountToMatch(0)
+		})
+	})
+	// Creates VRG with PVCs replicated individually, places them in a consistency
+	// group, and then removes the group, checking that a PVC is never replicated
+	// by both a VolumeReplication and a VolumeGroupReplication resource.
+	var vrgConsistencyGroupChangeTestCase vrgTest
+	Context("in primary state with consistency group changes", func() {
+		const consistencyGroupName = "cg-change"
+		It("sets up PVCs, PVs and VRG", func() {
+			vrgConsistencyGroupChangeTestCase = newVRGTestCase(2)
+			v := vrgConsistencyGroupChangeTestCase
+			v.waitForVRCountToMatch(len(v.pvcNames))
+			v.promoteVolReps()
+			v.verifyVRGStatusExpectation(true)
+		})
+		It("demotes and deletes the VRs before creating a VolumeGroupReplication for PVCs joining a group", func() {
+			v := vrgConsistencyGroupChangeTestCase
+			v.setConsistencyGroup(consistencyGroupName)
+			v.waitForVolRepState(volrep.Secondary)
+			v.waitForVGRCountToMatch(0)
+			v.updateVolRepsStatus(volrepController.Demoted, false, false)
+			v.waitForVRCountToMatch(0)
+			v.waitForVGRCountToMatch(1)
+			v.promoteVGRs()
+			v.verifyVRGStatusExpectation(true)
+		})
+		It("deletes the VolumeGroupReplication of a removed group before creating VRs for its PVCs", func() {
+			v := vrgConsistencyGroupChangeTestCase
+			v.setConsistencyGroup("")
+			v.waitForVGRCountToMatch(0)
+			v.waitForVRCountToMatch(len(v.pvcNames))
+			v.promoteVolReps()
+			v.verifyVRGStatusExpectation(true)
+		})
+		It("cleans up after testing", func() {
+			v := vrgConsistencyGroupChangeTestCase
+			v.cleanup()
+		})
+	})
+	// Creates VRG in preview mode, and checks that the protection plan is
+	// reported in its status without any VRs created or PVCs protected.
+	var vrgPreviewTests []vrgTest
+	Context("in primary state with preview", func() {
+		previewTemplate := &template{
+			ClaimBindInfo:          corev1.ClaimBound,
+			VolumeBindInfo:         corev1.VolumeBound,
+			schedulingInterval:     "1h",
+			storageClassName:       "manual",
+			replicationClassName:   "test-replicationclass",
+			vrcProvisioner:         "manual.storage.com",
+			scProvisioner:          "manual.storage.com",
+			replicationClassLabels: map[string]string{"protection": "ramen"},
+			preview:                true,
+		}
+		It("sets up PVCs, PVs and VRG", func() {
+			v := newVRGTestCaseBindInfo(3, previewTemplate, true, false)
+			vrgPreviewTests = append(vrgPreviewTests, v)
+		})
+		It("waits for VRG to report the protection preview", func() {
+			v := vrgPreviewTests[0]
+			v.waitForProtectionPreview(ramendrv1alpha1.PVCProtectable)
+		})
+		It("expects no VR to be created and no PVC to be protected", func() {
+			v := vrgPreviewTests[0]
+			v.waitForVRCountToMatch(0)
+			for _, pvcName := range v.pvcNames {
+				Expect(v.getPVC(pvcName).Finalizers).To(BeEmpty())
+			}
+		})
+		It("refreshes the preview once the VolumeReplicationClass is deleted", func() {
+			v := vrgPreviewTests[0]
+			v.cleanupVRC()
+			v.waitForProtectionPreview(ramendrv1alpha1.PVCNoReplicationClass)
+		})
+		It("cleans up after testing", func() {
+			v := vrgPreviewTests[0]
+			v.cleanup()
+		})
+	})
+	// Protects PVCs as primary, moves the VRG to secondary, and then requests
+	// a resync of its PVCs, checking that the VRs are resynced, that the
+	// progress of the resync is reported, and that its completion is recorded
+	// for each PVC.
+	var vrgResyncTestCase vrgTest
+	Context("in secondary state with a resync request", func() {
+		const resyncRequestID = "resync-1"
+		It("sets up PVCs, PVs and VRG", func() {
+			vrgResyncTestCase = newVRGTestCase(2)
+			v := vrgResyncTestCase
+			v.waitForVRCountToMatch(len(v.pvcNames))
+			v.promoteVolReps()
+			v.verifyVRGStatusExpectation(true)
+		})
+		It("moves the VRG to secondary", func() {
+			v := vrgResyncTestCase
+			v.moveToSecondary()
+			v.waitForVolRepState(volrep.Secondary)
+			v.updateVolRepsStatus(volrepController.Demoted, false, false)
+			v.verifyVRGStatusExpectation(true)
+		})
+		It("resyncs the VRs once a resync is requested", func() {
+			v := vrgResyncTestCase
+			v.requestResync(resyncRequestID)
+			v.waitForVolRepState(volrep.Resync)
+		})
+		It("reports the resync in progress", func() {
+			v := vrgResyncTestCase
+			v.updateVolRepsStatus(volrepController.ResycTriggered, true, true)
+			v.waitForResyncRequiredCondition(metav1.ConditionTrue, vrgController.VRGConditionReasonResyncing)
+		})
+		It("records the completion of the resync and moves the VRs back to secondary", func() {
+			v := vrgResyncTestCase
+			v.updateVolRepsStatus(volrepController.Demoted, false, false)
+			v.waitForResyncCompleted(resyncRequestID)
+			v.waitForResyncRequiredCondition(metav1.ConditionFalse, vrgController.VRGConditionReasonHealthy)
+			v.waitForVolRepState(volrep.Secondary)
+		})
+		It("cleans up after testing", func() {
+			v := vrgResyncTestCase
+			v.updateVolRepsStatus(volrepController.Demoted, false, false)
+			v.cleanupVRG()
+			v.cleanupNamespace()
+			v.cleanupSC()
+			v.cleanupVRC()
+		})
+	})
+	// Creates VRG paused, and checks that no VRs are created until it is
+	// resumed, and that the paused condition is reported and then cleared.
+	var vrgPauseTests []vrgTest
+	Context("in primary state paused", func() {
+		pauseTemplate := &template{
+			ClaimBindInfo:          corev1.ClaimBound,
+			VolumeBindInfo:         corev1.VolumeBound,
+			schedulingInterval:     "1h",
+			storageClassName:       "manual",
+			replicationClassName:   "test-replicationclass",
+			vrcProvisioner:         "manual.storage.com",
+			scProvisioner:          "manual.storage.com",
+			replicationClassLabels: map[string]string{"protection": "ramen"},
+			paused:                 true,
+		}
+		It("sets up PVCs, PVs and a paused VRG", func() {
+			v := newVRGTestCaseBindInfo(2, pauseTemplate, true, false)
+			vrgPauseTests = append(vrgPauseTests, v)
+		})
+		It("reports the VRG as paused and creates no VR", func() {
+			v := vrgPauseTests[0]
+			v.waitForPausedCondition(metav1.ConditionTrue, vrgController.VRGConditionReasonPaused)
+			Consistently(func() int {
+				volRepList := &volrep.VolumeReplicationList{}
+				Expect(k8sClient.List(context.TODO(), volRepList, client.InNamespace(v.namespace))).To(Succeed())
+
+				return len(volRepList.Items)
+			}, timeout, interval).Should(BeZero())
+		})
+		It("protects the PVCs once resumed", func() {
+			v := vrgPauseTests[0]
+			v.resumeVRG()
+			v.waitForPausedCondition(metav1.ConditionFalse, vrgController.VRGConditionReasonResumed)
+			v.waitForVRCountToMatch(len(v.pvcNames))
+			v.promoteVolReps()
+			v.verifyVRGStatusExpectation(true)
+		})
+		It("cleans up after testing", func() {
+			v := vrgPauseTests[0]
+			v.cleanup()
+		})
+	})
 	// TODO: Add tests to move VRG to Secondary
 	// TODO: Add tests to ensure delete as Secondary (check if delete as Primary is tested above)
 })
@@ -352,6 +582,9 @@ type vrgTest struct {
 	vrgName          string
 	storageClass     string
 	replicationClass string
+	consistencyGroup string
+	preview          bool
+	paused           bool
 }
 
 // Use to generate unique object names across multiple VRG test cases
@@ -384,6 +617,9 @@ type template struct {
 	storageClassName       string
 	replicationClassName   string
 	replicationClassLabels map[string]string
+	consistencyGroup       bool
+	preview                bool
+	paused                 bool
 }
 
 // newVRGTestCaseBindInfo creates a new namespace, zero or more PVCs (equal
@@ -404,6 +640,13 @@ func newVRGTestCaseBindInfo(pvcCount int, testTemplate *template, checkBind, vrg
 		replicationClass: testTemplate.replicationClassName,
 	}
 
+	if testTemplate.consistencyGroup {
+		v.consistencyGroup = fmt.Sprintf("cg-%c", objectNameSuffix)
+	}
+
+	v.preview = testTemplate.preview
+	v.paused = testTemplate.paused
+
 	By("Creating namespace " + v.namespace)
 	v.createNamespace()
 	v.createSC(testTemplate)
@@ -609,8 +852,23 @@ func (v *vrgTest) createVRG(pvcLabels map[string]string) {
 			SchedulingInterval:       schedulingInterval,
 			ReplicationClassSelector: metav1.LabelSelector{MatchLabels: replicationClassLabels},
 			S3ProfileList:            []string{"fakeS3Profile"},
+			Preview:                  v.preview,
 		},
 	}
+
+	if v.paused {
+		vrg.SetAnnotations(map[string]string{vrgController.VRGPausedAnnotation: "true"})
+	}
+
+	if v.consistencyGroup != "" {
+		vrg.Spec.ConsistencyGroups = []ramendrv1alpha1.ConsistencyGroup{
+			{
+				Name:        v.consistencyGroup,
+				PVCSelector: metav1.LabelSelector{MatchLabels: pvcLabels},
+			},
+		}
+	}
+
 	err := k8sClient.Create(context.TODO(), vrg)
 	expectedErr := errors.NewAlreadyExists(
 		schema.GroupResource{
@@ -721,6 +979,116 @@ func (v *vrgTest) getPVC(pvcName string) *corev1.PersistentVolumeClaim {
 	return pvc
 }
 
+func (v *vrgTest) waitForProtectionPreview(result ramendrv1alpha1.PVCPreviewResult) {
+	By(fmt.Sprintf("Waiting for VRG protection preview %s to be %s", v.vrgName, result))
+
+	Eventually(func() bool {
+		preview := v.getVRG(v.vrgName).Status.ProtectionPreview
+		if preview == nil || len(preview.PVCs) != len(v.pvcNames) {
+			return false
+		}
+
+		for _, pvcPreview := range preview.PVCs {
+			if pvcPreview.Result != result {
+				return false
+			}
+
+			if result == ramendrv1alpha1.PVCProtectable && pvcPreview.VolumeReplicationClass != v.replicationClass {
+				return false
+			}
+		}
+
+		return true
+	}, vrgtimeout, vrginterval).Should(BeTrue(),
+		"while waiting for VRG %s protection preview", v.vrgName)
+}
+
+// setConsistencyGroup places the PVCs of the VRG in a consistency group of the
+// given name, or replicates them individually if the name is empty
+func (v *vrgTest) setConsistencyGroup(groupName string) {
+	By(fmt.Sprintf("Setting consistency group of VRG %s to %q", v.vrgName, groupName))
+
+	Eventually(func() error {
+		vrg := v.getVRG(v.vrgName)
+		vrg.Spec.ConsistencyGroups = nil
+
+		if groupName != "" {
+			vrg.Spec.ConsistencyGroups = []ramendrv1alpha1.ConsistencyGroup{
+				{Name: groupName, PVCSelector: vrg.Spec.PVCSelector},
+			}
+		}
+
+		return k8sClient.Update(context.TODO(), vrg)
+	}, timeout, interval).Should(Succeed(), "failed to set consistency group of VRG %s", v.vrgName)
+}
+
+// verifyPVsUploaded checks that the PV of each protected PVC is uploaded, or
+// that it remains not uploaded
+func (v *vrgTest) verifyPVsUploaded(uploaded bool) {
+	By(fmt.Sprintf("Verifying PVs of VRG %s uploaded: %v", v.vrgName, uploaded))
+
+	pvsUploaded := func() bool {
+		vrg := v.getVRG(v.vrgName)
+		if len(vrg.Status.ProtectedPVCs) != len(v.pvcNames) {
+			return !uploaded
+		}
+
+		for index := range vrg.Status.ProtectedPVCs {
+			condition := checkConditions(vrg.Status.ProtectedPVCs[index].Conditions,
+				vrgController.VRGConditionTypeClusterDataProtected)
+			if (condition != nil && condition.Status == metav1.ConditionTrue) != uploaded {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	if uploaded {
+		Eventually(pvsUploaded, vrgtimeout, vrginterval).Should(BeTrue(),
+			"while waiting for PVs of VRG %s to be uploaded", v.vrgName)
+
+		return
+	}
+
+	Consistently(pvsUploaded, timeout, interval).Should(BeTrue(),
+		"while checking that PVs of VRG %s are not uploaded", v.vrgName)
+}
+
+func (v *vrgTest) unlabelPVC(pvcName string) {
+	By("Removing labels of PVC " + pvcName)
+
+	pvc := v.getPVC(pvcName)
+	pvc.Labels = map[string]string{}
+	err := k8sClient.Update(context.TODO(), pvc)
+	Expect(err).NotTo(HaveOccurred(),
+		"failed to update labels of PVC %s", pvcName)
+}
+
+func (v *vrgTest) waitForPVCUnprotected(pvcName string) {
+	By("Waiting for VRG to unprotect PVC " + pvcName)
+
+	pvName := v.getPVC(pvcName).Spec.VolumeName
+
+	Eventually(func() bool {
+		vrg := v.getVRG(v.vrgName)
+		for index := range vrg.Status.ProtectedPVCs {
+			if vrg.Status.ProtectedPVCs[index].Name == pvcName {
+				return false
+			}
+		}
+
+		if len(v.getPVC(pvcName).Finalizers) != 0 {
+			return false
+		}
+
+		_, uploaded := UploadedPVs[pvName]
+
+		return !uploaded
+	}, vrgtimeout, vrginterval).Should(BeTrue(),
+		"while waiting for VRG %s to unprotect PVC %s", v.vrgName, pvcName)
+}
+
 func (v *vrgTest) getVRG(vrgName string) *ramendrv1alpha1.VolumeReplicationGroup {
 	key := types.NamespacedName{
 		Namespace: v.namespace,
@@ -869,6 +1237,72 @@ func (v *vrgTest) waitForVRCountToMatch(vrCount int) {
 		vrCount, v.vrgName, v.namespace)
 }
 
+func newVGRList() *unstructured.UnstructuredList {
+	vgrList := &unstructured.UnstructuredList{}
+	vgrList.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   volrep.GroupVersion.Group,
+		Version: volrep.GroupVersion.Version,
+		Kind:    "VolumeGroupReplicationList",
+	})
+
+	return vgrList
+}
+
+func (v *vrgTest) waitForVGRCountToMatch(vgrCount int) {
+	By("Waiting for VolumeGroupReplications count to match " + v.namespace)
+
+	Eventually(func() int {
+		vgrList := newVGRList()
+		err := k8sClient.List(context.TODO(), vgrList, client.InNamespace(v.namespace))
+		Expect(err).NotTo(HaveOccurred(),
+			"failed to get a list of VolumeGroupReplications in namespace %s", v.namespace)
+
+		return len(vgrList.Items)
+	}, timeout, interval).Should(BeNumerically("==", vgrCount),
+		"while waiting for VolumeGroupReplication count of %d in VRG %s of namespace %s",
+		vgrCount, v.vrgName, v.namespace)
+}
+
+func (v *vrgTest) promoteVGRs() {
+	By("Promoting VolumeGroupReplication resources " + v.namespace)
+
+	vgrList := newVGRList()
+	err := k8sClient.List(context.TODO(), vgrList, client.InNamespace(v.namespace))
+	Expect(err).NotTo(HaveOccurred(), "failed to get a list of VolumeGroupReplications in namespace %s",
+		v.namespace)
+
+	for index := range vgrList.Items {
+		vgr := &vgrList.Items[index]
+		generation := vgr.GetGeneration()
+
+		conditions := []interface{}{}
+		for _, condition := range []metav1.Condition{
+			{Type: volrepController.ConditionCompleted, Reason: volrepController.Promoted, Status: metav1.ConditionTrue},
+			{Type: volrepController.ConditionDegraded, Reason: volrepController.Healthy, Status: metav1.ConditionFalse},
+			{Type: volrepController.ConditionResyncing, Reason: volrepController.NotResyncing, Status: metav1.ConditionFalse},
+		} {
+			conditions = append(conditions, map[string]interface{}{
+				"type":               condition.Type,
+				"reason":             condition.Reason,
+				"status":             string(condition.Status),
+				"observedGeneration": generation,
+				"lastTransitionTime": time.Now().UTC().Format(time.RFC3339),
+				"message":            "",
+			})
+		}
+
+		vgr.Object["status"] = map[string]interface{}{
+			"state":              string(volrep.PrimaryState),
+			"observedGeneration": generation,
+			"conditions":         conditions,
+		}
+
+		err = k8sClient.Status().Update(context.TODO(), vgr)
+		Expect(err).NotTo(HaveOccurred(), "failed to update the status of VolumeGroupReplication %s",
+			vgr.GetName())
+	}
+}
+
 func (v *vrgTest) promoteVolReps() {
 	By("Promoting VolumeReplication resources " + v.namespace)
 
@@ -990,6 +1424,183 @@ func (v *vrgTest) checkProtectedPVCSuccess(vrg *ramendrv1alpha1.VolumeReplicatio
 	return success
 }
 
+func (v *vrgTest) resumeVRG() {
+	By("Resuming VRG " + v.vrgName)
+
+	Eventually(func() error {
+		vrg := v.getVRG(v.vrgName)
+		vrg.SetAnnotations(map[string]string{})
+
+		return k8sClient.Update(context.TODO(), vrg)
+	}, timeout, interval).Should(Succeed(), "failed to resume VRG %s", v.vrgName)
+}
+
+func (v *vrgTest) waitForPausedCondition(status metav1.ConditionStatus, reason string) {
+	By(fmt.Sprintf("Waiting for VRG %s Paused condition to be %s (%s)", v.vrgName, status, reason))
+
+	Eventually(func() bool {
+		vrg := v.getVRG(v.vrgName)
+		condition := checkConditions(vrg.Status.Conditions, vrgController.VRGConditionTypePaused)
+
+		return condition != nil && condition.Status == status && condition.Reason == reason
+	}, vrgtimeout, vrginterval).Should(BeTrue(),
+		"while waiting for VRG %s Paused condition %s (%s)", v.vrgName, status, reason)
+}
+
+// moveToSecondary deletes the PVCs, which remain while protected, and moves
+// the VRG to secondary
+func (v *vrgTest) moveToSecondary() {
+	By("Moving VRG to secondary " + v.vrgName)
+
+	for _, pvcName := range v.pvcNames {
+		err := k8sClient.Delete(context.TODO(), v.getPVC(pvcName))
+		Expect(err).NotTo(HaveOccurred(), "failed to delete PVC %s", pvcName)
+	}
+
+	Eventually(func() error {
+		vrg := v.getVRG(v.vrgName)
+		vrg.Spec.ReplicationState = ramendrv1alpha1.Secondary
+
+		return k8sClient.Update(context.TODO(), vrg)
+	}, timeout, interval).Should(Succeed(), "failed to move VRG %s to secondary", v.vrgName)
+}
+
+func (v *vrgTest) requestResync(requestID string) {
+	By("Requesting a resync of VRG " + v.vrgName)
+
+	Eventually(func() error {
+		vrg := v.getVRG(v.vrgName)
+		vrg.Spec.Resync = &ramendrv1alpha1.ResyncRequest{RequestID: requestID}
+
+		return k8sClient.Update(context.TODO(), vrg)
+	}, timeout, interval).Should(Succeed(), "failed to request a resync of VRG %s", v.vrgName)
+}
+
+func (v *vrgTest) waitForVolRepState(state volrep.ReplicationState) {
+	By(fmt.Sprintf("Waiting for VRs to be %s in %s", state, v.namespace))
+
+	Eventually(func() bool {
+		volRepList := &volrep.VolumeReplicationList{}
+		err := k8sClient.List(context.TODO(), volRepList, client.InNamespace(v.namespace))
+		Expect(err).NotTo(HaveOccurred(), "failed to get a list of VRs in namespace %s", v.namespace)
+
+		if len(volRepList.Items) != len(v.pvcNames) {
+			return false
+		}
+
+		for index := range volRepList.Items {
+			if volRepList.Items[index].Spec.ReplicationState != state {
+				return false
+			}
+		}
+
+		return true
+	}, vrgtimeout, vrginterval).Should(BeTrue(),
+		"while waiting for VRs of VRG %s to be %s", v.vrgName, state)
+}
+
+// updateVolRepsStatus reports the VRs as secondary at their current generation,
+// completed with the given reason, and degraded and resyncing as given
+func (v *vrgTest) updateVolRepsStatus(completedReason string, degraded, resyncing bool) {
+	By("Updating VolumeReplication resources status " + v.namespace)
+
+	conditionStatus := func(met bool) metav1.ConditionStatus {
+		if met {
+			return metav1.ConditionTrue
+		}
+
+		return metav1.ConditionFalse
+	}
+
+	degradedReason := volrepController.Healthy
+	if degraded {
+		degradedReason = volrepController.VolumeDegraded
+	}
+
+	resyncingReason := volrepController.NotResyncing
+	if resyncing {
+		resyncingReason = volrepController.ResycTriggered
+	}
+
+	volRepList := &volrep.VolumeReplicationList{}
+	err := k8sClient.List(context.TODO(), volRepList, client.InNamespace(v.namespace))
+	Expect(err).NotTo(HaveOccurred(), "failed to get a list of VRs in namespace %s", v.namespace)
+
+	for index := range volRepList.Items {
+		volRep := &volRepList.Items[index]
+		now := metav1.NewTime(time.Now())
+
+		volRep.Status = volrep.VolumeReplicationStatus{
+			State:              volrep.SecondaryState,
+			Message:            "volume is marked secondary",
+			ObservedGeneration: volRep.Generation,
+			Conditions: []metav1.Condition{
+				{
+					Type:               volrepController.ConditionCompleted,
+					Reason:             completedReason,
+					ObservedGeneration: volRep.Generation,
+					Status:             metav1.ConditionTrue,
+					LastTransitionTime: now,
+				},
+				{
+					Type:               volrepController.ConditionDegraded,
+					Reason:             degradedReason,
+					ObservedGeneration: volRep.Generation,
+					Status:             conditionStatus(degraded),
+					LastTransitionTime: now,
+				},
+				{
+					Type:               volrepController.ConditionResyncing,
+					Reason:             resyncingReason,
+					ObservedGeneration: volRep.Generation,
+					Status:             conditionStatus(resyncing),
+					LastTransitionTime: now,
+				},
+			},
+		}
+
+		err = k8sClient.Status().Update(context.TODO(), volRep)
+		Expect(err).NotTo(HaveOccurred(), "failed to update the status of VolRep %s", volRep.Name)
+	}
+}
+
+func (v *vrgTest) waitForResyncRequiredCondition(status metav1.ConditionStatus, reason string) {
+	By(fmt.Sprintf("Waiting for VRG %s ResyncRequired condition to be %s (%s)", v.vrgName, status, reason))
+
+	Eventually(func() bool {
+		vrg := v.getVRG(v.vrgName)
+		condition := checkConditions(vrg.Status.Conditions, vrgController.VRGConditionTypeResyncRequired)
+
+		return condition != nil && condition.Status == status && condition.Reason == reason
+	}, vrgtimeout, vrginterval).Should(BeTrue(),
+		"while waiting for VRG %s ResyncRequired condition %s (%s)", v.vrgName, status, reason)
+}
+
+func (v *vrgTest) waitForResyncCompleted(requestID string) {
+	By("Waiting for VRG to record the completed resync " + v.vrgName)
+
+	Eventually(func() bool {
+		vrg := v.getVRG(v.vrgName)
+		if len(vrg.Status.ProtectedPVCs) != len(v.pvcNames) {
+			return false
+		}
+
+		for index := range vrg.Status.ProtectedPVCs {
+			protectedPVC := &vrg.Status.ProtectedPVCs[index]
+			condition := checkConditions(protectedPVC.Conditions, vrgController.VRGConditionTypeResyncRequired)
+
+			if protectedPVC.ResyncRequestID != requestID || condition == nil ||
+				condition.Status != metav1.ConditionFalse ||
+				condition.Reason != vrgController.VRGConditionReasonResynced {
+				return false
+			}
+		}
+
+		return true
+	}, vrgtimeout, vrginterval).Should(BeTrue(),
+		"while waiting for VRG %s to complete resync %s", v.vrgName, requestID)
+}
+
 func (v *vrgTest) waitForNamespaceDeletion() {
 	By("Waiting for namespace deletion " + v.namespace)
 
@@ -1106,3 +1717,9 @@ func (s FakePVDeleter) DeletePVs(v interface{}, s3ProfileName string) error {
 
 	return nil
 }
+
+func (s FakePVDeleter) DeletePV(v interface{}, s3ProfileName string, pvName string) error {
+	delete(UploadedPVs, pvName)
+
+	return nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	volrep "github.com/csi-addons/volume-replication-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/controllers/util"
+)
+
+// isPaused returns true if the VRG is annotated as paused, for example during
+// storage maintenance or a manual investigation
+func (v *VRGInstance) isPaused() bool {
+	return v.instance.GetAnnotations()[VRGPausedAnnotation] == "true"
+}
+
+// processAsPaused reports the status of the VolumeReplication resources of the
+// VRG without creating, updating or deleting any of them, and without
+// uploading or deleting PV cluster data.  This applies to a VRG being deleted
+// as well, whose deletion is held until it is resumed.  No requeue is needed,
+// as removing the annotation triggers a reconcile.
+func (v *VRGInstance) processAsPaused() (ctrl.Result, error) {
+	v.log.Info("VolumeReplicationGroup is paused, skipping changes to VolumeReplication resources")
+
+	msg := "VolumeReplicationGroup is paused by annotation " + VRGPausedAnnotation
+	setVRGPausedCondition(&v.instance.Status.Conditions, v.instance.Generation, msg)
+
+	rmnutil.ReportIfNotPresent(v.reconciler.eventRecorder, v.instance, corev1.EventTypeNormal,
+		rmnutil.EventReasonPaused, msg)
+
+	v.reportVRsStatus()
+
+	if err := v.updateVRGStatus(true); err != nil {
+		v.log.Error(err, "VRG Status update failed")
+
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// updateResumedCondition flips the paused condition, if it was ever reported
+func (v *VRGInstance) updateResumedCondition() {
+	if findCondition(v.instance.Status.Conditions, VRGConditionTypePaused) == nil {
+		return
+	}
+
+	setVRGResumedCondition(&v.instance.Status.Conditions, v.instance.Generation,
+		"VolumeReplicationGroup is not paused")
+}
+
+// reportVRsStatus updates the protected PVC conditions from existing
+// VolumeReplication and VolumeGroupReplication resources, without modifying them
+func (v *VRGInstance) reportVRsStatus() {
+	state := volrep.Secondary
+	if v.instance.Spec.ReplicationState == ramendrv1alpha1.Primary {
+		state = volrep.Primary
+	}
+
+	groupPVCs := map[string][]*corev1.PersistentVolumeClaim{}
+
+	for idx := range v.pvcList.Items {
+		pvc := &v.pvcList.Items[idx]
+		log := v.log.WithValues("pvc", types.NamespacedName{Name: pvc.Name, Namespace: pvc.Namespace}.String())
+
+		if groupName := v.consistencyGroupOf(pvc); groupName != "" {
+			groupPVCs[groupName] = append(groupPVCs[groupName], pvc)
+
+			continue
+		}
+
+		volRep := &volrep.VolumeReplication{}
+		if err := v.reconciler.Get(v.ctx, types.NamespacedName{Name: pvc.Name, Namespace: pvc.Namespace},
+			volRep); err != nil {
+			if !errors.IsNotFound(err) {
+				log.Info("Failed to get VolumeReplication resource", "errorValue", err)
+			}
+
+			continue
+		}
+
+		if volRep.Spec.ReplicationState != state && volRep.Spec.ReplicationState != volrep.Resync {
+			msg := "VolumeReplicationGroup paused before VolumeReplication resource reached desired state"
+			v.updatePVCDataReadyCondition(pvc.Name, VRGConditionReasonProgressing, msg)
+
+			continue
+		}
+
+		if _, err := v.checkVRStatus(volRep); err != nil {
+			log.Info("Failed to check VolumeReplication resource status", "errorValue", err)
+		}
+	}
+
+	for groupName, pvcs := range groupPVCs {
+		vgr := newVolumeGroupReplication()
+		if err := v.reconciler.Get(v.ctx, types.NamespacedName{Name: v.vgrName(groupName),
+			Namespace: v.instance.Namespace}, vgr); err != nil {
+			continue
+		}
+
+		if _, err := v.checkVGRStatus(vgr, pvcs); err != nil {
+			v.log.Info("Failed to check VolumeGroupReplication resource status", "consistencyGroup", groupName,
+				"errorValue", err)
+		}
+	}
+}
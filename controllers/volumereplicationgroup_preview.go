@@ -0,0 +1,155 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// previewOnly returns true if the VRG is to only preview the protection of its
+// PVCs
+func (v *VRGInstance) previewOnly() bool {
+	return vrgPreviewOnly(v.instance)
+}
+
+// vrgPreviewOnly returns true if the VRG is to only preview the protection of
+// its PVCs.  A VRG that has started protecting PVCs, as indicated by its
+// finalizer, continues to protect them even if preview is requested.
+func vrgPreviewOnly(vrg *ramendrv1alpha1.VolumeReplicationGroup) bool {
+	return vrg.Spec.Preview && !containsString(vrg.GetFinalizers(), vrgFinalizerName)
+}
+
+// previewVRGsMapFunc maps a change to a VolumeReplicationClass or a
+// StorageClass to the VRGs that only preview the protection of their PVCs, as
+// their protection plan depends on these classes
+func previewVRGsMapFunc(mgr manager.Manager) handler.MapFunc {
+	return func(obj client.Object) []reconcile.Request {
+		log := ctrl.Log.WithName("previewmap").WithName("VolumeReplicationGroup")
+
+		vrgs := &ramendrv1alpha1.VolumeReplicationGroupList{}
+		if err := mgr.GetClient().List(context.TODO(), vrgs); err != nil {
+			log.Error(err, "Failed to get list of VolumeReplicationGroup resources")
+
+			return []reconcile.Request{}
+		}
+
+		req := []reconcile.Request{}
+
+		for idx := range vrgs.Items {
+			vrg := &vrgs.Items[idx]
+			if !vrgPreviewOnly(vrg) {
+				continue
+			}
+
+			log.Info("Found VolumeReplicationGroup previewing protection", "vrg", vrg.Name,
+				"namespace", vrg.Namespace, "class", obj.GetName())
+
+			req = append(req, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: vrg.Name, Namespace: vrg.Namespace},
+			})
+		}
+
+		return req
+	}
+}
+
+// processAsPreview reports the protection plan of the PVCs without creating
+// VolumeReplication resources or finalizers.  No requeue is needed, as changes
+// to the selected PVCs, and to VolumeReplicationClasses and StorageClasses,
+// trigger a reconcile.
+func (v *VRGInstance) processAsPreview() (ctrl.Result, error) {
+	v.log.Info("Previewing protection of PersistentVolumeClaims")
+
+	if err := v.updateVRGStatus(false); err != nil {
+		v.log.Error(err, "VRG Status update failed")
+
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// updateProtectionPreview computes the protection plan of the PVCs while the
+// VRG only previews their protection, and clears any previously reported plan
+// otherwise, including once the VRG has started protecting them
+func (v *VRGInstance) updateProtectionPreview() {
+	if !v.previewOnly() {
+		v.instance.Status.ProtectionPreview = nil
+
+		return
+	}
+
+	preview := &ramendrv1alpha1.ProtectionPreview{
+		ObservedGeneration: v.instance.Generation,
+		PVCs:               []ramendrv1alpha1.PVCPreview{},
+	}
+
+	for idx := range v.pvcList.Items {
+		preview.PVCs = append(preview.PVCs, v.previewPVC(idx))
+	}
+
+	v.instance.Status.ProtectionPreview = preview
+}
+
+// previewPVC returns the previewed protection of the pvc at the given index of
+// the PVC list, following the same checks as protecting it would
+func (v *VRGInstance) previewPVC(idx int) ramendrv1alpha1.PVCPreview {
+	pvc := &v.pvcList.Items[idx]
+	pvcNamespacedName := types.NamespacedName{Name: pvc.Name, Namespace: pvc.Namespace}
+	log := v.log.WithValues("pvc", pvcNamespacedName.String())
+
+	preview := ramendrv1alpha1.PVCPreview{
+		Name:             pvc.Name,
+		ConsistencyGroup: v.pvcConsistencyGroups[pvc.Name],
+	}
+
+	if skip, msg := skipPVC(pvc, log); skip {
+		preview.Result = ramendrv1alpha1.PVCSkipped
+		preview.Message = msg
+
+		return preview
+	}
+
+	if pvc.Spec.StorageClassName == nil {
+		preview.Result = ramendrv1alpha1.PVCNoReplicationClass
+		preview.Message = "PVC has no storage class"
+
+		return preview
+	}
+
+	className, err := v.selectVolumeReplicationClass(pvcNamespacedName)
+	if err != nil {
+		preview.Result = ramendrv1alpha1.PVCNoReplicationClass
+		preview.Message = err.Error()
+
+		return preview
+	}
+
+	preview.Result = ramendrv1alpha1.PVCProtectable
+	preview.VolumeReplicationClass = className
+
+	return preview
+}
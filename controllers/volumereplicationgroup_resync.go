@@ -0,0 +1,197 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+
+	volrep "github.com/csi-addons/volume-replication-operator/api/v1alpha1"
+	volrepController "github.com/csi-addons/volume-replication-operator/controllers"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/controllers/util"
+)
+
+// resyncPending returns true if the VRG spec requests a resync of the pvc, and
+// the resync has not yet completed for the requested RequestID
+func (v *VRGInstance) resyncPending(pvcName string) bool {
+	resync := v.instance.Spec.Resync
+
+	if resync == nil ||
+		v.instance.Spec.ReplicationState != ramendrv1alpha1.Secondary ||
+		!v.instance.GetDeletionTimestamp().IsZero() {
+		return false
+	}
+
+	if len(resync.PVCNames) != 0 && !containsString(resync.PVCNames, pvcName) {
+		return false
+	}
+
+	protectedPVC := v.findProtectedPVC(pvcName)
+
+	return protectedPVC == nil || protectedPVC.ResyncRequestID != resync.RequestID
+}
+
+// secondaryReplicationState returns the desired state of the VolumeReplication
+// resource replicating the given PVCs as secondary.  It is Resync if a resync is
+// pending for any of the PVCs, and Secondary otherwise.
+func (v *VRGInstance) secondaryReplicationState(pvcNames ...string) volrep.ReplicationState {
+	for _, pvcName := range pvcNames {
+		if v.resyncPending(pvcName) {
+			return volrep.Resync
+		}
+	}
+
+	return volrep.Secondary
+}
+
+// updatePVCResyncCondition updates the ResyncRequired condition of the pvc
+// replicated by the given VolumeReplication resource, as secondary:
+// - A VolumeReplication in Resync state has completed its resync when it is
+//   Completed and not Degraded, in which case the RequestID is recorded as
+//   complete for the pvc, and the VolumeReplication is moved back to Secondary
+//   in the next reconcile.
+// - A VolumeReplication that failed to demote or resync has diverged from its
+//   primary (split-brain), and one that is Degraded but not Resyncing will not
+//   recover without a resync, and hence requires a resync.
+func (v *VRGInstance) updatePVCResyncCondition(volRep *volrep.VolumeReplication) {
+	protectedPVC := v.findProtectedPVC(volRep.Name)
+	if protectedPVC == nil {
+		return
+	}
+
+	if volRep.Generation != volRep.Status.ObservedGeneration {
+		return
+	}
+
+	if volRep.Spec.ReplicationState == volrep.Resync {
+		v.updatePVCResyncProgress(protectedPVC, volRep)
+
+		return
+	}
+
+	completed := findCondition(volRep.Status.Conditions, volrepController.ConditionCompleted)
+	if completed != nil && completed.Status == metav1.ConditionFalse &&
+		(completed.Reason == volrepController.FailedToDemote || completed.Reason == volrepController.FailedToResync) {
+		v.setPVCResyncRequired(protectedPVC, VRGConditionReasonSplitBrain,
+			fmt.Sprintf("Volume has diverged from its primary (%s), and requires a resync", completed.Reason))
+
+		return
+	}
+
+	degraded, _ := isVRConditionMet(volRep, volrepController.ConditionDegraded, metav1.ConditionTrue)
+	resyncing, _ := isVRConditionMet(volRep, volrepController.ConditionResyncing, metav1.ConditionTrue)
+
+	if degraded && !resyncing {
+		v.setPVCResyncRequired(protectedPVC, VRGConditionReasonDegraded,
+			"Volume is degraded and not resyncing, and requires a resync")
+
+		return
+	}
+
+	// Clear a previously reported resync requirement, if any
+	if findCondition(protectedPVC.Conditions, VRGConditionTypeResyncRequired) != nil {
+		setVRGResyncNotRequiredCondition(&protectedPVC.Conditions, v.instance.Generation,
+			VRGConditionReasonHealthy, "Volume does not require a resync")
+	}
+}
+
+func (v *VRGInstance) updatePVCResyncProgress(protectedPVC *ramendrv1alpha1.ProtectedPVC,
+	volRep *volrep.VolumeReplication) {
+	if failed, _ := isVRConditionMet(volRep, volrepController.ConditionCompleted, metav1.ConditionFalse); failed {
+		v.setPVCResyncRequired(protectedPVC, VRGConditionReasonSplitBrain,
+			"Resync of volume failed, and requires a resync")
+
+		return
+	}
+
+	completed, _ := isVRConditionMet(volRep, volrepController.ConditionCompleted, metav1.ConditionTrue)
+	notDegraded, _ := isVRConditionMet(volRep, volrepController.ConditionDegraded, metav1.ConditionFalse)
+
+	if !completed || !notDegraded {
+		setVRGResyncRequiredCondition(&protectedPVC.Conditions, v.instance.Generation,
+			VRGConditionReasonResyncing, "Resync of volume in progress")
+
+		return
+	}
+
+	protectedPVC.ResyncRequestID = v.instance.Spec.Resync.RequestID
+
+	msg := fmt.Sprintf("Resync of volume completed for request %s", protectedPVC.ResyncRequestID)
+	setVRGResyncNotRequiredCondition(&protectedPVC.Conditions, v.instance.Generation,
+		VRGConditionReasonResynced, msg)
+
+	rmnutil.ReportIfNotPresent(v.reconciler.eventRecorder, v.instance, corev1.EventTypeNormal,
+		rmnutil.EventReasonResyncSuccess, fmt.Sprintf("PVC %s: %s", protectedPVC.Name, msg))
+}
+
+func (v *VRGInstance) setPVCResyncRequired(protectedPVC *ramendrv1alpha1.ProtectedPVC, reason, message string) {
+	setVRGResyncRequiredCondition(&protectedPVC.Conditions, v.instance.Generation, reason, message)
+
+	rmnutil.ReportIfNotPresent(v.reconciler.eventRecorder, v.instance, corev1.EventTypeWarning,
+		rmnutil.EventReasonResyncRequired, fmt.Sprintf("PVC %s: %s", protectedPVC.Name, message))
+}
+
+// updateVRGResyncRequiredCondition updates the VRG summary level resync required
+// condition based on the individual PVC's condition.  A PVC that diverged from
+// its primary takes precedence over a degraded PVC, which takes precedence over
+// a PVC that is being resynced.  The condition is not reported if none of the
+// PVCs report it.
+func (v *VRGInstance) updateVRGResyncRequiredCondition() {
+	reported := false
+	reasonPriority := map[string]int{
+		VRGConditionReasonSplitBrain: 3,
+		VRGConditionReasonDegraded:   2,
+		VRGConditionReasonResyncing:  1,
+	}
+
+	var required *metav1.Condition
+
+	for index := range v.instance.Status.ProtectedPVCs {
+		condition := findCondition(v.instance.Status.ProtectedPVCs[index].Conditions, VRGConditionTypeResyncRequired)
+		if condition == nil {
+			continue
+		}
+
+		reported = true
+
+		if condition.Status == metav1.ConditionTrue &&
+			(required == nil || reasonPriority[condition.Reason] > reasonPriority[required.Reason]) {
+			required = condition
+		}
+	}
+
+	if !reported {
+		return
+	}
+
+	if required != nil {
+		msg := "One or more PVCs of the VolumeReplicationGroup require a resync"
+		if required.Reason == VRGConditionReasonResyncing {
+			msg = "Resync of one or more PVCs of the VolumeReplicationGroup is in progress"
+		}
+
+		setVRGResyncRequiredCondition(&v.instance.Status.Conditions, v.instance.Generation, required.Reason, msg)
+
+		return
+	}
+
+	setVRGResyncNotRequiredCondition(&v.instance.Status.Conditions, v.instance.Generation,
+		VRGConditionReasonHealthy, "PVCs of the VolumeReplicationGroup do not require a resync")
+}
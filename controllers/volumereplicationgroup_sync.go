@@ -0,0 +1,119 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// In Sync replication mode, the volumes are backed by storage stretched across
+// the clusters, so that a volume is replicated as soon as it is written to.  A
+// primary is protected once its VolumeReplication is promoted, and a secondary
+// is neither demoted nor resynced: once its PVC is released, the
+// VolumeReplication is deleted, leaving the volume to the new primary.
+
+// replicationModeSync returns true if the VRG replicates its volumes
+// synchronously
+func (v *VRGInstance) replicationModeSync() bool {
+	return v.instance.Spec.ReplicationMode == ramendrv1alpha1.ReplicationModeSync
+}
+
+// replicationClassScheduleMatches returns true if the scheduling interval of a
+// VolumeReplicationClass, if found in its parameters, matches the VRG: equal
+// to its interval in Async mode, and absent in Sync mode
+func (v *VRGInstance) replicationClassScheduleMatches(schedulingInterval string, found bool) bool {
+	if v.replicationModeSync() {
+		return !found
+	}
+
+	return found && schedulingInterval == v.instance.Spec.SchedulingInterval
+}
+
+// reconcileVRAsSecondarySync releases the volume of a pvc, once the pvc is
+// ready for secondary, by deleting its VolumeReplication resource, with no
+// demotion.  It returns whether a requeue is required and whether further
+// processing of the pvc is to be skipped, as reconcileVRAsSecondary does.
+func (v *VRGInstance) reconcileVRAsSecondarySync(pvc *corev1.PersistentVolumeClaim,
+	log logr.Logger) (bool, bool) {
+	const (
+		requeue bool = true
+		skip    bool = true
+	)
+
+	if !v.isPVCReadyForSecondary(pvc, log) {
+		return !requeue, skip
+	}
+
+	if err := v.deleteVR(types.NamespacedName{Name: pvc.Name, Namespace: pvc.Namespace}, log); err != nil {
+		return requeue, skip
+	}
+
+	if err := v.preparePVCForVRDeletion(pvc, log); err != nil {
+		log.Info("Requeuing due to failure in preparing PersistentVolumeClaim for VolumeReplication deletion",
+			"errorValue", err)
+
+		return requeue, skip
+	}
+
+	v.updatePVCSecondarySyncConditions(pvc.Name)
+
+	return !requeue, !skip
+}
+
+// reconcileVGRsAsSecondarySync is the consistency group counterpart of
+// reconcileVRAsSecondarySync, for groups whose PVCs are all ready for
+// secondary.  It returns true if a requeue is required.
+func (v *VRGInstance) reconcileVGRsAsSecondarySync(groupPVCs map[string][]*corev1.PersistentVolumeClaim) bool {
+	requeue := false
+
+	for groupName, pvcs := range groupPVCs {
+		log := v.log.WithValues("consistencyGroup", groupName)
+
+		if err := v.deleteVGR(groupName, log); err != nil {
+			requeue = true
+
+			continue
+		}
+
+		for _, pvc := range pvcs {
+			if err := v.preparePVCForVRDeletion(pvc, log); err != nil {
+				log.Info("Requeuing due to failure in preparing PersistentVolumeClaim for VolumeGroupReplication"+
+					" deletion", "pvc", pvc.Name, "errorValue", err)
+
+				requeue = true
+
+				continue
+			}
+
+			v.updatePVCSecondarySyncConditions(pvc.Name)
+		}
+	}
+
+	return requeue
+}
+
+// updatePVCSecondarySyncConditions reports the volume of a released pvc as
+// replicating and protected, as the new primary holds the same data
+func (v *VRGInstance) updatePVCSecondarySyncConditions(pvcName string) {
+	msg := "Volume is synchronously replicated, and released to the primary"
+	v.updatePVCDataReadyCondition(pvcName, VRGConditionReasonReplicating, msg)
+	v.updatePVCDataProtectedCondition(pvcName, VRGConditionReasonDataProtected, msg)
+}
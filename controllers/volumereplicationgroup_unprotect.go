@@ -0,0 +1,217 @@
+/*
+Copyright 2021 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	volrep "github.com/csi-addons/volume-replication-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/controllers/util"
+)
+
+// vrgProtectsPVC returns true if the pvc is listed among the protected PVCs in
+// the VRG status
+func vrgProtectsPVC(vrg *ramendrv1alpha1.VolumeReplicationGroup, pvcName string) bool {
+	for index := range vrg.Status.ProtectedPVCs {
+		if vrg.Status.ProtectedPVCs[index].Name == pvcName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// reconcileUnprotectedPVCs unprotects PVCs that are listed as protected in the
+// VRG status, but are no longer selected by the VRG, as their labels changed.
+// Once a pvc is unprotected it is dropped from the VRG status.  It returns true
+// if a requeue is required.
+func (v *VRGInstance) reconcileUnprotectedPVCs() bool {
+	requeue := false
+	selected := map[string]bool{}
+
+	for idx := range v.pvcList.Items {
+		selected[v.pvcList.Items[idx].Name] = true
+	}
+
+	protectedPVCs := make([]ramendrv1alpha1.ProtectedPVC, len(v.instance.Status.ProtectedPVCs))
+	copy(protectedPVCs, v.instance.Status.ProtectedPVCs)
+
+	for idx := range protectedPVCs {
+		protectedPVC := &protectedPVCs[idx]
+		if selected[protectedPVC.Name] {
+			continue
+		}
+
+		log := v.log.WithValues("pvc", types.NamespacedName{Name: protectedPVC.Name,
+			Namespace: v.instance.Namespace}.String())
+
+		requeueResult, done := v.unprotectPVC(protectedPVC, log)
+		if requeueResult {
+			requeue = true
+
+			continue
+		}
+
+		if !done {
+			continue
+		}
+
+		v.removeProtectedPVC(protectedPVC.Name)
+
+		log.Info("Unprotected PersistentVolumeClaim no longer selected by VolumeReplicationGroup")
+		rmnutil.ReportIfNotPresent(v.reconciler.eventRecorder, v.instance, corev1.EventTypeNormal,
+			rmnutil.EventReasonPVCUnprotected,
+			fmt.Sprintf("PVC %s is no longer selected and has been unprotected", protectedPVC.Name))
+	}
+
+	return requeue
+}
+
+// unprotectPVC undoes the changes made to protect a pvc:
+// - Deletes its VolumeReplication resource, once it reaches the VRG state
+// - Deletes its PV cluster data from the S3 stores, if the VRG is primary
+// - Undoes the retention of its PV, and removes its finalizer and annotation
+// A pvc in a consistency group remains replicated by the VolumeGroupReplication
+// resource until the storage stops selecting it, hence only the pvc is cleaned.
+// It returns a requeue if any of the steps failed, and done when all are done.
+func (v *VRGInstance) unprotectPVC(protectedPVC *ramendrv1alpha1.ProtectedPVC,
+	log logr.Logger) (bool, bool) {
+	const (
+		requeue bool = true
+		done    bool = true
+	)
+
+	pvcNamespacedName := types.NamespacedName{Name: protectedPVC.Name, Namespace: v.instance.Namespace}
+
+	if protectedPVC.ConsistencyGroup == "" {
+		requeueResult, vrDeleted := v.unprotectVR(pvcNamespacedName, log)
+		if requeueResult || !vrDeleted {
+			return requeueResult, !done
+		}
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := v.reconciler.Get(v.ctx, pvcNamespacedName, pvc); err != nil {
+		if errors.IsNotFound(err) {
+			return !requeue, done
+		}
+
+		log.Info("Requeuing due to failure in getting PersistentVolumeClaim", "errorValue", err)
+
+		return requeue, !done
+	}
+
+	// Delete PV cluster data only if an upload was attempted
+	if v.instance.Spec.ReplicationState == ramendrv1alpha1.Primary && pvc.Spec.VolumeName != "" &&
+		findCondition(protectedPVC.Conditions, VRGConditionTypeClusterDataProtected) != nil {
+		if err := v.deletePVFromS3Stores(pvc.Spec.VolumeName, log); err != nil {
+			log.Info("Requeuing due to failure in deleting PV cluster data from S3 stores", "errorValue", err)
+
+			return requeue, !done
+		}
+	}
+
+	if err := v.preparePVCForVRDeletion(pvc, log); err != nil {
+		log.Info("Requeuing due to failure in preparing PersistentVolumeClaim for unprotection",
+			"errorValue", err)
+
+		return requeue, !done
+	}
+
+	return !requeue, done
+}
+
+// unprotectVR ensures that the VolumeReplication resource of the pvc, if created
+// by the VRG, reaches the VRG state before deleting it.  It returns a requeue on
+// failures, and whether the resource is deleted.
+func (v *VRGInstance) unprotectVR(vrNamespacedName types.NamespacedName, log logr.Logger) (bool, bool) {
+	const (
+		requeue bool = true
+		deleted bool = true
+	)
+
+	volRep := &volrep.VolumeReplication{}
+	if err := v.reconciler.Get(v.ctx, vrNamespacedName, volRep); err != nil {
+		if errors.IsNotFound(err) {
+			return !requeue, deleted
+		}
+
+		log.Info("Requeuing due to failure in getting VolumeReplication resource", "errorValue", err)
+
+		return requeue, !deleted
+	}
+
+	if !metav1.IsControlledBy(volRep, v.instance) {
+		log.Info("VolumeReplication resource not owned by VolumeReplicationGroup, skipping its deletion")
+
+		return !requeue, deleted
+	}
+
+	state := volrep.Secondary
+	if v.instance.Spec.ReplicationState == ramendrv1alpha1.Primary {
+		state = volrep.Primary
+	}
+
+	available, err := v.updateVR(volRep, state, log)
+	if err != nil {
+		return requeue, !deleted
+	}
+
+	// Wait for the VolumeReplication resource to reach the desired state, its
+	// status update triggers a reconcile
+	if !available {
+		return !requeue, !deleted
+	}
+
+	if err := v.deleteVR(vrNamespacedName, log); err != nil {
+		return requeue, !deleted
+	}
+
+	return !requeue, deleted
+}
+
+func (v *VRGInstance) deletePVFromS3Stores(pvName string, log logr.Logger) error {
+	log.Info("Delete PV from s3 stores", "PV", pvName, "s3Profiles", v.instance.Spec.S3ProfileList)
+
+	for _, s3ProfileName := range v.instance.Spec.S3ProfileList {
+		if err := v.reconciler.PVDeleter.DeletePV(v, s3ProfileName, pvName); err != nil {
+			return fmt.Errorf("error deleting PV %s using profile %s, err %w", pvName, s3ProfileName, err)
+		}
+	}
+
+	return nil
+}
+
+// removeProtectedPVC drops the pvc from the protected PVCs in the VRG status
+func (v *VRGInstance) removeProtectedPVC(pvcName string) {
+	for index := range v.instance.Status.ProtectedPVCs {
+		if v.instance.Status.ProtectedPVCs[index].Name == pvcName {
+			v.instance.Status.ProtectedPVCs = append(v.instance.Status.ProtectedPVCs[:index],
+				v.instance.Status.ProtectedPVCs[index+1:]...)
+
+			return
+		}
+	}
+}
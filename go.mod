@@ -19,6 +19,7 @@ require (
 	github.com/prometheus/common v0.15.0
 	go.uber.org/zap v1.18.1
 	k8s.io/api v0.21.3
+	k8s.io/apiextensions-apiserver v0.21.0
 	k8s.io/apimachinery v0.21.3
 	k8s.io/client-go v12.0.0+incompatible
 	k8s.io/kube-openapi v0.0.0-20210305001622-591a79e4bda7
@@ -17,6 +17,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -27,6 +28,7 @@ import (
 	viewv1beta1 "github.com/open-cluster-management/multicloud-operators-foundation/pkg/apis/view/v1beta1"
 	plrv1 "github.com/open-cluster-management/multicloud-operators-placementrule/pkg/apis/apps/v1"
 	uberzap "go.uber.org/zap"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -36,6 +38,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+	ramendrv1beta1 "github.com/ramendr/ramen/api/v1beta1"
 	"github.com/ramendr/ramen/controllers"
 	// +kubebuilder:scaffold:imports
 )
@@ -51,6 +54,8 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(ramendrv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(ramendrv1beta1.AddToScheme(scheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -81,12 +86,12 @@ func newManager() (ctrl.Manager, error) {
 	}
 
 	controllerType = ramenConfig.RamenControllerType
-	if !(controllerType == ramendrv1alpha1.DRCluster || controllerType == ramendrv1alpha1.DRHub) {
+	if !(controllerType == ramendrv1alpha1.DRClusterType || controllerType == ramendrv1alpha1.DRHubType) {
 		return nil, fmt.Errorf("invalid controller type specified (%s), should be one of [%s|%s]",
-			controllerType, ramendrv1alpha1.DRHub, ramendrv1alpha1.DRCluster)
+			controllerType, ramendrv1alpha1.DRHubType, ramendrv1alpha1.DRClusterType)
 	}
 
-	if controllerType == ramendrv1alpha1.DRHub {
+	if controllerType == ramendrv1alpha1.DRHubType {
 		utilruntime.Must(plrv1.AddToScheme(scheme))
 		utilruntime.Must(ocmworkv1.AddToScheme(scheme))
 		utilruntime.Must(spokeClusterV1.AddToScheme(scheme))
@@ -104,17 +109,28 @@ func newManager() (ctrl.Manager, error) {
 }
 
 func setupReconcilers(mgr ctrl.Manager) {
-	if controllerType == ramendrv1alpha1.DRHub {
+	if controllerType == ramendrv1alpha1.DRHubType {
 		if err := (&controllers.DRPolicyReconciler{
 			Client:            mgr.GetClient(),
 			APIReader:         mgr.GetAPIReader(),
 			Scheme:            mgr.GetScheme(),
 			ObjectStoreGetter: controllers.S3ObjectStoreGetter(),
+			MCVGetter:         controllers.ManagedClusterViewGetterImpl{Client: mgr.GetClient()},
 		}).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "DRPolicy")
 			os.Exit(1)
 		}
 
+		if err := (&controllers.DRClusterReconciler{
+			Client:    mgr.GetClient(),
+			APIReader: mgr.GetAPIReader(),
+			Log:       ctrl.Log.WithName("controllers").WithName("DRCluster"),
+			MCVGetter: controllers.ManagedClusterViewGetterImpl{Client: mgr.GetClient()},
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "DRCluster")
+			os.Exit(1)
+		}
+
 		drpcReconciler := (&controllers.DRPlacementControlReconciler{
 			Client:    mgr.GetClient(),
 			APIReader: mgr.GetAPIReader(),
@@ -128,6 +144,34 @@ func setupReconcilers(mgr ctrl.Manager) {
 			os.Exit(1)
 		}
 
+		if err := (&controllers.DRActionRequestReconciler{
+			Client:    mgr.GetClient(),
+			APIReader: mgr.GetAPIReader(),
+			Log:       ctrl.Log.WithName("controllers").WithName("DRActionRequest"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "DRActionRequest")
+			os.Exit(1)
+		}
+
+		if err := (&controllers.DRClusterActionReconciler{
+			Client:    mgr.GetClient(),
+			APIReader: mgr.GetAPIReader(),
+			Log:       ctrl.Log.WithName("controllers").WithName("DRClusterAction"),
+			MCVGetter: controllers.ManagedClusterViewGetterImpl{Client: mgr.GetClient()},
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "DRClusterAction")
+			os.Exit(1)
+		}
+
+		if err := (&controllers.DRPlacementControlGroupReconciler{
+			Client:    mgr.GetClient(),
+			APIReader: mgr.GetAPIReader(),
+			Log:       ctrl.Log.WithName("controllers").WithName("DRPlacementControlGroup"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "DRPlacementControlGroup")
+			os.Exit(1)
+		}
+
 		return
 	}
 
@@ -144,6 +188,87 @@ func setupReconcilers(mgr ctrl.Manager) {
 		setupLog.Error(err, "unable to create controller", "controller", "VolumeReplicationGroup")
 		os.Exit(1)
 	}
+
+	if err := (&controllers.ClusterInventoryReconciler{
+		Client:    mgr.GetClient(),
+		APIReader: mgr.GetAPIReader(),
+		Log:       ctrl.Log.WithName("controllers").WithName("ClusterInventory"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterInventory")
+		os.Exit(1)
+	}
+}
+
+// setupWebhooks sets up the validating and conversion webhooks of the resources
+// reconciled by the controller type, if enabled using the ENABLE_WEBHOOKS
+// environment variable.  Resources are migrated to their storage version once
+// the conversion webhook is available.
+func setupWebhooks(mgr ctrl.Manager) {
+	if os.Getenv("ENABLE_WEBHOOKS") != "true" {
+		return
+	}
+
+	crdNames := controllers.DRClusterCRDNames
+	if controllerType == ramendrv1alpha1.DRHubType {
+		crdNames = controllers.HubCRDNames
+	}
+
+	if err := mgr.Add(&controllers.StorageVersionMigrator{
+		Client:        mgr.GetClient(),
+		APIReader:     mgr.GetAPIReader(),
+		Log:           ctrl.Log.WithName("storageversionmigrator"),
+		CRDNames:      crdNames,
+		RetryInterval: time.Minute,
+	}); err != nil {
+		setupLog.Error(err, "unable to add storage version migrator")
+		os.Exit(1)
+	}
+
+	if controllerType == ramendrv1alpha1.DRHubType {
+		if err := (&ramendrv1alpha1.DRPolicy{}).SetupWebhookWithManager(mgr,
+			controllers.ValidateS3Profile); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DRPolicy")
+			os.Exit(1)
+		}
+
+		if err := (&ramendrv1alpha1.DRPlacementControl{}).SetupWebhookWithManager(mgr,
+			controllers.ValidateS3Profile); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DRPlacementControl")
+			os.Exit(1)
+		}
+
+		if err := (&ramendrv1alpha1.DRCluster{}).SetupWebhookWithManager(mgr,
+			controllers.ValidateS3Profile); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DRCluster")
+			os.Exit(1)
+		}
+
+		if err := (&ramendrv1alpha1.DRActionRequest{}).SetupWebhookWithManager(mgr,
+			controllers.ValidateS3Profile); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DRActionRequest")
+			os.Exit(1)
+		}
+
+		if err := (&ramendrv1alpha1.DRClusterAction{}).SetupWebhookWithManager(mgr,
+			controllers.ValidateS3Profile); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DRClusterAction")
+			os.Exit(1)
+		}
+
+		if err := (&ramendrv1alpha1.DRPlacementControlGroup{}).SetupWebhookWithManager(mgr,
+			controllers.ValidateS3Profile); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DRPlacementControlGroup")
+			os.Exit(1)
+		}
+
+		return
+	}
+
+	if err := (&ramendrv1alpha1.VolumeReplicationGroup{}).SetupWebhookWithManager(mgr,
+		controllers.ValidateS3Profile); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "VolumeReplicationGroup")
+		os.Exit(1)
+	}
 }
 
 func main() {
@@ -154,6 +279,7 @@ func main() {
 	}
 
 	setupReconcilers(mgr)
+	setupWebhooks(mgr)
 
 	// +kubebuilder:scaffold:builder
 	if err := mgr.AddHealthzCheck("health", healthz.Ping); err != nil {